@@ -1,9 +1,31 @@
 package main
 
 import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/config"
 	"github.com/hiago-balbino/hex-architecture-template/internal/handlers"
 )
 
 func main() {
-	handlers.NewServer().Start()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	server, err := handlers.NewServer(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := server.Start(ctx); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,219 @@
+// Package auth verifies the JSON Web Tokens that authenticate API requests.
+package auth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+)
+
+var (
+	ErrMalformedToken       = errors.New("malformed token")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrUnknownKey           = errors.New("no key matches the token")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrExpired              = errors.New("token is expired")
+	ErrNotYetValid          = errors.New("token is not valid yet")
+	ErrInvalidIssuer        = errors.New("invalid issuer")
+	ErrInvalidAudience      = errors.New("invalid audience")
+	ErrMissingClaim         = errors.New("missing required claim")
+)
+
+type header struct {
+	Algorithm string          `json:"alg"`
+	KeyID     string          `json:"kid"`
+	Critical  json.RawMessage `json:"crit"`
+}
+
+type claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss"`
+	Audience  audience `json:"aud"`
+	ExpiresAt *float64 `json:"exp"`
+	NotBefore *float64 `json:"nbf"`
+	IssuedAt  *float64 `json:"iat"`
+	Scope     string   `json:"scope"`
+	Tenant    string   `json:"tenant"`
+}
+
+// audience is a single string or an array of strings.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(data, []byte(`"`)) {
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*a = audience{value}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+// Verifier checks the signature and the registered claims of a token.
+// Tokens must carry sub and exp; iss and aud are only checked when the
+// verifier expects them. leeway absorbs the clock skew with the issuer.
+type Verifier struct {
+	keys     []Key
+	issuer   string
+	audience string
+	leeway   time.Duration
+	clock    clock.Clock
+}
+
+func NewVerifier(keys []Key, issuer string, audience string, leeway time.Duration, clock clock.Clock) Verifier {
+	return Verifier{
+		keys:     keys,
+		issuer:   issuer,
+		audience: audience,
+		leeway:   leeway,
+		clock:    clock,
+	}
+}
+
+// Enabled is false when no key is configured, in which case requests are
+// not authenticated.
+func (v Verifier) Enabled() bool {
+	return len(v.keys) > 0
+}
+
+// Verify returns the principal of a compact serialized JWS token, bound to
+// the tenant of its tenant claim when it has one.
+func (v Verifier) Verify(token string) (domain.Principal, error) {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return domain.Principal{}, ErrMalformedToken
+	}
+
+	var tokenHeader header
+	if err := decodeJSON(segments[0], &tokenHeader); err != nil {
+		return domain.Principal{}, ErrMalformedToken
+	}
+	// No extension is understood, so tokens requiring one are rejected.
+	if tokenHeader.Critical != nil {
+		return domain.Principal{}, ErrMalformedToken
+	}
+	signature, err := decodeSegment(segments[2])
+	if err != nil {
+		return domain.Principal{}, ErrMalformedToken
+	}
+	if err := v.verifySignature(tokenHeader, []byte(segments[0]+"."+segments[1]), signature); err != nil {
+		return domain.Principal{}, err
+	}
+
+	var tokenClaims claims
+	if err := decodeJSON(segments[1], &tokenClaims); err != nil {
+		return domain.Principal{}, ErrMalformedToken
+	}
+	if err := v.validate(tokenClaims); err != nil {
+		return domain.Principal{}, err
+	}
+	return domain.NewPrincipal(tokenClaims.Subject, strings.Fields(tokenClaims.Scope)).InTenant(tokenClaims.Tenant), nil
+}
+
+func (v Verifier) verifySignature(tokenHeader header, signed []byte, signature []byte) error {
+	switch tokenHeader.Algorithm {
+	case HS256, RS256, ES256:
+	default:
+		return ErrUnsupportedAlgorithm
+	}
+
+	found := false
+	for _, key := range v.keys {
+		// Matching the algorithm of the key keeps an RSA public key from
+		// being used as an HS256 secret.
+		if key.Algorithm != tokenHeader.Algorithm || (key.ID != "" && tokenHeader.KeyID != "" && key.ID != tokenHeader.KeyID) {
+			continue
+		}
+		found = true
+		if key.verify(signed, signature) {
+			return nil
+		}
+	}
+	if !found {
+		return ErrUnknownKey
+	}
+	return ErrInvalidSignature
+}
+
+func (k Key) verify(signed []byte, signature []byte) bool {
+	digest := sha256.Sum256(signed)
+	switch k.Algorithm {
+	case HS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case RS256:
+		return rsa.VerifyPKCS1v15(k.public.(*rsa.PublicKey), crypto.SHA256, digest[:], signature) == nil
+	case ES256:
+		// JWS signs with the raw r || s pair rather than ASN.1.
+		if len(signature) != 64 {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		return ecdsa.Verify(k.public.(*ecdsa.PublicKey), digest[:], r, s)
+	default:
+		return false
+	}
+}
+
+func (v Verifier) validate(tokenClaims claims) error {
+	if tokenClaims.Subject == "" || tokenClaims.ExpiresAt == nil {
+		return ErrMissingClaim
+	}
+
+	now := v.clock.Now()
+	if !now.Before(numericDate(*tokenClaims.ExpiresAt).Add(v.leeway)) {
+		return ErrExpired
+	}
+	if tokenClaims.NotBefore != nil && now.Add(v.leeway).Before(numericDate(*tokenClaims.NotBefore)) {
+		return ErrNotYetValid
+	}
+	if tokenClaims.IssuedAt != nil && now.Add(v.leeway).Before(numericDate(*tokenClaims.IssuedAt)) {
+		return ErrNotYetValid
+	}
+
+	if v.issuer != "" && tokenClaims.Issuer != v.issuer {
+		return ErrInvalidIssuer
+	}
+	if v.audience != "" && !containsAudience(tokenClaims.Audience, v.audience) {
+		return ErrInvalidAudience
+	}
+	return nil
+}
+
+func numericDate(seconds float64) time.Time {
+	whole, fraction := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(fraction*float64(time.Second)))
+}
+
+func containsAudience(audiences audience, expected string) bool {
+	for _, candidate := range audiences {
+		if candidate == expected {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeJSON(segment string, value any) error {
+	data, err := decodeSegment(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, value)
+}
@@ -0,0 +1,259 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	fixedNow = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	secret   = []byte("0123456789abcdef0123456789abcdef")
+)
+
+func TestVerify_ShouldReturnPrincipalOfHS256Token(t *testing.T) {
+	key, err := NewHMACKey("", secret)
+	assert.NoError(t, err)
+	token := signHS256(t, map[string]any{"alg": HS256}, validClaims())
+
+	principal, err := newVerifier(key).Verify(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewPrincipal("user-1", []string{"messages:read", "messages:write"}), principal)
+}
+
+func TestVerify_ShouldBindPrincipalToTenantClaim(t *testing.T) {
+	key, err := NewHMACKey("", secret)
+	assert.NoError(t, err)
+	claims := validClaims()
+	claims["tenant"] = "acme"
+	token := signHS256(t, map[string]any{"alg": HS256}, claims)
+
+	principal, err := newVerifier(key).Verify(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", principal.Tenant)
+}
+
+func TestVerify_ShouldAcceptRS256Token(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	key, err := NewPublicKey("rsa-1", &private.PublicKey)
+	assert.NoError(t, err)
+
+	signingInput := encode(t, map[string]any{"alg": RS256, "kid": "rsa-1"}) + "." + encode(t, validClaims())
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, private, crypto.SHA256, digest[:])
+	assert.NoError(t, err)
+
+	principal, err := newVerifier(key).Verify(signingInput + "." + base64.RawURLEncoding.EncodeToString(signature))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", principal.Subject)
+}
+
+func TestVerify_ShouldAcceptES256Token(t *testing.T) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	key, err := NewPublicKey("", &private.PublicKey)
+	assert.NoError(t, err)
+
+	signingInput := encode(t, map[string]any{"alg": ES256}) + "." + encode(t, validClaims())
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, private, digest[:])
+	assert.NoError(t, err)
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	principal, err := newVerifier(key).Verify(signingInput + "." + base64.RawURLEncoding.EncodeToString(signature))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", principal.Subject)
+}
+
+func TestVerify_ShouldRejectTamperedToken(t *testing.T) {
+	key, err := NewHMACKey("", secret)
+	assert.NoError(t, err)
+	token := signHS256(t, map[string]any{"alg": HS256}, validClaims())
+	segments := strings.Split(token, ".")
+	claims := validClaims()
+	claims["sub"] = "admin"
+	segments[1] = encode(t, claims)
+
+	_, err = newVerifier(key).Verify(strings.Join(segments, "."))
+
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestVerify_ShouldRejectUnsignedToken(t *testing.T) {
+	key, err := NewHMACKey("", secret)
+	assert.NoError(t, err)
+	token := encode(t, map[string]any{"alg": "none"}) + "." + encode(t, validClaims()) + "."
+
+	_, err = newVerifier(key).Verify(token)
+
+	assert.ErrorIs(t, err, ErrUnsupportedAlgorithm)
+}
+
+func TestVerify_ShouldNotUsePublicKeyAsHMACSecret(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	key, err := NewPublicKey("", &private.PublicKey)
+	assert.NoError(t, err)
+	token := signHS256(t, map[string]any{"alg": HS256}, validClaims())
+
+	_, err = newVerifier(key).Verify(token)
+
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestVerify_ShouldRejectTokenWhoseKeyIDIsUnknown(t *testing.T) {
+	key, err := NewHMACKey("key-1", secret)
+	assert.NoError(t, err)
+	token := signHS256(t, map[string]any{"alg": HS256, "kid": "key-2"}, validClaims())
+
+	_, err = newVerifier(key).Verify(token)
+
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestVerify_ShouldRejectTokenRequiringExtensions(t *testing.T) {
+	key, err := NewHMACKey("", secret)
+	assert.NoError(t, err)
+	token := signHS256(t, map[string]any{"alg": HS256, "crit": []string{"exp"}}, validClaims())
+
+	_, err = newVerifier(key).Verify(token)
+
+	assert.ErrorIs(t, err, ErrMalformedToken)
+}
+
+func TestVerify_ShouldRejectMalformedToken(t *testing.T) {
+	key, err := NewHMACKey("", secret)
+	assert.NoError(t, err)
+
+	for _, token := range []string{"", "a.b", "a.b.c.d", "!.!.!"} {
+		_, err = newVerifier(key).Verify(token)
+
+		assert.ErrorIs(t, err, ErrMalformedToken, token)
+	}
+}
+
+func TestVerify_ShouldCheckTimesWithLeeway(t *testing.T) {
+	key, err := NewHMACKey("", secret)
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		claims   map[string]any
+		expected error
+	}{
+		"expired within leeway":       {claims: map[string]any{"exp": fixedNow.Add(-20 * time.Second).Unix()}},
+		"expired":                     {claims: map[string]any{"exp": fixedNow.Add(-time.Minute).Unix()}, expected: ErrExpired},
+		"not before within leeway":    {claims: map[string]any{"nbf": fixedNow.Add(20 * time.Second).Unix()}},
+		"not before":                  {claims: map[string]any{"nbf": fixedNow.Add(time.Minute).Unix()}, expected: ErrNotYetValid},
+		"issued in the future":        {claims: map[string]any{"iat": fixedNow.Add(time.Minute).Unix()}, expected: ErrNotYetValid},
+		"without expiration":          {claims: map[string]any{"exp": nil}, expected: ErrMissingClaim},
+		"without subject":             {claims: map[string]any{"sub": ""}, expected: ErrMissingClaim},
+		"fractional expiration times": {claims: map[string]any{"exp": float64(fixedNow.Unix()) + 30.5}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			claims := validClaims()
+			for claim, value := range test.claims {
+				if value == nil {
+					delete(claims, claim)
+					continue
+				}
+				claims[claim] = value
+			}
+			token := signHS256(t, map[string]any{"alg": HS256}, claims)
+
+			_, err := newVerifier(key).Verify(token)
+
+			if test.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestVerify_ShouldCheckIssuerAndAudience(t *testing.T) {
+	key, err := NewHMACKey("", secret)
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		claims   map[string]any
+		expected error
+	}{
+		"audience list":  {claims: map[string]any{"aud": []string{"other", "hexapi"}}},
+		"other issuer":   {claims: map[string]any{"iss": "https://other.example.com"}, expected: ErrInvalidIssuer},
+		"other audience": {claims: map[string]any{"aud": "other"}, expected: ErrInvalidAudience},
+		"no audience":    {claims: map[string]any{"aud": []string{}}, expected: ErrInvalidAudience},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			claims := validClaims()
+			for claim, value := range test.claims {
+				claims[claim] = value
+			}
+			token := signHS256(t, map[string]any{"alg": HS256}, claims)
+
+			_, err := newVerifier(key).Verify(token)
+
+			if test.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestEnabled_ShouldBeFalseWithoutKeys(t *testing.T) {
+	assert.False(t, Verifier{}.Enabled())
+}
+
+func newVerifier(keys ...Key) Verifier {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(fixedNow)
+	return NewVerifier(keys, "https://issuer.example.com", "hexapi", 30*time.Second, clockMock)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":   "user-1",
+		"iss":   "https://issuer.example.com",
+		"aud":   "hexapi",
+		"exp":   fixedNow.Add(time.Hour).Unix(),
+		"iat":   fixedNow.Unix(),
+		"scope": "messages:read messages:write",
+	}
+}
+
+func signHS256(t *testing.T, header map[string]any, claims map[string]any) string {
+	signingInput := encode(t, header) + "." + encode(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func encode(t *testing.T, value any) string {
+	data, err := json.Marshal(value)
+	assert.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
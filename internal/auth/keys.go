@@ -0,0 +1,248 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+	ES256 = "ES256"
+
+	minHMACSecretSize = 32
+	minRSAKeyBits     = 2048
+)
+
+var (
+	errShortSecret    = fmt.Errorf("HS256 secrets must have at least %d bytes", minHMACSecretSize)
+	errWeakRSAKey     = fmt.Errorf("RSA keys must have at least %d bits", minRSAKeyBits)
+	errUnsupportedKey = errors.New("only RSA and P-256 ECDSA public keys are supported")
+)
+
+// Key verifies the signatures of a single algorithm. A key without an ID
+// is tried for every token of its algorithm, whatever their kid.
+type Key struct {
+	ID        string
+	Algorithm string
+	secret    []byte
+	public    crypto.PublicKey
+}
+
+func NewHMACKey(id string, secret []byte) (Key, error) {
+	if len(secret) < minHMACSecretSize {
+		return Key{}, errShortSecret
+	}
+	return Key{ID: id, Algorithm: HS256, secret: secret}, nil
+}
+
+// NewPublicKey verifies RS256 with an RSA key and ES256 with a P-256 key.
+func NewPublicKey(id string, public crypto.PublicKey) (Key, error) {
+	switch public := public.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < minRSAKeyBits {
+			return Key{}, errWeakRSAKey
+		}
+		return Key{ID: id, Algorithm: RS256, public: public}, nil
+	case *ecdsa.PublicKey:
+		if public.Curve != elliptic.P256() {
+			return Key{}, errUnsupportedKey
+		}
+		return Key{ID: id, Algorithm: ES256, public: public}, nil
+	default:
+		return Key{}, errUnsupportedKey
+	}
+}
+
+// ParsePublicKeyPEM reads a PKIX "PUBLIC KEY", a PKCS #1 "RSA PUBLIC KEY" or
+// the public key of a "CERTIFICATE".
+func ParsePublicKeyPEM(id string, data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, errors.New("no PEM block found")
+	}
+
+	var public crypto.PublicKey
+	var err error
+	switch block.Type {
+	case "PUBLIC KEY":
+		public, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		public, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var certificate *x509.Certificate
+		certificate, err = x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			public = certificate.PublicKey
+		}
+	default:
+		err = fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return Key{}, err
+	}
+	return NewPublicKey(id, public)
+}
+
+type jwk struct {
+	KeyType   string `json:"kty"`
+	ID        string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+	K         string `json:"k"`
+}
+
+// ParseJWKS reads a JSON Web Key Set. Keys meant for encryption or for
+// algorithms other than HS256, RS256 and ES256 are left out.
+func ParseJWKS(data []byte) ([]Key, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	var keys []Key
+	for i, entry := range set.Keys {
+		if entry.Use != "" && entry.Use != "sig" {
+			continue
+		}
+		key, ok, err := entry.key()
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", i, err)
+		}
+		if ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (k jwk) key() (Key, bool, error) {
+	var key Key
+	var err error
+	switch k.KeyType {
+	case "oct":
+		var secret []byte
+		if secret, err = decodeSegment(k.K); err == nil {
+			key, err = NewHMACKey(k.ID, secret)
+		}
+	case "RSA":
+		key, err = k.rsaKey()
+	case "EC":
+		key, err = k.ecdsaKey()
+	default:
+		return Key{}, false, nil
+	}
+	if err != nil {
+		return Key{}, false, err
+	}
+	if k.Algorithm != "" && k.Algorithm != key.Algorithm {
+		return Key{}, false, nil
+	}
+	return key, true, nil
+}
+
+func (k jwk) rsaKey() (Key, error) {
+	n, err := decodeInt(k.N)
+	if err != nil {
+		return Key{}, err
+	}
+	e, err := decodeInt(k.E)
+	if err != nil {
+		return Key{}, err
+	}
+	if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return Key{}, errors.New("invalid RSA exponent")
+	}
+	return NewPublicKey(k.ID, &rsa.PublicKey{N: n, E: int(e.Int64())})
+}
+
+func (k jwk) ecdsaKey() (Key, error) {
+	if k.Curve != "P-256" {
+		return Key{}, errUnsupportedKey
+	}
+	x, err := decodeInt(k.X)
+	if err != nil {
+		return Key{}, err
+	}
+	y, err := decodeInt(k.Y)
+	if err != nil {
+		return Key{}, err
+	}
+	if !elliptic.P256().IsOnCurve(x, y) {
+		return Key{}, errors.New("EC point is not on P-256")
+	}
+	return NewPublicKey(k.ID, &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y})
+}
+
+func decodeInt(segment string) (*big.Int, error) {
+	data, err := decodeSegment(segment)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("missing key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+func decodeSegment(segment string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(segment)
+}
+
+// LoadKeys gathers the keys configured for the server: an HS256 secret, a
+// PEM public key file and a JWKS file, each of which may be empty.
+func LoadKeys(secret string, publicKeyFile string, jwksFile string) ([]Key, error) {
+	var keys []Key
+	if secret != "" {
+		key, err := NewHMACKey("", []byte(secret))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if publicKeyFile != "" {
+		data, err := os.ReadFile(publicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParsePublicKeyPEM("", data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", publicKeyFile, err)
+		}
+		keys = append(keys, key)
+	}
+
+	if jwksFile != "" {
+		data, err := os.ReadFile(jwksFile)
+		if err != nil {
+			return nil, err
+		}
+		set, err := ParseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", jwksFile, err)
+		}
+		if len(set) == 0 {
+			return nil, fmt.Errorf("%s: no signing keys", jwksFile)
+		}
+		keys = append(keys, set...)
+	}
+	return keys, nil
+}
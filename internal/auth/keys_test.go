@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJWKS_ShouldReadSigningKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	data := jwks(t,
+		map[string]any{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": encodeInt(rsaKey.N), "e": encodeInt(big.NewInt(int64(rsaKey.E)))},
+		map[string]any{"kty": "EC", "kid": "ec-1", "alg": ES256, "crv": "P-256", "x": encodeInt(ecKey.X), "y": encodeInt(ecKey.Y)},
+		map[string]any{"kty": "oct", "kid": "oct-1", "k": base64.RawURLEncoding.EncodeToString(secret)},
+		map[string]any{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": encodeInt(rsaKey.N), "e": "AQAB"},
+		map[string]any{"kty": "OKP", "kid": "ed-1", "crv": "Ed25519", "x": "AA"},
+		map[string]any{"kty": "RSA", "kid": "ps-1", "alg": "PS256", "n": encodeInt(rsaKey.N), "e": "AQAB"},
+	)
+
+	keys, err := ParseJWKS(data)
+
+	assert.NoError(t, err)
+	assert.Len(t, keys, 3)
+	assert.Equal(t, []string{"rsa-1", "ec-1", "oct-1"}, []string{keys[0].ID, keys[1].ID, keys[2].ID})
+	assert.Equal(t, []string{RS256, ES256, HS256}, []string{keys[0].Algorithm, keys[1].Algorithm, keys[2].Algorithm})
+}
+
+func TestParseJWKS_ShouldReturnErrorWhenKeyIsInvalid(t *testing.T) {
+	tests := map[string]map[string]any{
+		"point off the curve": {"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"},
+		"unsupported curve":   {"kty": "EC", "crv": "P-384", "x": "AQ", "y": "AQ"},
+		"weak RSA key":        {"kty": "RSA", "n": "AQAB", "e": "AQAB"},
+		"short secret":        {"kty": "oct", "k": "c2VjcmV0"},
+	}
+	for name, key := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseJWKS(jwks(t, key))
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewHMACKey_ShouldReturnErrorWhenSecretIsShort(t *testing.T) {
+	_, err := NewHMACKey("", []byte("secret"))
+
+	assert.ErrorIs(t, err, errShortSecret)
+}
+
+func TestNewPublicKey_ShouldReturnErrorWhenCurveIsNotP256(t *testing.T) {
+	private, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+
+	_, err = NewPublicKey("", &private.PublicKey)
+
+	assert.ErrorIs(t, err, errUnsupportedKey)
+}
+
+func TestLoadKeys_ShouldReadEveryConfiguredSource(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	publicKeyFile := filepath.Join(dir, "public.pem")
+	assert.NoError(t, os.WriteFile(publicKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	jwksFile := filepath.Join(dir, "jwks.json")
+	assert.NoError(t, os.WriteFile(jwksFile, jwks(t, map[string]any{"kty": "oct", "kid": "oct-1", "k": base64.RawURLEncoding.EncodeToString(secret)}), 0o600))
+
+	keys, err := LoadKeys(string(secret), publicKeyFile, jwksFile)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{HS256, RS256, HS256}, []string{keys[0].Algorithm, keys[1].Algorithm, keys[2].Algorithm})
+}
+
+func TestLoadKeys_ShouldReturnNoKeysWhenNothingIsConfigured(t *testing.T) {
+	keys, err := LoadKeys("", "", "")
+
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestLoadKeys_ShouldReturnErrorWhenJWKSHasNoSigningKey(t *testing.T) {
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(jwksFile, []byte(`{"keys": []}`), 0o600))
+
+	_, err := LoadKeys("", "", jwksFile)
+
+	assert.ErrorContains(t, err, "no signing keys")
+}
+
+func jwks(t *testing.T, keys ...map[string]any) []byte {
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	assert.NoError(t, err)
+	return data
+}
+
+func encodeInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
@@ -0,0 +1,211 @@
+package changefeed
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+)
+
+var (
+	ErrLagged = errors.New("subscriber fell behind the change feed")
+	ErrClosed = errors.New("change feed is closed")
+)
+
+// Change is an event of the tenant it was published for.
+type Change struct {
+	ID     uint64
+	Tenant string
+	Event  domain.Event
+}
+
+// Feed numbers the published events and fans them out to the subscribers of
+// their tenant that the policy lets read the message. The last replaySize
+// changes are kept so that a subscriber can resume after the last change it
+// has seen.
+type Feed struct {
+	policy           ports.MessagePolicy
+	mu               sync.Mutex
+	lastID           uint64
+	replay           []Change
+	replaySize       int
+	subscriberBuffer int
+	subscribers      map[*Subscription]struct{}
+	closed           bool
+}
+
+func NewFeed(replaySize int, subscriberBuffer int, policy ports.MessagePolicy) *Feed {
+	return &Feed{
+		policy:           policy,
+		replaySize:       replaySize,
+		subscriberBuffer: subscriberBuffer,
+		subscribers:      make(map[*Subscription]struct{}),
+	}
+}
+
+// Handle publishes the event to the feed for the tenant in ctx. It never
+// blocks: a subscriber whose buffer is full is dropped with ErrLagged and has
+// to resume from the replay.
+func (f *Feed) Handle(ctx context.Context, event domain.Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return nil
+	}
+
+	f.lastID++
+	change := Change{ID: f.lastID, Tenant: domain.TenantFromContext(ctx), Event: event}
+	if f.replaySize > 0 {
+		if len(f.replay) == f.replaySize {
+			f.replay = append(f.replay[:0], f.replay[1:]...)
+		}
+		f.replay = append(f.replay, change)
+	}
+
+	for subscription := range f.subscribers {
+		if !subscription.receives(change) {
+			continue
+		}
+		select {
+		case subscription.changes <- change:
+		default:
+			f.drop(subscription, ErrLagged)
+		}
+	}
+	return nil
+}
+
+// Subscribe starts receiving the changes published from now on for the
+// subscriber in ctx: those of its tenant, about messages it may read, and
+// matching filter unless it is nil.
+func (f *Feed) Subscribe(ctx context.Context, filter func(event domain.Event) bool) (*Subscription, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	subscription := f.newSubscription(ctx, filter)
+	if err := f.subscribe(subscription, nil); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// SubscribeAfter replays the buffered changes after lastID before the new
+// ones. complete is false when some of those changes already left the replay
+// buffer, or lastID comes from before a restart, so the subscriber should
+// reload its state instead.
+func (f *Feed) SubscribeAfter(ctx context.Context, lastID uint64, filter func(event domain.Event) bool) (subscription *Subscription, complete bool, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	complete = lastID == f.lastID || (lastID < f.lastID && len(f.replay) > 0 && lastID+1 >= f.replay[0].ID)
+
+	subscription = f.newSubscription(ctx, filter)
+	var missed []Change
+	for _, change := range f.replay {
+		if change.ID > lastID && subscription.receives(change) {
+			missed = append(missed, change)
+		}
+	}
+
+	if err := f.subscribe(subscription, missed); err != nil {
+		return nil, false, err
+	}
+	return subscription, complete, nil
+}
+
+// Close ends every subscription with ErrClosed.
+func (f *Feed) Close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	for subscription := range f.subscribers {
+		f.drop(subscription, ErrClosed)
+	}
+}
+
+func (f *Feed) newSubscription(ctx context.Context, filter func(event domain.Event) bool) *Subscription {
+	return &Subscription{
+		feed:   f,
+		tenant: domain.TenantFromContext(ctx),
+		filter: func(event domain.Event) bool {
+			if err := f.policy.AuthorizeMessage(ctx, domain.ActionRead, eventMessage(event)); err != nil {
+				return false
+			}
+			return filter == nil || filter(event)
+		},
+	}
+}
+
+func (f *Feed) subscribe(subscription *Subscription, missed []Change) error {
+	if f.closed {
+		return ErrClosed
+	}
+
+	subscription.changes = make(chan Change, len(missed)+f.subscriberBuffer)
+	for _, change := range missed {
+		subscription.changes <- change
+	}
+
+	f.subscribers[subscription] = struct{}{}
+	return nil
+}
+
+func (f *Feed) drop(subscription *Subscription, err error) {
+	if _, ok := f.subscribers[subscription]; !ok {
+		return
+	}
+	delete(f.subscribers, subscription)
+	subscription.err = err
+	close(subscription.changes)
+}
+
+// eventMessage returns the message the event is about, with what the policy
+// needs to authorize reading it.
+func eventMessage(event domain.Event) domain.Message {
+	switch event := event.(type) {
+	case domain.MessageCreated:
+		return event.Message
+	case domain.MessageUpdated:
+		return event.Message
+	case domain.MessageDeleted:
+		return domain.Message{ID: event.MessageID, Owner: event.Owner}
+	default:
+		return domain.Message{ID: event.AggregateID()}
+	}
+}
+
+type Subscription struct {
+	feed    *Feed
+	tenant  string
+	filter  func(event domain.Event) bool
+	changes chan Change
+	err     error
+}
+
+// Changes is closed when the subscription ends, Err tells why.
+func (s *Subscription) Changes() <-chan Change {
+	return s.changes
+}
+
+func (s *Subscription) Err() error {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+
+	return s.err
+}
+
+// receives reports whether the subscriber is sent the change.
+func (s *Subscription) receives(change Change) bool {
+	return change.Tenant == s.tenant && s.filter(change.Event)
+}
+
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+
+	s.feed.drop(s, nil)
+}
@@ -0,0 +1,195 @@
+package changefeed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+var fixedNow = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestFeed_ShouldDeliverChangesToSubscribers(t *testing.T) {
+	ctx := context.Background()
+	feed := NewFeed(10, 10, policy.NewRolePolicy())
+
+	subscription, err := feed.Subscribe(context.Background(), nil)
+	assert.NoError(t, err)
+	defer subscription.Close()
+
+	created := domain.NewMessageCreated(domain.NewMessage("id", "message content"), fixedNow)
+	deleted := domain.NewMessageDeleted("id", fixedNow)
+	assert.NoError(t, feed.Handle(ctx, created))
+	assert.NoError(t, feed.Handle(ctx, deleted))
+
+	assert.Equal(t, Change{ID: 1, Tenant: domain.DefaultTenant, Event: created}, <-subscription.Changes())
+	assert.Equal(t, Change{ID: 2, Tenant: domain.DefaultTenant, Event: deleted}, <-subscription.Changes())
+}
+
+func TestFeed_ShouldOnlyDeliverChangesMatchingFilter(t *testing.T) {
+	ctx := context.Background()
+	feed := NewFeed(10, 10, policy.NewRolePolicy())
+
+	subscription, err := feed.Subscribe(context.Background(), func(event domain.Event) bool {
+		return event.EventName() == domain.EventMessageDeleted
+	})
+	assert.NoError(t, err)
+
+	deleted := domain.NewMessageDeleted("id", fixedNow)
+	assert.NoError(t, feed.Handle(ctx, domain.NewMessageCreated(domain.NewMessage("id", "message content"), fixedNow)))
+	assert.NoError(t, feed.Handle(ctx, deleted))
+
+	assert.Equal(t, Change{ID: 2, Tenant: domain.DefaultTenant, Event: deleted}, <-subscription.Changes())
+	assert.Empty(t, subscription.Changes())
+}
+
+func TestFeed_ShouldOnlyDeliverAndReplayChangesOfTenant(t *testing.T) {
+	acmeCtx := domain.ContextWithTenant(context.Background(), "acme")
+	globexCtx := domain.ContextWithTenant(context.Background(), "globex")
+	feed := NewFeed(10, 10, policy.NewRolePolicy())
+
+	live, err := feed.Subscribe(acmeCtx, nil)
+	assert.NoError(t, err)
+	acme := domain.NewMessageDeleted("1", fixedNow)
+	assert.NoError(t, feed.Handle(globexCtx, domain.NewMessageDeleted("2", fixedNow)))
+	assert.NoError(t, feed.Handle(acmeCtx, acme))
+
+	replayed, complete, err := feed.SubscribeAfter(acmeCtx, 0, nil)
+	assert.NoError(t, err)
+	assert.True(t, complete)
+
+	for _, subscription := range []*Subscription{live, replayed} {
+		assert.Equal(t, Change{ID: 2, Tenant: "acme", Event: acme}, <-subscription.Changes())
+		assert.Empty(t, subscription.Changes())
+	}
+}
+
+func TestFeed_ShouldOnlyDeliverAndReplayChangesOfMessagesTheSubscriberMayRead(t *testing.T) {
+	ctx := context.Background()
+	readerCtx := domain.ContextWithPrincipal(ctx, domain.NewPrincipal("user-1", []string{string(domain.RoleReader)}))
+	adminCtx := domain.ContextWithPrincipal(ctx, domain.NewPrincipal("admin", []string{domain.ScopeAdmin}))
+	roleless := domain.ContextWithPrincipal(ctx, domain.NewPrincipal("user-1", nil))
+	feed := NewFeed(10, 10, policy.NewRolePolicy())
+
+	reader, err := feed.Subscribe(readerCtx, nil)
+	assert.NoError(t, err)
+	admin, err := feed.Subscribe(adminCtx, nil)
+	assert.NoError(t, err)
+	withoutRole, err := feed.Subscribe(roleless, nil)
+	assert.NoError(t, err)
+
+	own := domain.NewMessageCreated(domain.NewMessage("1", "message content").OwnedBy("user-1"), fixedNow)
+	other := domain.NewMessageCreated(domain.NewMessage("2", "message content").OwnedBy("user-2"), fixedNow)
+	ownDeleted := domain.NewMessageDeleted("1", fixedNow).OwnedBy("user-1")
+	otherDeleted := domain.NewMessageDeleted("2", fixedNow).OwnedBy("user-2")
+	for _, event := range []domain.Event{own, other, ownDeleted, otherDeleted} {
+		assert.NoError(t, feed.Handle(ctx, event))
+	}
+	replayed, _, err := feed.SubscribeAfter(readerCtx, 0, nil)
+	assert.NoError(t, err)
+
+	for _, subscription := range []*Subscription{reader, replayed, admin} {
+		for _, event := range []domain.Event{own, other, ownDeleted, otherDeleted} {
+			assert.Equal(t, event, (<-subscription.Changes()).Event)
+		}
+		assert.Empty(t, subscription.Changes())
+	}
+	assert.Empty(t, withoutRole.Changes())
+}
+
+func TestFeed_ShouldReplayChangesAfterLastID(t *testing.T) {
+	ctx := context.Background()
+	feed := NewFeed(10, 10, policy.NewRolePolicy())
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, feed.Handle(ctx, domain.NewMessageDeleted("id", fixedNow)))
+	}
+
+	subscription, complete, err := feed.SubscribeAfter(context.Background(), 1, nil)
+	assert.NoError(t, err)
+	assert.True(t, complete)
+
+	assert.Equal(t, uint64(2), (<-subscription.Changes()).ID)
+	assert.Equal(t, uint64(3), (<-subscription.Changes()).ID)
+
+	assert.NoError(t, feed.Handle(ctx, domain.NewMessageDeleted("id", fixedNow)))
+	assert.Equal(t, uint64(4), (<-subscription.Changes()).ID)
+}
+
+func TestFeed_ShouldReportIncompleteReplayWhenChangesWereEvicted(t *testing.T) {
+	ctx := context.Background()
+	feed := NewFeed(2, 10, policy.NewRolePolicy())
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, feed.Handle(ctx, domain.NewMessageDeleted("id", fixedNow)))
+	}
+
+	subscription, complete, err := feed.SubscribeAfter(context.Background(), 1, nil)
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, uint64(4), (<-subscription.Changes()).ID)
+	assert.Equal(t, uint64(5), (<-subscription.Changes()).ID)
+
+	_, complete, err = feed.SubscribeAfter(context.Background(), 3, nil)
+	assert.NoError(t, err)
+	assert.True(t, complete)
+
+	_, complete, err = feed.SubscribeAfter(context.Background(), 5, nil)
+	assert.NoError(t, err)
+	assert.True(t, complete)
+
+	_, complete, err = feed.SubscribeAfter(context.Background(), 9, nil)
+	assert.NoError(t, err)
+	assert.False(t, complete)
+}
+
+func TestFeed_ShouldDropSubscribersThatFallBehind(t *testing.T) {
+	ctx := context.Background()
+	feed := NewFeed(10, 1, policy.NewRolePolicy())
+
+	slow, err := feed.Subscribe(context.Background(), nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, feed.Handle(ctx, domain.NewMessageDeleted("id1", fixedNow)))
+	assert.NoError(t, feed.Handle(ctx, domain.NewMessageDeleted("id2", fixedNow)))
+
+	change, ok := <-slow.Changes()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), change.ID)
+	_, ok = <-slow.Changes()
+	assert.False(t, ok)
+	assert.ErrorIs(t, slow.Err(), ErrLagged)
+}
+
+func TestFeed_ShouldEndSubscriptionsWhenClosed(t *testing.T) {
+	feed := NewFeed(10, 10, policy.NewRolePolicy())
+
+	subscription, err := feed.Subscribe(context.Background(), nil)
+	assert.NoError(t, err)
+
+	feed.Close()
+
+	_, ok := <-subscription.Changes()
+	assert.False(t, ok)
+	assert.ErrorIs(t, subscription.Err(), ErrClosed)
+
+	_, err = feed.Subscribe(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.NoError(t, feed.Handle(context.Background(), domain.NewMessageDeleted("id", fixedNow)))
+}
+
+func TestFeed_ShouldStopDeliveringWhenSubscriptionIsClosed(t *testing.T) {
+	feed := NewFeed(10, 10, policy.NewRolePolicy())
+
+	subscription, err := feed.Subscribe(context.Background(), nil)
+	assert.NoError(t, err)
+
+	subscription.Close()
+	subscription.Close()
+
+	assert.NoError(t, feed.Handle(context.Background(), domain.NewMessageDeleted("id", fixedNow)))
+	_, ok := <-subscription.Changes()
+	assert.False(t, ok)
+	assert.NoError(t, subscription.Err())
+}
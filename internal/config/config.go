@@ -0,0 +1,307 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	StorageMemory = "memory"
+	StorageBolt   = "bolt"
+
+	RateLimitKeyClient = "client"
+	RateLimitKeyIP     = "ip"
+	RateLimitKeyTenant = "tenant"
+
+	minAdminAPIKeyLength = 32
+)
+
+type Config struct {
+	Address                string
+	Storage                string
+	BoltPath               string
+	IdempotencyTTL         time.Duration
+	OutboxInterval         time.Duration
+	OutboxBatchSize        int
+	OutboxMaxAttempts      int
+	WebhookInterval        time.Duration
+	WebhookBatchSize       int
+	WebhookTimeout         time.Duration
+	WebhookMaxAttempts     int
+	WebhookBackoff         time.Duration
+	WebhookAllowPrivate    bool
+	StreamReplaySize       int
+	StreamSubscriberBuffer int
+	StreamHeartbeat        time.Duration
+	WebSocketPingInterval  time.Duration
+	WebSocketRateLimit     int
+	WebSocketRateBurst     int
+	GRPCAddress            string
+	GraphQLComplexityLimit int
+	GraphQLPlayground      bool
+	ValidateResponses      bool
+	V1DeprecatedAt         time.Time
+	V1SunsetAt             time.Time
+	JWTSecret              string
+	JWTPublicKeyFile       string
+	JWTJWKSFile            string
+	JWTIssuer              string
+	JWTAudience            string
+	JWTLeeway              time.Duration
+	APIKeysEnabled         bool
+	AdminAPIKey            string
+	TenantsFile            string
+	TenantDomain           string
+	RateLimit              RateLimit
+	RouteRateLimits        map[string]RateLimit
+	RateLimitKey           string
+	IPRateLimit            RateLimit
+	TrustedProxies         []string
+	TrashRetention         time.Duration
+	TrashPurgeInterval     time.Duration
+}
+
+// RateLimit allows Requests per Period. A zero value allows any number.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+func Load() (Config, error) {
+	loader := envLoader{}
+	cfg := Config{
+		Address:                loader.string("HEXAPI_ADDRESS", ":8080"),
+		Storage:                loader.string("HEXAPI_STORAGE", StorageMemory),
+		BoltPath:               loader.string("HEXAPI_BOLT_PATH", "hexapi.db"),
+		IdempotencyTTL:         loader.duration("HEXAPI_IDEMPOTENCY_TTL", 24*time.Hour),
+		OutboxInterval:         loader.duration("HEXAPI_OUTBOX_INTERVAL", time.Second),
+		OutboxBatchSize:        loader.int("HEXAPI_OUTBOX_BATCH_SIZE", 100),
+		OutboxMaxAttempts:      loader.int("HEXAPI_OUTBOX_MAX_ATTEMPTS", 5),
+		WebhookInterval:        loader.duration("HEXAPI_WEBHOOK_INTERVAL", time.Second),
+		WebhookBatchSize:       loader.int("HEXAPI_WEBHOOK_BATCH_SIZE", 100),
+		WebhookTimeout:         loader.duration("HEXAPI_WEBHOOK_TIMEOUT", 5*time.Second),
+		WebhookMaxAttempts:     loader.int("HEXAPI_WEBHOOK_MAX_ATTEMPTS", 5),
+		WebhookBackoff:         loader.duration("HEXAPI_WEBHOOK_BACKOFF", time.Second),
+		WebhookAllowPrivate:    loader.bool("HEXAPI_WEBHOOK_ALLOW_PRIVATE", false),
+		StreamReplaySize:       loader.int("HEXAPI_STREAM_REPLAY_SIZE", 1000),
+		StreamSubscriberBuffer: loader.int("HEXAPI_STREAM_SUBSCRIBER_BUFFER", 64),
+		StreamHeartbeat:        loader.duration("HEXAPI_STREAM_HEARTBEAT", 15*time.Second),
+		WebSocketPingInterval:  loader.duration("HEXAPI_WS_PING_INTERVAL", 30*time.Second),
+		WebSocketRateLimit:     loader.int("HEXAPI_WS_RATE_LIMIT", 10),
+		WebSocketRateBurst:     loader.int("HEXAPI_WS_RATE_BURST", 20),
+		GRPCAddress:            loader.string("HEXAPI_GRPC_ADDRESS", ":9090"),
+		GraphQLComplexityLimit: loader.int("HEXAPI_GRAPHQL_COMPLEXITY_LIMIT", 500),
+		GraphQLPlayground:      loader.bool("HEXAPI_GRAPHQL_PLAYGROUND", false),
+		ValidateResponses:      loader.bool("HEXAPI_VALIDATE_RESPONSES", false),
+		V1DeprecatedAt:         loader.time("HEXAPI_V1_DEPRECATED_AT"),
+		V1SunsetAt:             loader.time("HEXAPI_V1_SUNSET_AT"),
+		JWTSecret:              loader.string("HEXAPI_JWT_SECRET", ""),
+		JWTPublicKeyFile:       loader.string("HEXAPI_JWT_PUBLIC_KEY_FILE", ""),
+		JWTJWKSFile:            loader.string("HEXAPI_JWT_JWKS_FILE", ""),
+		JWTIssuer:              loader.string("HEXAPI_JWT_ISSUER", ""),
+		JWTAudience:            loader.string("HEXAPI_JWT_AUDIENCE", ""),
+		JWTLeeway:              loader.duration("HEXAPI_JWT_LEEWAY", 30*time.Second),
+		APIKeysEnabled:         loader.bool("HEXAPI_API_KEYS_ENABLED", false),
+		AdminAPIKey:            loader.string("HEXAPI_ADMIN_API_KEY", ""),
+		TenantsFile:            loader.string("HEXAPI_TENANTS_FILE", ""),
+		TenantDomain:           loader.string("HEXAPI_TENANT_DOMAIN", ""),
+		RateLimit:              loader.rateLimit("HEXAPI_RATE_LIMIT", RateLimit{Requests: 600, Period: time.Minute}),
+		RouteRateLimits:        loader.routeRateLimits("HEXAPI_RATE_LIMIT_ROUTES"),
+		RateLimitKey:           loader.string("HEXAPI_RATE_LIMIT_KEY", RateLimitKeyClient),
+		IPRateLimit:            loader.rateLimit("HEXAPI_RATE_LIMIT_IP", RateLimit{Requests: 1200, Period: time.Minute}),
+		TrustedProxies:         loader.addresses("HEXAPI_TRUSTED_PROXIES"),
+		TrashRetention:         loader.duration("HEXAPI_TRASH_RETENTION", 30*24*time.Hour),
+		TrashPurgeInterval:     loader.duration("HEXAPI_TRASH_PURGE_INTERVAL", time.Hour),
+	}
+	if loader.err != nil {
+		return Config{}, loader.err
+	}
+
+	if cfg.Storage != StorageMemory && cfg.Storage != StorageBolt {
+		return Config{}, fmt.Errorf("invalid HEXAPI_STORAGE %q", cfg.Storage)
+	}
+
+	if cfg.RateLimitKey != RateLimitKeyClient && cfg.RateLimitKey != RateLimitKeyIP && cfg.RateLimitKey != RateLimitKeyTenant {
+		return Config{}, fmt.Errorf("invalid HEXAPI_RATE_LIMIT_KEY %q", cfg.RateLimitKey)
+	}
+
+	if cfg.AdminAPIKey != "" && !cfg.APIKeysEnabled {
+		return Config{}, errors.New("HEXAPI_ADMIN_API_KEY requires HEXAPI_API_KEYS_ENABLED")
+	}
+	if cfg.AdminAPIKey != "" && len(cfg.AdminAPIKey) < minAdminAPIKeyLength {
+		return Config{}, fmt.Errorf("HEXAPI_ADMIN_API_KEY must have at least %d characters", minAdminAPIKeyLength)
+	}
+	if cfg.TenantDomain != "" && cfg.TenantsFile == "" {
+		return Config{}, errors.New("HEXAPI_TENANT_DOMAIN requires HEXAPI_TENANTS_FILE")
+	}
+
+	return cfg, nil
+}
+
+// envLoader keeps the first parsing error so Load can read every variable
+// before checking it once.
+type envLoader struct {
+	err error
+}
+
+func (l *envLoader) string(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+func (l *envLoader) duration(key string, fallback time.Duration) time.Duration {
+	value := l.string(key, "")
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		l.fail(key, err)
+		return fallback
+	}
+	return duration
+}
+
+func (l *envLoader) int(key string, fallback int) int {
+	value := l.string(key, "")
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		l.fail(key, err)
+		return fallback
+	}
+	return number
+}
+
+func (l *envLoader) bool(key string, fallback bool) bool {
+	value := l.string(key, "")
+	if value == "" {
+		return fallback
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		l.fail(key, err)
+		return fallback
+	}
+	return enabled
+}
+
+// time reads an RFC 3339 timestamp, returning the zero time when unset.
+func (l *envLoader) time(key string) time.Time {
+	value := l.string(key, "")
+	if value == "" {
+		return time.Time{}
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		l.fail(key, err)
+		return time.Time{}
+	}
+	return timestamp
+}
+
+func (l *envLoader) rateLimit(key string, fallback RateLimit) RateLimit {
+	value := l.string(key, "")
+	if value == "" {
+		return fallback
+	}
+
+	limit, err := parseRateLimit(value)
+	if err != nil {
+		l.fail(key, err)
+		return fallback
+	}
+	return limit
+}
+
+// addresses reads comma separated IP addresses and CIDR prefixes.
+func (l *envLoader) addresses(key string) []string {
+	value := l.string(key, "")
+	if value == "" {
+		return nil
+	}
+
+	var addresses []string
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if _, err := netip.ParsePrefix(entry); err != nil {
+			if _, err := netip.ParseAddr(entry); err != nil {
+				l.fail(key, fmt.Errorf("entry %q is not an IP address or CIDR prefix", entry))
+				return nil
+			}
+		}
+		addresses = append(addresses, entry)
+	}
+	return addresses
+}
+
+// routeRateLimits reads comma separated "METHOD /path=limit" entries, where
+// the path is the one the route is registered with, such as "/v2/messages/:id".
+func (l *envLoader) routeRateLimits(key string) map[string]RateLimit {
+	limits := map[string]RateLimit{}
+	value := l.string(key, "")
+	if value == "" {
+		return limits
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		route, limitValue, found := strings.Cut(strings.TrimSpace(entry), "=")
+		method, path, hasPath := strings.Cut(route, " ")
+		if !found || !hasPath || method == "" || !strings.HasPrefix(path, "/") {
+			l.fail(key, fmt.Errorf("entry %q is not METHOD /path=limit", entry))
+			return map[string]RateLimit{}
+		}
+
+		limit, err := parseRateLimit(limitValue)
+		if err != nil {
+			l.fail(key, err)
+			return map[string]RateLimit{}
+		}
+		limits[strings.ToUpper(method)+" "+path] = limit
+	}
+	return limits
+}
+
+// parseRateLimit reads "requests/period", such as "100/1m". "0" disables the
+// limit.
+func parseRateLimit(value string) (RateLimit, error) {
+	if value == "0" {
+		return RateLimit{}, nil
+	}
+
+	requestsValue, periodValue, found := strings.Cut(value, "/")
+	if !found {
+		return RateLimit{}, fmt.Errorf("limit %q is not requests/period", value)
+	}
+	requests, err := strconv.Atoi(requestsValue)
+	if err != nil {
+		return RateLimit{}, err
+	}
+	period, err := time.ParseDuration(periodValue)
+	if err != nil {
+		return RateLimit{}, err
+	}
+	if requests < 0 || period <= 0 {
+		return RateLimit{}, fmt.Errorf("limit %q must have a positive period and no negative requests", value)
+	}
+	return RateLimit{Requests: requests, Period: period}, nil
+}
+
+func (l *envLoader) fail(key string, err error) {
+	if l.err == nil {
+		l.err = fmt.Errorf("invalid %s: %w", key, err)
+	}
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad_ShouldReturnDefaultsWhenEnvIsEmpty(t *testing.T) {
+	cfg, err := Load()
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":8080", cfg.Address)
+	assert.Equal(t, StorageMemory, cfg.Storage)
+	assert.Equal(t, "hexapi.db", cfg.BoltPath)
+	assert.Equal(t, 24*time.Hour, cfg.IdempotencyTTL)
+	assert.Equal(t, time.Second, cfg.OutboxInterval)
+	assert.Equal(t, 100, cfg.OutboxBatchSize)
+	assert.Equal(t, 5, cfg.OutboxMaxAttempts)
+	assert.Equal(t, time.Second, cfg.WebhookInterval)
+	assert.Equal(t, 100, cfg.WebhookBatchSize)
+	assert.Equal(t, 5*time.Second, cfg.WebhookTimeout)
+	assert.Equal(t, 5, cfg.WebhookMaxAttempts)
+	assert.Equal(t, time.Second, cfg.WebhookBackoff)
+	assert.False(t, cfg.WebhookAllowPrivate)
+	assert.Equal(t, 1000, cfg.StreamReplaySize)
+	assert.Equal(t, 64, cfg.StreamSubscriberBuffer)
+	assert.Equal(t, 15*time.Second, cfg.StreamHeartbeat)
+	assert.Equal(t, 30*time.Second, cfg.WebSocketPingInterval)
+	assert.Equal(t, 10, cfg.WebSocketRateLimit)
+	assert.Equal(t, 20, cfg.WebSocketRateBurst)
+	assert.Equal(t, ":9090", cfg.GRPCAddress)
+	assert.Equal(t, 500, cfg.GraphQLComplexityLimit)
+	assert.False(t, cfg.GraphQLPlayground)
+	assert.False(t, cfg.ValidateResponses)
+	assert.True(t, cfg.V1DeprecatedAt.IsZero())
+	assert.True(t, cfg.V1SunsetAt.IsZero())
+	assert.Empty(t, cfg.JWTSecret)
+	assert.Empty(t, cfg.JWTPublicKeyFile)
+	assert.Empty(t, cfg.JWTJWKSFile)
+	assert.Empty(t, cfg.JWTIssuer)
+	assert.Empty(t, cfg.JWTAudience)
+	assert.Equal(t, 30*time.Second, cfg.JWTLeeway)
+	assert.False(t, cfg.APIKeysEnabled)
+	assert.Empty(t, cfg.AdminAPIKey)
+	assert.Empty(t, cfg.TenantsFile)
+	assert.Empty(t, cfg.TenantDomain)
+	assert.Equal(t, RateLimit{Requests: 600, Period: time.Minute}, cfg.RateLimit)
+	assert.Empty(t, cfg.RouteRateLimits)
+	assert.Equal(t, RateLimitKeyClient, cfg.RateLimitKey)
+	assert.Equal(t, RateLimit{Requests: 1200, Period: time.Minute}, cfg.IPRateLimit)
+	assert.Empty(t, cfg.TrustedProxies)
+	assert.Equal(t, 30*24*time.Hour, cfg.TrashRetention)
+	assert.Equal(t, time.Hour, cfg.TrashPurgeInterval)
+}
+
+func TestLoad_ShouldReadValuesFromEnv(t *testing.T) {
+	t.Setenv("HEXAPI_ADDRESS", ":9090")
+	t.Setenv("HEXAPI_STORAGE", StorageBolt)
+	t.Setenv("HEXAPI_BOLT_PATH", "/tmp/hexapi.db")
+	t.Setenv("HEXAPI_IDEMPOTENCY_TTL", "30m")
+	t.Setenv("HEXAPI_OUTBOX_INTERVAL", "5s")
+	t.Setenv("HEXAPI_OUTBOX_BATCH_SIZE", "50")
+	t.Setenv("HEXAPI_OUTBOX_MAX_ATTEMPTS", "10")
+	t.Setenv("HEXAPI_WEBHOOK_INTERVAL", "2s")
+	t.Setenv("HEXAPI_WEBHOOK_BATCH_SIZE", "20")
+	t.Setenv("HEXAPI_WEBHOOK_TIMEOUT", "3s")
+	t.Setenv("HEXAPI_WEBHOOK_MAX_ATTEMPTS", "8")
+	t.Setenv("HEXAPI_WEBHOOK_BACKOFF", "10s")
+	t.Setenv("HEXAPI_WEBHOOK_ALLOW_PRIVATE", "true")
+	t.Setenv("HEXAPI_STREAM_REPLAY_SIZE", "10")
+	t.Setenv("HEXAPI_STREAM_SUBSCRIBER_BUFFER", "4")
+	t.Setenv("HEXAPI_STREAM_HEARTBEAT", "1m")
+	t.Setenv("HEXAPI_WS_PING_INTERVAL", "10s")
+	t.Setenv("HEXAPI_WS_RATE_LIMIT", "5")
+	t.Setenv("HEXAPI_WS_RATE_BURST", "7")
+	t.Setenv("HEXAPI_GRPC_ADDRESS", ":9191")
+	t.Setenv("HEXAPI_GRAPHQL_COMPLEXITY_LIMIT", "50")
+	t.Setenv("HEXAPI_GRAPHQL_PLAYGROUND", "true")
+	t.Setenv("HEXAPI_VALIDATE_RESPONSES", "true")
+	t.Setenv("HEXAPI_V1_DEPRECATED_AT", "2024-01-01T00:00:00Z")
+	t.Setenv("HEXAPI_V1_SUNSET_AT", "2025-01-01T00:00:00Z")
+	t.Setenv("HEXAPI_JWT_SECRET", "secret")
+	t.Setenv("HEXAPI_JWT_PUBLIC_KEY_FILE", "/etc/hexapi/public.pem")
+	t.Setenv("HEXAPI_JWT_JWKS_FILE", "/etc/hexapi/jwks.json")
+	t.Setenv("HEXAPI_JWT_ISSUER", "https://issuer.example.com")
+	t.Setenv("HEXAPI_JWT_AUDIENCE", "hexapi")
+	t.Setenv("HEXAPI_JWT_LEEWAY", "1m")
+	t.Setenv("HEXAPI_API_KEYS_ENABLED", "true")
+	t.Setenv("HEXAPI_ADMIN_API_KEY", "0123456789abcdef0123456789abcdef")
+	t.Setenv("HEXAPI_TENANTS_FILE", "/etc/hexapi/tenants.json")
+	t.Setenv("HEXAPI_TENANT_DOMAIN", "hexapi.example.com")
+	t.Setenv("HEXAPI_RATE_LIMIT", "100/1s")
+	t.Setenv("HEXAPI_RATE_LIMIT_ROUTES", "post /v2/messages=10/1m, GET /v2/messages/:id=0")
+	t.Setenv("HEXAPI_RATE_LIMIT_KEY", RateLimitKeyTenant)
+	t.Setenv("HEXAPI_RATE_LIMIT_IP", "0")
+	t.Setenv("HEXAPI_TRUSTED_PROXIES", "10.0.0.0/8, 192.168.1.1")
+	t.Setenv("HEXAPI_TRASH_RETENTION", "168h")
+	t.Setenv("HEXAPI_TRASH_PURGE_INTERVAL", "10m")
+
+	cfg, err := Load()
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":9090", cfg.Address)
+	assert.Equal(t, StorageBolt, cfg.Storage)
+	assert.Equal(t, "/tmp/hexapi.db", cfg.BoltPath)
+	assert.Equal(t, 30*time.Minute, cfg.IdempotencyTTL)
+	assert.Equal(t, 5*time.Second, cfg.OutboxInterval)
+	assert.Equal(t, 50, cfg.OutboxBatchSize)
+	assert.Equal(t, 10, cfg.OutboxMaxAttempts)
+	assert.Equal(t, 2*time.Second, cfg.WebhookInterval)
+	assert.Equal(t, 20, cfg.WebhookBatchSize)
+	assert.Equal(t, 3*time.Second, cfg.WebhookTimeout)
+	assert.Equal(t, 8, cfg.WebhookMaxAttempts)
+	assert.Equal(t, 10*time.Second, cfg.WebhookBackoff)
+	assert.True(t, cfg.WebhookAllowPrivate)
+	assert.Equal(t, 10, cfg.StreamReplaySize)
+	assert.Equal(t, 4, cfg.StreamSubscriberBuffer)
+	assert.Equal(t, time.Minute, cfg.StreamHeartbeat)
+	assert.Equal(t, 10*time.Second, cfg.WebSocketPingInterval)
+	assert.Equal(t, 5, cfg.WebSocketRateLimit)
+	assert.Equal(t, 7, cfg.WebSocketRateBurst)
+	assert.Equal(t, ":9191", cfg.GRPCAddress)
+	assert.Equal(t, 50, cfg.GraphQLComplexityLimit)
+	assert.True(t, cfg.GraphQLPlayground)
+	assert.True(t, cfg.ValidateResponses)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), cfg.V1DeprecatedAt)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), cfg.V1SunsetAt)
+	assert.Equal(t, "secret", cfg.JWTSecret)
+	assert.Equal(t, "/etc/hexapi/public.pem", cfg.JWTPublicKeyFile)
+	assert.Equal(t, "/etc/hexapi/jwks.json", cfg.JWTJWKSFile)
+	assert.Equal(t, "https://issuer.example.com", cfg.JWTIssuer)
+	assert.Equal(t, "hexapi", cfg.JWTAudience)
+	assert.Equal(t, time.Minute, cfg.JWTLeeway)
+	assert.True(t, cfg.APIKeysEnabled)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", cfg.AdminAPIKey)
+	assert.Equal(t, "/etc/hexapi/tenants.json", cfg.TenantsFile)
+	assert.Equal(t, "hexapi.example.com", cfg.TenantDomain)
+	assert.Equal(t, RateLimit{Requests: 100, Period: time.Second}, cfg.RateLimit)
+	assert.Equal(t, map[string]RateLimit{
+		"POST /v2/messages":    {Requests: 10, Period: time.Minute},
+		"GET /v2/messages/:id": {},
+	}, cfg.RouteRateLimits)
+	assert.Equal(t, RateLimitKeyTenant, cfg.RateLimitKey)
+	assert.Equal(t, RateLimit{}, cfg.IPRateLimit)
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.1"}, cfg.TrustedProxies)
+	assert.Equal(t, 7*24*time.Hour, cfg.TrashRetention)
+	assert.Equal(t, 10*time.Minute, cfg.TrashPurgeInterval)
+}
+
+func TestLoad_ShouldReturnErrorWhenInvalidDuration(t *testing.T) {
+	t.Setenv("HEXAPI_IDEMPOTENCY_TTL", "one day")
+
+	_, err := Load()
+
+	assert.Error(t, err)
+}
+
+func TestLoad_ShouldReturnErrorWhenInvalidBool(t *testing.T) {
+	t.Setenv("HEXAPI_GRAPHQL_PLAYGROUND", "maybe")
+
+	_, err := Load()
+
+	assert.Error(t, err)
+}
+
+func TestLoad_ShouldReturnErrorWhenInvalidTime(t *testing.T) {
+	t.Setenv("HEXAPI_V1_SUNSET_AT", "next year")
+
+	_, err := Load()
+
+	assert.Error(t, err)
+}
+
+func TestLoad_ShouldReturnErrorWhenInvalidNumber(t *testing.T) {
+	t.Setenv("HEXAPI_OUTBOX_BATCH_SIZE", "many")
+
+	_, err := Load()
+
+	assert.Error(t, err)
+}
+
+func TestLoad_ShouldReturnErrorWhenInvalidStorage(t *testing.T) {
+	t.Setenv("HEXAPI_STORAGE", "postgres")
+
+	_, err := Load()
+
+	assert.Error(t, err)
+}
+
+func TestLoad_ShouldReturnErrorWhenAdminAPIKeyIsSetWithoutAPIKeys(t *testing.T) {
+	t.Setenv("HEXAPI_ADMIN_API_KEY", "0123456789abcdef0123456789abcdef")
+
+	_, err := Load()
+
+	assert.Error(t, err)
+}
+
+func TestLoad_ShouldReturnErrorWhenAdminAPIKeyIsShort(t *testing.T) {
+	t.Setenv("HEXAPI_API_KEYS_ENABLED", "true")
+	t.Setenv("HEXAPI_ADMIN_API_KEY", "admin")
+
+	_, err := Load()
+
+	assert.Error(t, err)
+}
+
+func TestLoad_ShouldReturnErrorWhenTenantDomainIsSetWithoutTenants(t *testing.T) {
+	t.Setenv("HEXAPI_TENANT_DOMAIN", "hexapi.example.com")
+
+	_, err := Load()
+
+	assert.Error(t, err)
+}
+
+func TestLoad_ShouldReturnErrorWhenInvalidRateLimit(t *testing.T) {
+	t.Setenv("HEXAPI_RATE_LIMIT", "100 per minute")
+
+	_, err := Load()
+
+	assert.Error(t, err)
+}
+
+func TestLoad_ShouldReturnErrorWhenInvalidRouteRateLimit(t *testing.T) {
+	t.Setenv("HEXAPI_RATE_LIMIT_ROUTES", "/v2/messages=10/1m")
+
+	_, err := Load()
+
+	assert.Error(t, err)
+}
+
+func TestLoad_ShouldReturnErrorWhenInvalidTrustedProxy(t *testing.T) {
+	t.Setenv("HEXAPI_TRUSTED_PROXIES", "10.0.0.0/8,proxy.internal")
+
+	_, err := Load()
+
+	assert.Error(t, err)
+}
+
+func TestLoad_ShouldReturnErrorWhenInvalidRateLimitKey(t *testing.T) {
+	t.Setenv("HEXAPI_RATE_LIMIT_KEY", "session")
+
+	_, err := Load()
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// ScopeAdmin allows managing API keys.
+const ScopeAdmin = "admin"
+
+// APIKey is stored without its secret: Hash is the SHA-256 of Salt followed
+// by the secret. A zero ExpiresAt never expires. Keys belong to the tenant
+// they were created in, the default one when Tenant is empty.
+type APIKey struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Scopes     []string  `json:"scopes"`
+	Tenant     string    `json:"tenant,omitempty"`
+	Salt       []byte    `json:"salt"`
+	Hash       []byte    `json:"hash"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	RevokedAt  time.Time `json:"revoked_at"`
+}
+
+func NewAPIKey(id string, name string, scopes []string, salt []byte, hash []byte, createdAt time.Time, expiresAt time.Time) APIKey {
+	return APIKey{
+		ID:        id,
+		Name:      name,
+		Scopes:    scopes,
+		Salt:      salt,
+		Hash:      hash,
+		CreatedAt: createdAt,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (k APIKey) IsRevoked() bool {
+	return !k.RevokedAt.IsZero()
+}
+
+func (k APIKey) IsExpired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+func (k APIKey) Revoke(now time.Time) APIKey {
+	k.RevokedAt = now
+	return k
+}
+
+func (k APIKey) InTenant(tenant string) APIKey {
+	k.Tenant = tenant
+	return k
+}
+
+func (k APIKey) TenantOrDefault() string {
+	if k.Tenant == "" {
+		return DefaultTenant
+	}
+	return k.Tenant
+}
+
+func (k APIKey) Principal() Principal {
+	return NewPrincipal("apikey:"+k.ID, k.Scopes).InTenant(k.TenantOrDefault())
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// AuditSnapshot is a message as the audit log records it. It is kept apart
+// from Message so that new message fields do not change the hash of the
+// entries recorded before them.
+type AuditSnapshot struct {
+	ID      string `json:"id"`
+	Content string `json:"content"`
+	Owner   string `json:"owner,omitempty"`
+}
+
+func NewAuditSnapshot(message Message) *AuditSnapshot {
+	return &AuditSnapshot{
+		ID:      message.ID,
+		Content: message.Content,
+		Owner:   message.Owner,
+	}
+}
+
+// AuditEntry records an action on a message, by whom and with what outcome.
+// The entries of a tenant form a chain: each one holds the hash of the one
+// before it, and its own hash covers every other field, so altering, removing
+// or reordering entries breaks the chain after them.
+type AuditEntry struct {
+	Sequence     uint64         `json:"sequence"`
+	Time         time.Time      `json:"time"`
+	Tenant       string         `json:"tenant"`
+	Action       Action         `json:"action"`
+	MessageID    string         `json:"message_id,omitempty"`
+	Principal    string         `json:"principal,omitempty"`
+	RequestID    string         `json:"request_id,omitempty"`
+	Before       *AuditSnapshot `json:"before,omitempty"`
+	After        *AuditSnapshot `json:"after,omitempty"`
+	Outcome      AuditOutcome   `json:"outcome"`
+	Error        string         `json:"error,omitempty"`
+	PreviousHash string         `json:"previous_hash"`
+	Hash         string         `json:"hash"`
+}
+
+// NewAuditEntry records a successful action unless err is set. The entry is
+// only sealed when it is chained.
+func NewAuditEntry(at time.Time, tenant string, action Action, messageID string, err error) AuditEntry {
+	entry := AuditEntry{
+		Time:      at.UTC(),
+		Tenant:    tenant,
+		Action:    action,
+		MessageID: messageID,
+		Outcome:   AuditOutcomeSuccess,
+	}
+	if err != nil {
+		entry.Outcome = AuditOutcomeFailure
+		entry.Error = err.Error()
+	}
+	return entry
+}
+
+func (e AuditEntry) By(principal string, requestID string) AuditEntry {
+	e.Principal = principal
+	e.RequestID = requestID
+	return e
+}
+
+func (e AuditEntry) WithSnapshots(before *AuditSnapshot, after *AuditSnapshot) AuditEntry {
+	e.Before = before
+	e.After = after
+	return e
+}
+
+// Chain seals the entry as the one following previous, which is the zero
+// entry for the first entry of a tenant.
+func (e AuditEntry) Chain(previous AuditEntry) AuditEntry {
+	e.Sequence = previous.Sequence + 1
+	e.PreviousHash = previous.Hash
+	e.Hash = e.computeHash()
+	return e
+}
+
+// Verify checks that the entry is the one following previous and that none
+// of its fields changed since it was sealed.
+func (e AuditEntry) Verify(previous AuditEntry) error {
+	if e.Sequence != previous.Sequence+1 {
+		return fmt.Errorf("entry %d follows entry %d", e.Sequence, previous.Sequence)
+	}
+	if e.PreviousHash != previous.Hash {
+		return fmt.Errorf("entry %d does not hold the hash of entry %d", e.Sequence, previous.Sequence)
+	}
+	if e.Hash != e.computeHash() {
+		return fmt.Errorf("entry %d does not match its hash", e.Sequence)
+	}
+	return nil
+}
+
+// computeHash hashes the JSON encoding of every field but the hash itself.
+// Encoding cannot fail, as every field has a JSON representation.
+func (e AuditEntry) computeHash() string {
+	e.Hash = ""
+	entryJSON, _ := json.Marshal(e)
+	sum := sha256.Sum256(entryJSON)
+	return hex.EncodeToString(sum[:])
+}
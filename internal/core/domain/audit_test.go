@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEntryChain_ShouldLinkEntriesThatVerify(t *testing.T) {
+	at := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	message := NewMessage("1", "content").OwnedBy("user-1")
+
+	first := NewAuditEntry(at, DefaultTenant, ActionCreate, message.ID, nil).
+		By("user-1", "request-1").
+		WithSnapshots(nil, NewAuditSnapshot(message)).
+		Chain(AuditEntry{})
+	second := NewAuditEntry(at, DefaultTenant, ActionDelete, message.ID, errors.New("forbidden")).
+		Chain(first)
+
+	assert.Equal(t, uint64(1), first.Sequence)
+	assert.Empty(t, first.PreviousHash)
+	assert.Len(t, first.Hash, 64)
+	assert.Equal(t, uint64(2), second.Sequence)
+	assert.Equal(t, first.Hash, second.PreviousHash)
+	assert.Equal(t, AuditOutcomeFailure, second.Outcome)
+	assert.Equal(t, "forbidden", second.Error)
+	assert.NoError(t, first.Verify(AuditEntry{}))
+	assert.NoError(t, second.Verify(first))
+}
+
+func TestAuditEntryVerify_ShouldDetectTampering(t *testing.T) {
+	at := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	first := NewAuditEntry(at, DefaultTenant, ActionCreate, "1", nil).
+		WithSnapshots(nil, &AuditSnapshot{ID: "1", Content: "content"}).
+		Chain(AuditEntry{})
+	second := NewAuditEntry(at, DefaultTenant, ActionCreate, "2", nil).Chain(first)
+	third := NewAuditEntry(at, DefaultTenant, ActionDelete, "1", nil).Chain(second)
+
+	altered := first
+	altered.After = &AuditSnapshot{ID: "1", Content: "altered"}
+	assert.ErrorContains(t, altered.Verify(AuditEntry{}), "does not match its hash")
+
+	rehashed := altered.Chain(AuditEntry{})
+	assert.ErrorContains(t, second.Verify(rehashed), "does not hold the hash")
+
+	assert.ErrorContains(t, third.Verify(first), "follows entry 1")
+}
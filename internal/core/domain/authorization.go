@@ -0,0 +1,54 @@
+package domain
+
+import "slices"
+
+// Role is granted to a principal by the scope of the same name. Each role
+// includes the ones before it: writers are readers and admins are writers.
+type Role string
+
+const (
+	RoleReader Role = "reader"
+	RoleWriter Role = "writer"
+	RoleAdmin  Role = ScopeAdmin
+
+	// RoleCrossTenant lets a principal without a tenant act in any tenant.
+	// It stands apart from the roles above, neither including nor included
+	// in them.
+	RoleCrossTenant Role = "cross-tenant"
+)
+
+var roles = []Role{RoleReader, RoleWriter, RoleAdmin}
+
+// Action is what a principal does with messages.
+type Action string
+
+const (
+	ActionRead    Action = "read"
+	ActionCreate  Action = "create"
+	ActionDelete  Action = "delete"
+	ActionRestore Action = "restore"
+	ActionPurge   Action = "purge"
+	ActionImport  Action = "import"
+	ActionAudit   Action = "audit"
+)
+
+func (p Principal) HasRole(role Role) bool {
+	if role == RoleCrossTenant {
+		return slices.Contains(p.Scopes, string(role))
+	}
+
+	required := slices.Index(roles, role)
+	if required < 0 {
+		return false
+	}
+	for _, granted := range roles[required:] {
+		if slices.Contains(p.Scopes, string(granted)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Principal) Owns(message Message) bool {
+	return message.Owner != "" && message.Owner == p.Subject
+}
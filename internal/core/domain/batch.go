@@ -0,0 +1,33 @@
+package domain
+
+type BatchMode string
+
+const (
+	BatchModeAtomic     BatchMode = "atomic"
+	BatchModeBestEffort BatchMode = "best_effort"
+)
+
+func (m BatchMode) IsValid() bool {
+	return m == BatchModeAtomic || m == BatchModeBestEffort
+}
+
+type BatchResult struct {
+	ID  string
+	Err error
+}
+
+func NewBatchResult(id string, err error) BatchResult {
+	return BatchResult{
+		ID:  id,
+		Err: err,
+	}
+}
+
+func HasBatchFailures(results []BatchResult) bool {
+	for _, result := range results {
+		if result.Err != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package domain
+
+import "time"
+
+const (
+	EventMessageCreated = "message.created"
+	EventMessageUpdated = "message.updated"
+	EventMessageDeleted = "message.deleted"
+)
+
+type Event interface {
+	EventName() string
+	AggregateID() string
+	OccurredAt() time.Time
+}
+
+type MessageCreated struct {
+	Message   Message   `json:"message"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewMessageCreated(message Message, occurredAt time.Time) MessageCreated {
+	return MessageCreated{
+		Message:   message,
+		Timestamp: occurredAt,
+	}
+}
+
+func (e MessageCreated) EventName() string {
+	return EventMessageCreated
+}
+
+func (e MessageCreated) AggregateID() string {
+	return e.Message.ID
+}
+
+func (e MessageCreated) OccurredAt() time.Time {
+	return e.Timestamp
+}
+
+type MessageUpdated struct {
+	Previous  Message   `json:"previous"`
+	Message   Message   `json:"message"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewMessageUpdated(previous Message, message Message, occurredAt time.Time) MessageUpdated {
+	return MessageUpdated{
+		Previous:  previous,
+		Message:   message,
+		Timestamp: occurredAt,
+	}
+}
+
+func (e MessageUpdated) EventName() string {
+	return EventMessageUpdated
+}
+
+func (e MessageUpdated) AggregateID() string {
+	return e.Message.ID
+}
+
+func (e MessageUpdated) OccurredAt() time.Time {
+	return e.Timestamp
+}
+
+// MessageDeleted records the owner of the deleted message, so that the change
+// is only shown to who could read the message.
+type MessageDeleted struct {
+	MessageID string    `json:"message_id"`
+	Owner     string    `json:"owner,omitempty"`
+	Timestamp time.Time `json:"occurred_at"`
+}
+
+func NewMessageDeleted(messageID string, occurredAt time.Time) MessageDeleted {
+	return MessageDeleted{
+		MessageID: messageID,
+		Timestamp: occurredAt,
+	}
+}
+
+func (e MessageDeleted) OwnedBy(owner string) MessageDeleted {
+	e.Owner = owner
+	return e
+}
+
+func (e MessageDeleted) EventName() string {
+	return EventMessageDeleted
+}
+
+func (e MessageDeleted) AggregateID() string {
+	return e.MessageID
+}
+
+func (e MessageDeleted) OccurredAt() time.Time {
+	return e.Timestamp
+}
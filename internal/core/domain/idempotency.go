@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+type IdempotencyRecord struct {
+	Key         string    `json:"key"`
+	Fingerprint string    `json:"fingerprint"`
+	StatusCode  int       `json:"status_code"`
+	ContentType string    `json:"content_type"`
+	Body        []byte    `json:"body"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func NewIdempotencyRecord(key string, fingerprint string, statusCode int, contentType string, body []byte, expiresAt time.Time) IdempotencyRecord {
+	return IdempotencyRecord{
+		Key:         key,
+		Fingerprint: fingerprint,
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		Body:        body,
+		ExpiresAt:   expiresAt,
+	}
+}
+
+func (r IdempotencyRecord) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
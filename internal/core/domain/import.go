@@ -0,0 +1,79 @@
+package domain
+
+type ImportIDMode string
+
+const (
+	ImportIDsPreserve   ImportIDMode = "preserve"
+	ImportIDsRegenerate ImportIDMode = "regenerate"
+)
+
+func (m ImportIDMode) IsValid() bool {
+	return m == ImportIDsPreserve || m == ImportIDsRegenerate
+}
+
+// ImportConflictPolicy decides what happens to an imported message whose id
+// is already stored.
+type ImportConflictPolicy string
+
+const (
+	ImportConflictSkip      ImportConflictPolicy = "skip"
+	ImportConflictOverwrite ImportConflictPolicy = "overwrite"
+	ImportConflictFail      ImportConflictPolicy = "fail"
+)
+
+func (p ImportConflictPolicy) IsValid() bool {
+	return p == ImportConflictSkip || p == ImportConflictOverwrite || p == ImportConflictFail
+}
+
+type ImportOptions struct {
+	IDs      ImportIDMode
+	Conflict ImportConflictPolicy
+}
+
+func NewImportOptions(ids ImportIDMode, conflict ImportConflictPolicy) ImportOptions {
+	return ImportOptions{
+		IDs:      ids,
+		Conflict: conflict,
+	}
+}
+
+// ImportRecord is a message read from an import, with the line it came from.
+// Err is set when the line could not be read as a message.
+type ImportRecord struct {
+	Line    int
+	Message Message
+	Err     error
+}
+
+func NewImportRecord(line int, message Message, err error) ImportRecord {
+	return ImportRecord{
+		Line:    line,
+		Message: message,
+		Err:     err,
+	}
+}
+
+type ImportFailure struct {
+	Line int
+	ID   string
+	Err  error
+}
+
+// ImportReport counts the committed outcome of an import.
+type ImportReport struct {
+	Created     int
+	Overwritten int
+	Skipped     int
+	Failures    []ImportFailure
+}
+
+func (r *ImportReport) Fail(record ImportRecord, err error) {
+	r.Failures = append(r.Failures, ImportFailure{Line: record.Line, ID: record.Message.ID, Err: err})
+}
+
+func (r *ImportReport) Add(other ImportReport) {
+	r.Created += other.Created
+	r.Overwritten += other.Overwritten
+	r.Skipped += other.Skipped
+	r.Failures = append(r.Failures, other.Failures...)
+}
@@ -1,8 +1,15 @@
 package domain
 
+import "time"
+
+// Message is owned by the subject of the principal that created it. Owner is
+// empty for messages created while authentication was disabled. A deleted
+// message stays in the trash, with DeletedAt set, until it is purged.
 type Message struct {
-	ID      string `json:"id"`
-	Content string `json:"content"`
+	ID        string     `json:"id"`
+	Content   string     `json:"content"`
+	Owner     string     `json:"owner,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 func NewMessage(messageID string, content string) Message {
@@ -11,3 +18,23 @@ func NewMessage(messageID string, content string) Message {
 		Content: content,
 	}
 }
+
+func (m Message) OwnedBy(owner string) Message {
+	m.Owner = owner
+	return m
+}
+
+func (m Message) DeletedOn(at time.Time) Message {
+	deletedAt := at.UTC()
+	m.DeletedAt = &deletedAt
+	return m
+}
+
+func (m Message) Restored() Message {
+	m.DeletedAt = nil
+	return m
+}
+
+func (m Message) IsDeleted() bool {
+	return m.DeletedAt != nil
+}
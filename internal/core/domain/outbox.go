@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// OutboxEntry is an event waiting to be published for the tenant it happened
+// in. Entries recorded without a tenant belong to DefaultTenant.
+type OutboxEntry struct {
+	Sequence    uint64    `json:"sequence"`
+	Tenant      string    `json:"tenant,omitempty"`
+	AggregateID string    `json:"aggregate_id"`
+	EventName   string    `json:"event_name"`
+	Payload     []byte    `json:"payload"`
+	Attempts    int       `json:"attempts"`
+	LastError   string    `json:"last_error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewOutboxEntry(tenant string, event Event) (OutboxEntry, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return OutboxEntry{}, err
+	}
+
+	return OutboxEntry{
+		Tenant:      tenant,
+		AggregateID: event.AggregateID(),
+		EventName:   event.EventName(),
+		Payload:     payload,
+		CreatedAt:   event.OccurredAt(),
+	}, nil
+}
+
+func (e OutboxEntry) Event() (Event, error) {
+	return DecodeEvent(e.EventName, e.Payload)
+}
+
+func DecodeEvent(name string, payload []byte) (Event, error) {
+	switch name {
+	case EventMessageCreated:
+		return decodeEvent[MessageCreated](payload)
+	case EventMessageUpdated:
+		return decodeEvent[MessageUpdated](payload)
+	case EventMessageDeleted:
+		return decodeEvent[MessageDeleted](payload)
+	default:
+		return nil, fmt.Errorf("unknown event %q", name)
+	}
+}
+
+func decodeEvent[E Event](payload []byte) (Event, error) {
+	var event E
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxEntry_ShouldDecodeRecordedEvents(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	message := NewMessage("id", "message content")
+	events := []Event{
+		NewMessageCreated(message, now),
+		NewMessageUpdated(message, NewMessage("id", "new content"), now),
+		NewMessageDeleted(message.ID, now),
+	}
+
+	for _, expectedEvent := range events {
+		entry, err := NewOutboxEntry("acme", expectedEvent)
+		assert.NoError(t, err)
+		assert.Equal(t, "acme", entry.Tenant)
+		assert.Equal(t, expectedEvent.EventName(), entry.EventName)
+		assert.Equal(t, message.ID, entry.AggregateID)
+		assert.Equal(t, now, entry.CreatedAt)
+
+		actualEvent, err := entry.Event()
+		assert.NoError(t, err)
+		assert.Equal(t, expectedEvent, actualEvent)
+	}
+}
+
+func TestOutboxEntry_ShouldReturnErrorWhenEventIsUnknown(t *testing.T) {
+	entry := OutboxEntry{EventName: "message.archived", Payload: []byte(`{}`)}
+
+	event, err := entry.Event()
+
+	assert.Error(t, err)
+	assert.Nil(t, event)
+}
+
+func TestOutboxEntry_ShouldReturnErrorWhenPayloadIsInvalid(t *testing.T) {
+	entry := OutboxEntry{EventName: EventMessageCreated, Payload: []byte(`{`)}
+
+	event, err := entry.Event()
+
+	assert.Error(t, err)
+	assert.Nil(t, event)
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "context"
+
+// Principal is who a request was authenticated as. A principal with a Tenant
+// is bound to it, and one without is bound to the default tenant unless it has
+// the cross-tenant role.
+type Principal struct {
+	Subject string
+	Scopes  []string
+	Tenant  string
+}
+
+func NewPrincipal(subject string, scopes []string) Principal {
+	return Principal{
+		Subject: subject,
+		Scopes:  scopes,
+	}
+}
+
+func (p Principal) InTenant(tenant string) Principal {
+	p.Tenant = tenant
+	return p
+}
+
+type principalKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns false when the request was not authenticated,
+// which is the case of every request when authentication is disabled.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
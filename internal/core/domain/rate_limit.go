@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// RateLimit allows Requests per Period, all of which may come at once. It is
+// enforced with the generic cell rate algorithm (GCRA), which only keeps, per
+// key, the theoretical arrival time (TAT) of the next request.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+func NewRateLimit(requests int, period time.Duration) RateLimit {
+	return RateLimit{
+		Requests: requests,
+		Period:   period,
+	}
+}
+
+func (l RateLimit) IsUnlimited() bool {
+	return l.Requests <= 0 || l.Period <= 0
+}
+
+// RateLimitDecision tells whether a request was allowed, how many more would
+// be right away, and when the limit is whole again. RetryAfter is only set on
+// denied requests.
+type RateLimitDecision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// Take decides on a request arriving at now, given the TAT stored for its
+// key, which is zero when none is. It returns the TAT to store, unchanged
+// when the request is denied. A TAT in the past is the same as none.
+func (l RateLimit) Take(tat time.Time, now time.Time) (time.Time, RateLimitDecision) {
+	interval := l.Period / time.Duration(l.Requests)
+	if tat.Before(now) {
+		tat = now
+	}
+
+	next := tat.Add(interval)
+	allowAt := next.Add(-l.Period)
+	if now.Before(allowAt) {
+		return tat, RateLimitDecision{
+			Limit:      l.Requests,
+			ResetAfter: tat.Sub(now),
+			RetryAfter: allowAt.Sub(now),
+		}
+	}
+	return next, RateLimitDecision{
+		Allowed:    true,
+		Limit:      l.Requests,
+		Remaining:  int(now.Sub(allowAt) / interval),
+		ResetAfter: next.Sub(now),
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitTake_ShouldAllowBurstThenSpaceRequests(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	limit := NewRateLimit(3, 3*time.Second)
+
+	var tat time.Time
+	var decision RateLimitDecision
+	for remaining := 2; remaining >= 0; remaining-- {
+		tat, decision = limit.Take(tat, now)
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, remaining, decision.Remaining)
+	}
+	assert.Equal(t, 3*time.Second, decision.ResetAfter)
+
+	denied, decision := limit.Take(tat, now.Add(500*time.Millisecond))
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, tat, denied)
+	assert.Equal(t, 500*time.Millisecond, decision.RetryAfter)
+	assert.Equal(t, 3, decision.Limit)
+
+	_, decision = limit.Take(tat, now.Add(time.Second))
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+}
+
+func TestRateLimitTake_ShouldForgetPastArrivalTime(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	limit := NewRateLimit(2, time.Minute)
+
+	_, decision := limit.Take(now.Add(-time.Hour), now)
+
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 1, decision.Remaining)
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "context"
+
+// DefaultTenant holds the messages of requests that name no tenant, which
+// are all of them when tenancy is disabled.
+const DefaultTenant = "default"
+
+type tenantKey struct{}
+
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns DefaultTenant when ctx carries no tenant.
+func TenantFromContext(ctx context.Context) string {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	if !ok || tenant == "" {
+		return DefaultTenant
+	}
+	return tenant
+}
@@ -0,0 +1,22 @@
+package domain
+
+import "time"
+
+const usageDayLayout = "2006-01-02"
+
+// DailyUsage is what a tenant stored on Day, a UTC date.
+type DailyUsage struct {
+	Day      string `json:"day"`
+	Messages int    `json:"messages"`
+}
+
+// Add counts messages stored at the given time, starting over when it falls
+// on another day than the usage.
+func (u DailyUsage) Add(at time.Time, messages int) DailyUsage {
+	day := at.UTC().Format(usageDayLayout)
+	if u.Day != day {
+		u = DailyUsage{Day: day}
+	}
+	u.Messages += messages
+	return u
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyUsageAdd_ShouldCountMessagesOfSameDay(t *testing.T) {
+	at := time.Date(2023, 10, 1, 23, 0, 0, 0, time.FixedZone("UTC-3", -3*60*60))
+
+	usage := DailyUsage{}.Add(at, 2).Add(at.Add(30*time.Minute), 3)
+
+	assert.Equal(t, DailyUsage{Day: "2023-10-02", Messages: 5}, usage)
+}
+
+func TestDailyUsageAdd_ShouldStartOverOnAnotherDay(t *testing.T) {
+	at := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	usage := DailyUsage{}.Add(at, 2).Add(at.AddDate(0, 0, 1), 1)
+
+	assert.Equal(t, DailyUsage{Day: "2023-10-02", Messages: 1}, usage)
+}
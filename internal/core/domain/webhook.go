@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"encoding/json"
+	"net/netip"
+	"slices"
+	"time"
+)
+
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+const maxWebhookBackoff = time.Hour
+
+// Webhook receives the events of the tenant it was registered in.
+type Webhook struct {
+	ID        string    `json:"id"`
+	Tenant    string    `json:"tenant,omitempty"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewWebhook(id string, tenant string, url string, secret string, events []string, createdAt time.Time) Webhook {
+	return Webhook{
+		ID:        id,
+		Tenant:    tenant,
+		URL:       url,
+		Secret:    secret,
+		Events:    events,
+		CreatedAt: createdAt,
+	}
+}
+
+// IsPublicAddress reports whether webhooks may be sent to the address.
+// Loopback, link-local, private, unspecified and multicast addresses are
+// refused, so that webhooks cannot reach the services next to the API.
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsPrivate() &&
+		!addr.IsUnspecified()
+}
+
+// Subscribes reports whether the webhook wants the event. A webhook without
+// events receives all of them.
+func (w Webhook) Subscribes(eventName string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, eventName)
+}
+
+// BelongsTo reports whether the webhook was registered in the tenant.
+func (w Webhook) BelongsTo(tenant string) bool {
+	return belongsTo(w.Tenant, tenant)
+}
+
+func IsKnownEvent(name string) bool {
+	switch name {
+	case EventMessageCreated, EventMessageUpdated, EventMessageDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+type WebhookDelivery struct {
+	ID            string           `json:"id"`
+	WebhookID     string           `json:"webhook_id"`
+	Tenant        string           `json:"tenant,omitempty"`
+	EventName     string           `json:"event_name"`
+	AggregateID   string           `json:"aggregate_id"`
+	Payload       []byte           `json:"payload"`
+	Status        string           `json:"status"`
+	Attempts      []WebhookAttempt `json:"attempts"`
+	RedeliveryOf  string           `json:"redelivery_of,omitempty"`
+	NextAttemptAt time.Time        `json:"next_attempt_at"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+type WebhookAttempt struct {
+	At         time.Time     `json:"at"`
+	StatusCode int           `json:"status_code,omitempty"`
+	Error      string        `json:"error,omitempty"`
+	Duration   time.Duration `json:"duration"`
+}
+
+type webhookPayload struct {
+	ID          string    `json:"id"`
+	Event       string    `json:"event"`
+	AggregateID string    `json:"aggregate_id"`
+	OccurredAt  time.Time `json:"occurred_at"`
+	Data        Event     `json:"data"`
+}
+
+// NewWebhookDelivery queues the event to the webhook, in the tenant of the
+// webhook.
+func NewWebhookDelivery(id string, webhook Webhook, event Event, now time.Time) (WebhookDelivery, error) {
+	payload, err := json.Marshal(webhookPayload{
+		ID:          id,
+		Event:       event.EventName(),
+		AggregateID: event.AggregateID(),
+		OccurredAt:  event.OccurredAt(),
+		Data:        event,
+	})
+	if err != nil {
+		return WebhookDelivery{}, err
+	}
+
+	return WebhookDelivery{
+		ID:            id,
+		WebhookID:     webhook.ID,
+		Tenant:        webhook.Tenant,
+		EventName:     event.EventName(),
+		AggregateID:   event.AggregateID(),
+		Payload:       payload,
+		Status:        DeliveryStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// Redeliver returns a new pending delivery with the same payload, keeping the
+// attempts of the original one in its own log.
+func (d WebhookDelivery) Redeliver(id string, now time.Time) WebhookDelivery {
+	return WebhookDelivery{
+		ID:            id,
+		WebhookID:     d.WebhookID,
+		Tenant:        d.Tenant,
+		EventName:     d.EventName,
+		AggregateID:   d.AggregateID,
+		Payload:       d.Payload,
+		Status:        DeliveryStatusPending,
+		RedeliveryOf:  d.ID,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+func (d WebhookDelivery) BelongsTo(tenant string) bool {
+	return belongsTo(d.Tenant, tenant)
+}
+
+func (d WebhookDelivery) IsDue(now time.Time) bool {
+	return d.Status == DeliveryStatusPending && !now.Before(d.NextAttemptAt)
+}
+
+// RecordAttempt appends the attempt to the log. A failed attempt is retried
+// with an exponential backoff starting at backoff until maxAttempts is
+// reached.
+func (d WebhookDelivery) RecordAttempt(attempt WebhookAttempt, maxAttempts int, backoff time.Duration) WebhookDelivery {
+	d.Attempts = append(slices.Clone(d.Attempts), attempt)
+
+	switch {
+	case attempt.Error == "":
+		d.Status = DeliveryStatusSucceeded
+	case len(d.Attempts) >= maxAttempts:
+		d.Status = DeliveryStatusFailed
+	default:
+		d.NextAttemptAt = attempt.At.Add(retryBackoff(backoff, len(d.Attempts)))
+	}
+	return d
+}
+
+func retryBackoff(backoff time.Duration, attempts int) time.Duration {
+	delay := backoff
+	for i := 1; i < attempts && delay < maxWebhookBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxWebhookBackoff)
+}
+
+// belongsTo compares the tenant recorded on a webhook or delivery with
+// tenant. Those stored before tenants were recorded belong to DefaultTenant.
+func belongsTo(recorded string, tenant string) bool {
+	if recorded == "" {
+		recorded = DefaultTenant
+	}
+	return recorded == tenant
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook_ShouldSubscribeToAllEventsWhenEventsAreEmpty(t *testing.T) {
+	webhook := NewWebhook("id", DefaultTenant, "http://localhost", "secret", nil, time.Time{})
+
+	assert.True(t, webhook.Subscribes(EventMessageCreated))
+	assert.True(t, webhook.Subscribes(EventMessageDeleted))
+}
+
+func TestWebhook_ShouldSubscribeOnlyToSelectedEvents(t *testing.T) {
+	webhook := NewWebhook("id", DefaultTenant, "http://localhost", "secret", []string{EventMessageCreated}, time.Time{})
+
+	assert.True(t, webhook.Subscribes(EventMessageCreated))
+	assert.False(t, webhook.Subscribes(EventMessageDeleted))
+}
+
+func TestIsPublicAddress_ShouldRefuseLocalAndPrivateAddresses(t *testing.T) {
+	tests := map[string]bool{
+		"93.184.216.34":        true,
+		"2606:2800:220:1::":    true,
+		"127.0.0.1":            false,
+		"::1":                  false,
+		"169.254.169.254":      false,
+		"fe80::1":              false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.0.1":          false,
+		"fd00::1":              false,
+		"::ffff:10.1.2.3":      false,
+		"0.0.0.0":              false,
+		"::":                   false,
+		"224.0.0.1":            false,
+		"::ffff:93.184.216.34": true,
+	}
+	for address, expected := range tests {
+		assert.Equal(t, expected, IsPublicAddress(netip.MustParseAddr(address)), address)
+	}
+	assert.False(t, IsPublicAddress(netip.Addr{}))
+}
+
+func TestWebhookDelivery_ShouldBuildPayloadFromEvent(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	event := NewMessageCreated(NewMessage("message-id", "message content"), now)
+
+	webhook := NewWebhook("webhook-id", "tenant-a", "http://localhost", "secret", nil, now)
+
+	delivery, err := NewWebhookDelivery("delivery-id", webhook, event, now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "webhook-id", delivery.WebhookID)
+	assert.Equal(t, "tenant-a", delivery.Tenant)
+	assert.Equal(t, DeliveryStatusPending, delivery.Status)
+	assert.Equal(t, EventMessageCreated, delivery.EventName)
+	assert.Equal(t, "message-id", delivery.AggregateID)
+	assert.True(t, delivery.IsDue(now))
+	assert.JSONEq(t, `{
+		"id": "delivery-id",
+		"event": "message.created",
+		"aggregate_id": "message-id",
+		"occurred_at": "2023-10-01T12:00:00Z",
+		"data": {"message": {"id": "message-id", "content": "message content"}, "occurred_at": "2023-10-01T12:00:00Z"}
+	}`, string(delivery.Payload))
+}
+
+func TestWebhookDelivery_ShouldRetryWithExponentialBackoff(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	delivery := WebhookDelivery{ID: "id", Status: DeliveryStatusPending, NextAttemptAt: now}
+
+	delivery = delivery.RecordAttempt(WebhookAttempt{At: now, Error: "timeout"}, 3, time.Second)
+	assert.Equal(t, DeliveryStatusPending, delivery.Status)
+	assert.Equal(t, now.Add(time.Second), delivery.NextAttemptAt)
+	assert.False(t, delivery.IsDue(now))
+
+	delivery = delivery.RecordAttempt(WebhookAttempt{At: now, StatusCode: 500, Error: "unexpected status"}, 3, time.Second)
+	assert.Equal(t, DeliveryStatusPending, delivery.Status)
+	assert.Equal(t, now.Add(2*time.Second), delivery.NextAttemptAt)
+
+	delivery = delivery.RecordAttempt(WebhookAttempt{At: now, Error: "timeout"}, 3, time.Second)
+	assert.Equal(t, DeliveryStatusFailed, delivery.Status)
+	assert.Len(t, delivery.Attempts, 3)
+	assert.False(t, delivery.IsDue(now.Add(time.Hour)))
+}
+
+func TestWebhookDelivery_ShouldSucceedWhenAttemptHasNoError(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	delivery := WebhookDelivery{ID: "id", Status: DeliveryStatusPending}
+
+	delivery = delivery.RecordAttempt(WebhookAttempt{At: now, StatusCode: 204}, 3, time.Second)
+
+	assert.Equal(t, DeliveryStatusSucceeded, delivery.Status)
+	assert.Equal(t, []WebhookAttempt{{At: now, StatusCode: 204}}, delivery.Attempts)
+}
+
+func TestWebhookDelivery_ShouldCapBackoff(t *testing.T) {
+	assert.Equal(t, maxWebhookBackoff, retryBackoff(time.Minute, 20))
+}
+
+func TestWebhookDelivery_ShouldRedeliverWithSamePayload(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	original := WebhookDelivery{
+		ID:        "original-id",
+		WebhookID: "webhook-id",
+		EventName: EventMessageDeleted,
+		Payload:   []byte(`{}`),
+		Status:    DeliveryStatusFailed,
+		Attempts:  []WebhookAttempt{{At: now, Error: "timeout"}},
+	}
+
+	redelivery := original.Redeliver("new-id", now)
+
+	assert.Equal(t, "new-id", redelivery.ID)
+	assert.Equal(t, "original-id", redelivery.RedeliveryOf)
+	assert.Equal(t, original.Payload, redelivery.Payload)
+	assert.Equal(t, DeliveryStatusPending, redelivery.Status)
+	assert.Empty(t, redelivery.Attempts)
+	assert.True(t, redelivery.IsDue(now))
+}
+
+func TestWebhookDelivery_ShouldReturnErrorWhenEventCannotBeEncoded(t *testing.T) {
+	_, err := NewWebhookDelivery("id", Webhook{ID: "webhook-id"}, unencodableEvent{}, time.Time{})
+
+	assert.Error(t, err)
+}
+
+type unencodableEvent struct{}
+
+func (unencodableEvent) EventName() string     { return "unencodable" }
+func (unencodableEvent) AggregateID() string   { return "" }
+func (unencodableEvent) OccurredAt() time.Time { return time.Time{} }
+func (unencodableEvent) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("cannot encode")
+}
@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type GetAPIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	Tenant     string     `json:"tenant"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreateAPIKeyResponse is the only response carrying the key, which is not
+// stored and cannot be shown again.
+type CreateAPIKeyResponse struct {
+	GetAPIKeyResponse
+	Key string `json:"key"`
+}
+
+func BuildResponseCreateAPIKey(key domain.APIKey, secret string) CreateAPIKeyResponse {
+	return CreateAPIKeyResponse{
+		GetAPIKeyResponse: BuildResponseGetAPIKey(key),
+		Key:               secret,
+	}
+}
+
+func BuildResponseGetAPIKey(key domain.APIKey) GetAPIKeyResponse {
+	scopes := key.Scopes
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return GetAPIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Scopes:     scopes,
+		Tenant:     key.TenantOrDefault(),
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  optionalTime(key.ExpiresAt),
+		LastUsedAt: optionalTime(key.LastUsedAt),
+		RevokedAt:  optionalTime(key.RevokedAt),
+	}
+}
+
+func BuildResponseGetAPIKeys(keys []domain.APIKey) []GetAPIKeyResponse {
+	keysDto := []GetAPIKeyResponse{}
+	for _, key := range keys {
+		keysDto = append(keysDto, BuildResponseGetAPIKey(key))
+	}
+	return keysDto
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+// AuditEntryResponse has the fields of domain.AuditEntry under the same
+// names, so that exported entries can be checked against their hash.
+type AuditEntryResponse struct {
+	Sequence     uint64                 `json:"sequence"`
+	Time         time.Time              `json:"time"`
+	Tenant       string                 `json:"tenant"`
+	Action       string                 `json:"action" openapi:"enum=create|delete|restore|import|purge"`
+	MessageID    string                 `json:"message_id,omitempty"`
+	Principal    string                 `json:"principal,omitempty"`
+	RequestID    string                 `json:"request_id,omitempty"`
+	Before       *AuditSnapshotResponse `json:"before,omitempty"`
+	After        *AuditSnapshotResponse `json:"after,omitempty"`
+	Outcome      string                 `json:"outcome" openapi:"enum=success|failure"`
+	Error        string                 `json:"error,omitempty"`
+	PreviousHash string                 `json:"previous_hash"`
+	Hash         string                 `json:"hash"`
+}
+
+type AuditSnapshotResponse struct {
+	ID      string `json:"id"`
+	Content string `json:"content"`
+	Owner   string `json:"owner,omitempty"`
+}
+
+func BuildResponseAuditEntry(entry domain.AuditEntry) AuditEntryResponse {
+	return AuditEntryResponse{
+		Sequence:     entry.Sequence,
+		Time:         entry.Time,
+		Tenant:       entry.Tenant,
+		Action:       string(entry.Action),
+		MessageID:    entry.MessageID,
+		Principal:    entry.Principal,
+		RequestID:    entry.RequestID,
+		Before:       buildResponseAuditSnapshot(entry.Before),
+		After:        buildResponseAuditSnapshot(entry.After),
+		Outcome:      string(entry.Outcome),
+		Error:        entry.Error,
+		PreviousHash: entry.PreviousHash,
+		Hash:         entry.Hash,
+	}
+}
+
+func buildResponseAuditSnapshot(snapshot *domain.AuditSnapshot) *AuditSnapshotResponse {
+	if snapshot == nil {
+		return nil
+	}
+	return &AuditSnapshotResponse{
+		ID:      snapshot.ID,
+		Content: snapshot.Content,
+		Owner:   snapshot.Owner,
+	}
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"errors"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+const (
+	BatchItemStatusCreated = "created"
+	BatchItemStatusDeleted = "deleted"
+	BatchItemStatusFailed  = "failed"
+	BatchItemStatusAborted = "aborted"
+)
+
+type BatchCreateMessagesRequest struct {
+	Mode     string                 `json:"mode,omitempty" openapi:"enum=atomic|best_effort"`
+	Messages []CreateMessageRequest `json:"messages" openapi:"minItems=1,maxItems=1000"`
+}
+
+type BatchDeleteMessagesRequest struct {
+	Mode string   `json:"mode,omitempty" openapi:"enum=atomic|best_effort"`
+	IDs  []string `json:"ids" openapi:"minItems=1,maxItems=1000"`
+}
+
+type BatchMessagesResponse struct {
+	Mode    string              `json:"mode"`
+	Results []BatchItemResponse `json:"results"`
+}
+
+type BatchItemResponse struct {
+	Index  int    `json:"index"`
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func BuildResponseBatchMessages(mode domain.BatchMode, results []domain.BatchResult, succeededStatus string) BatchMessagesResponse {
+	itemsDto := []BatchItemResponse{}
+	for i, result := range results {
+		item := BatchItemResponse{Index: i, ID: result.ID, Status: succeededStatus}
+		if errors.Is(result.Err, apperrors.Aborted) {
+			item.Status = BatchItemStatusAborted
+		} else if result.Err != nil {
+			item.Status = BatchItemStatusFailed
+			item.Error = result.Err.Error()
+		}
+		itemsDto = append(itemsDto, item)
+	}
+
+	return BatchMessagesResponse{
+		Mode:    string(mode),
+		Results: itemsDto,
+	}
+}
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type ChangeResponse struct {
+	ID          uint64       `json:"id"`
+	Event       string       `json:"event"`
+	AggregateID string       `json:"aggregate_id"`
+	OccurredAt  time.Time    `json:"occurred_at"`
+	Data        domain.Event `json:"data"`
+}
+
+func BuildResponseChange(id uint64, event domain.Event) ChangeResponse {
+	return ChangeResponse{
+		ID:          id,
+		Event:       event.EventName(),
+		AggregateID: event.AggregateID(),
+		OccurredAt:  event.OccurredAt(),
+		Data:        event,
+	}
+}
@@ -0,0 +1,15 @@
+package dto
+
+// ErrorResponse is the body of every error response of the HTTP API.
+// Violations lists what did not match the API contract, when that was the
+// cause.
+type ErrorResponse struct {
+	Error      string              `json:"error"`
+	Violations []ViolationResponse `json:"violations,omitempty"`
+}
+
+type ViolationResponse struct {
+	In      string `json:"in"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
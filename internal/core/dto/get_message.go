@@ -2,10 +2,18 @@ package dto
 
 import "github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
 
-type GetMessageResponse domain.Message
+// GetMessageResponse is the message resource of v1, which does not show the
+// owner.
+type GetMessageResponse struct {
+	ID      string `json:"id"`
+	Content string `json:"content"`
+}
 
 func BuildResponseGetMessage(message domain.Message) GetMessageResponse {
-	return GetMessageResponse(message)
+	return GetMessageResponse{
+		ID:      message.ID,
+		Content: message.Content,
+	}
 }
 
 func BuildResponseGetMessages(messages []domain.Message) []GetMessageResponse {
@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+
+// MessageResponseV2 is the message resource of the v2 API, returned by every
+// v2 route that reads or creates messages.
+type MessageResponseV2 struct {
+	ID      string `json:"id"`
+	Content string `json:"content"`
+	Owner   string `json:"owner,omitempty"`
+}
+
+// ListMessagesResponseV2 wraps the list so that fields such as a page cursor
+// can be added without breaking clients.
+type ListMessagesResponseV2 struct {
+	Messages []MessageResponseV2 `json:"messages"`
+}
+
+func BuildResponseMessageV2(message domain.Message) MessageResponseV2 {
+	return MessageResponseV2{
+		ID:      message.ID,
+		Content: message.Content,
+		Owner:   message.Owner,
+	}
+}
+
+func BuildResponseListMessagesV2(messages []domain.Message) ListMessagesResponseV2 {
+	messagesDto := []MessageResponseV2{}
+	for _, message := range messages {
+		messagesDto = append(messagesDto, BuildResponseMessageV2(message))
+	}
+	return ListMessagesResponseV2{Messages: messagesDto}
+}
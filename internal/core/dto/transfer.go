@@ -0,0 +1,48 @@
+package dto
+
+import "github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+
+// ImportMessageRequest is a line of an import. ID is ignored when the ids are
+// regenerated.
+type ImportMessageRequest struct {
+	ID      string `json:"id,omitempty"`
+	Content string `json:"content"`
+}
+
+type ImportReportResponse struct {
+	Created     int                     `json:"created"`
+	Overwritten int                     `json:"overwritten"`
+	Skipped     int                     `json:"skipped"`
+	Failed      int                     `json:"failed"`
+	Failures    []ImportFailureResponse `json:"failures"`
+	Error       string                  `json:"error,omitempty"`
+}
+
+type ImportFailureResponse struct {
+	Line  int    `json:"line"`
+	ID    string `json:"id,omitempty"`
+	Error string `json:"error"`
+}
+
+func BuildResponseImportReport(report domain.ImportReport, err error) ImportReportResponse {
+	failuresDto := []ImportFailureResponse{}
+	for _, failure := range report.Failures {
+		failuresDto = append(failuresDto, ImportFailureResponse{
+			Line:  failure.Line,
+			ID:    failure.ID,
+			Error: failure.Err.Error(),
+		})
+	}
+
+	response := ImportReportResponse{
+		Created:     report.Created,
+		Overwritten: report.Overwritten,
+		Skipped:     report.Skipped,
+		Failed:      len(report.Failures),
+		Failures:    failuresDto,
+	}
+	if err != nil {
+		response.Error = err.Error()
+	}
+	return response
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+// TrashedMessageResponse is a deleted message, which can be restored until it
+// is purged.
+type TrashedMessageResponse struct {
+	ID        string    `json:"id"`
+	Content   string    `json:"content"`
+	Owner     string    `json:"owner,omitempty"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type ListTrashedMessagesResponse struct {
+	Messages []TrashedMessageResponse `json:"messages"`
+}
+
+func BuildResponseListTrashedMessages(messages []domain.Message) ListTrashedMessagesResponse {
+	messagesDto := []TrashedMessageResponse{}
+	for _, message := range messages {
+		messagesDto = append(messagesDto, TrashedMessageResponse{
+			ID:        message.ID,
+			Content:   message.Content,
+			Owner:     message.Owner,
+			DeletedAt: *message.DeletedAt,
+		})
+	}
+	return ListTrashedMessagesResponse{Messages: messagesDto}
+}
@@ -0,0 +1,114 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret,omitempty"`
+}
+
+type GetWebhookResponse struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateWebhookResponse is the only response carrying the signing secret.
+type CreateWebhookResponse struct {
+	GetWebhookResponse
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID            string                   `json:"id"`
+	WebhookID     string                   `json:"webhook_id"`
+	Event         string                   `json:"event"`
+	AggregateID   string                   `json:"aggregate_id"`
+	Status        string                   `json:"status"`
+	Payload       json.RawMessage          `json:"payload"`
+	Attempts      []WebhookAttemptResponse `json:"attempts"`
+	RedeliveryOf  string                   `json:"redelivery_of,omitempty"`
+	NextAttemptAt *time.Time               `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time                `json:"created_at"`
+}
+
+type WebhookAttemptResponse struct {
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+func BuildResponseCreateWebhook(webhook domain.Webhook) CreateWebhookResponse {
+	return CreateWebhookResponse{
+		GetWebhookResponse: BuildResponseGetWebhook(webhook),
+		Secret:             webhook.Secret,
+	}
+}
+
+func BuildResponseGetWebhook(webhook domain.Webhook) GetWebhookResponse {
+	events := webhook.Events
+	if events == nil {
+		events = []string{}
+	}
+
+	return GetWebhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    events,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+func BuildResponseGetWebhooks(webhooks []domain.Webhook) []GetWebhookResponse {
+	webhooksDto := []GetWebhookResponse{}
+	for _, webhook := range webhooks {
+		webhooksDto = append(webhooksDto, BuildResponseGetWebhook(webhook))
+	}
+	return webhooksDto
+}
+
+func BuildResponseWebhookDelivery(delivery domain.WebhookDelivery) WebhookDeliveryResponse {
+	attempts := []WebhookAttemptResponse{}
+	for _, attempt := range delivery.Attempts {
+		attempts = append(attempts, WebhookAttemptResponse{
+			At:         attempt.At,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			DurationMS: attempt.Duration.Milliseconds(),
+		})
+	}
+
+	var nextAttemptAt *time.Time
+	if delivery.Status == domain.DeliveryStatusPending {
+		nextAttemptAt = &delivery.NextAttemptAt
+	}
+
+	return WebhookDeliveryResponse{
+		ID:            delivery.ID,
+		WebhookID:     delivery.WebhookID,
+		Event:         delivery.EventName,
+		AggregateID:   delivery.AggregateID,
+		Status:        delivery.Status,
+		Payload:       json.RawMessage(delivery.Payload),
+		Attempts:      attempts,
+		RedeliveryOf:  delivery.RedeliveryOf,
+		NextAttemptAt: nextAttemptAt,
+		CreatedAt:     delivery.CreatedAt,
+	}
+}
+
+func BuildResponseWebhookDeliveries(deliveries []domain.WebhookDelivery) []WebhookDeliveryResponse {
+	deliveriesDto := []WebhookDeliveryResponse{}
+	for _, delivery := range deliveries {
+		deliveriesDto = append(deliveriesDto, BuildResponseWebhookDelivery(delivery))
+	}
+	return deliveriesDto
+}
@@ -0,0 +1,50 @@
+package dto
+
+const (
+	WebSocketCommandCreate      = "create"
+	WebSocketCommandGet         = "get"
+	WebSocketCommandDelete      = "delete"
+	WebSocketCommandSubscribe   = "subscribe"
+	WebSocketCommandUnsubscribe = "unsubscribe"
+
+	WebSocketReplyResult            = "result"
+	WebSocketReplyError             = "error"
+	WebSocketReplyChange            = "change"
+	WebSocketReplySubscriptionEnded = "subscription_ended"
+)
+
+// WebSocketCommand is a client frame. ID is chosen by the client and echoed in
+// the reply so that commands can be pipelined.
+type WebSocketCommand struct {
+	ID        string   `json:"id"`
+	Type      string   `json:"type"`
+	Content   string   `json:"content,omitempty"`
+	MessageID string   `json:"message_id,omitempty"`
+	Events    []string `json:"events,omitempty"`
+}
+
+type WebSocketReply struct {
+	ID     string `json:"id,omitempty"`
+	Type   string `json:"type"`
+	Status int    `json:"status,omitempty"`
+	Data   any    `json:"data,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func BuildWebSocketResult(id string, status int, data any) WebSocketReply {
+	return WebSocketReply{
+		ID:     id,
+		Type:   WebSocketReplyResult,
+		Status: status,
+		Data:   data,
+	}
+}
+
+func BuildWebSocketError(id string, status int, err error) WebSocketReply {
+	return WebSocketReply{
+		ID:     id,
+		Type:   WebSocketReplyError,
+		Status: status,
+		Error:  err.Error(),
+	}
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type APIKeyRepository interface {
+	Save(ctx context.Context, key domain.APIKey) error
+	GetByID(ctx context.Context, id string) (domain.APIKey, error)
+	GetAll(ctx context.Context) ([]domain.APIKey, error)
+	// Touch sets the last use of the key and leaves the rest of the stored
+	// key as it is, so that it cannot undo a concurrent revocation.
+	Touch(ctx context.Context, id string, usedAt time.Time) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type APIKeyUseCase interface {
+	// Create returns the key and its secret, which cannot be retrieved again.
+	Create(ctx context.Context, name string, scopes []string, expiresAt time.Time) (domain.APIKey, string, error)
+	GetAll(ctx context.Context) ([]domain.APIKey, error)
+	Revoke(ctx context.Context, id string) error
+	Authenticate(ctx context.Context, secret string) (domain.Principal, error)
+}
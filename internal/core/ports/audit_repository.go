@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+// AuditRepository keeps the audit log of every tenant. It only ever appends
+// to it: entries cannot be changed or removed through it.
+type AuditRepository interface {
+	// Append chains entry after the last one of the tenant in ctx, as a single
+	// atomic step, and returns it sealed.
+	Append(ctx context.Context, entry domain.AuditEntry) (domain.AuditEntry, error)
+	// ForEach calls fn with the entries of the tenant in ctx in sequence
+	// order, only those of messageID unless it is empty. It stops like
+	// MessageRepository.ForEach.
+	ForEach(ctx context.Context, messageID string, fn func(entry domain.AuditEntry) error) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type AuditUseCase interface {
+	// ForEach streams the audit log of the tenant in ctx, following the
+	// AuditRepository contract.
+	ForEach(ctx context.Context, messageID string, fn func(entry domain.AuditEntry) error) error
+}
@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...domain.Event) error
+}
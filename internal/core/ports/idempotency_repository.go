@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type IdempotencyRepository interface {
+	Save(ctx context.Context, record domain.IdempotencyRecord) error
+	GetByKey(ctx context.Context, key string) (domain.IdempotencyRecord, error)
+}
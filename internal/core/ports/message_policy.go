@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+// MessagePolicy decides what the principal in ctx may do with messages. Both
+// methods return an error wrapping apperrors.Forbidden to deny the action.
+type MessagePolicy interface {
+	// Authorize checks that the action is allowed at all, before any message
+	// is read.
+	Authorize(ctx context.Context, action domain.Action) error
+	// AuthorizeMessage checks that the action is allowed on message.
+	AuthorizeMessage(ctx context.Context, action domain.Action, message domain.Message) error
+}
@@ -2,13 +2,24 @@ package ports
 
 import (
 	"context"
+	"errors"
 
 	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
 )
 
+// ErrStopIteration is returned by a ForEach callback to stop early without
+// failing the iteration.
+var ErrStopIteration = errors.New("stop iteration")
+
 type MessageRepository interface {
 	Save(ctx context.Context, message domain.Message) error
+	SaveBatch(ctx context.Context, messages []domain.Message, mode domain.BatchMode) ([]domain.BatchResult, error)
 	GetByID(ctx context.Context, id string) (domain.Message, error)
-	GetAll(ctx context.Context) ([]domain.Message, error)
+	// ForEach calls fn for every message in id order, decoding one at a time,
+	// starting after the message with id after unless it is empty. It stops at
+	// the first error fn returns, or when ctx is done, and returns nil when fn
+	// stopped it with ErrStopIteration.
+	ForEach(ctx context.Context, after string, fn func(message domain.Message) error) error
 	DeleteByID(ctx context.Context, id string) error
+	DeleteBatch(ctx context.Context, ids []string, mode domain.BatchMode) ([]domain.BatchResult, error)
 }
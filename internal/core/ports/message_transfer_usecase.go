@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+// MessageTransferUseCase moves every message in or out at once, for backups
+// and migrations between environments. Export calls fn for each stored
+// message, and Import reads records from next until it returns io.EOF.
+type MessageTransferUseCase interface {
+	Export(ctx context.Context, fn func(message domain.Message) error) error
+	Import(ctx context.Context, next func() (domain.ImportRecord, error), options domain.ImportOptions) (domain.ImportReport, error)
+}
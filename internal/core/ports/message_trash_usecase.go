@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+// MessageTrashUseCase reaches the deleted messages, which every other use case
+// treats as gone. ForEachTrashed follows the MessageRepository contract.
+// Purge removes the messages of the tenant in ctx deleted before the given
+// time for good; it is meant for the background purge and authorizes nothing.
+type MessageTrashUseCase interface {
+	ForEachTrashed(ctx context.Context, fn func(message domain.Message) error) error
+	Restore(ctx context.Context, id string) (domain.Message, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int, error)
+}
@@ -8,7 +8,13 @@ import (
 
 type MessageUseCase interface {
 	Save(ctx context.Context, content string) (domain.Message, error)
+	SaveBatch(ctx context.Context, contents []string, mode domain.BatchMode) ([]domain.BatchResult, error)
 	GetByID(ctx context.Context, id string) (domain.Message, error)
 	GetAll(ctx context.Context) ([]domain.Message, error)
+	// ForEach streams the messages GetAll would return, following the
+	// MessageRepository contract, so that they need not fit in memory and can
+	// be paged through.
+	ForEach(ctx context.Context, after string, fn func(message domain.Message) error) error
 	DeleteByID(ctx context.Context, id string) error
+	DeleteBatch(ctx context.Context, ids []string, mode domain.BatchMode) ([]domain.BatchResult, error)
 }
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type OutboxRepository interface {
+	Append(ctx context.Context, entries ...domain.OutboxEntry) error
+	Pending(ctx context.Context, limit int) ([]domain.OutboxEntry, error)
+	MarkDispatched(ctx context.Context, sequence uint64) error
+	MarkFailed(ctx context.Context, sequence uint64, reason string, deadLetter bool) error
+}
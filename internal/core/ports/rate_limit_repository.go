@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+// RateLimitRepository keeps the state of the rate limit of every key, so that
+// the servers sharing a repository share their limits.
+type RateLimitRepository interface {
+	// Take decides on a request for key and records it when allowed, as a
+	// single atomic step.
+	Take(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error)
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "context"
+
+// TenantQuotas tells how much the tenant in ctx may store.
+type TenantQuotas interface {
+	// DailyMessages is the number of messages the tenant may create per day,
+	// or 0 when it may create any number.
+	DailyMessages(ctx context.Context) int
+}
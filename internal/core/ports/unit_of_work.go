@@ -0,0 +1,14 @@
+package ports
+
+import "context"
+
+type Repositories struct {
+	Messages MessageRepository
+	Outbox   OutboxRepository
+	Usage    UsageRepository
+	Audit    AuditRepository
+}
+
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context, repositories Repositories) error) error
+}
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// UsageRepository counts the messages the tenant in ctx stores per day, only
+// keeping the count of the latest day.
+type UsageRepository interface {
+	// Add adds count to the day of the given time, in UTC, and returns the
+	// new count of that day.
+	Add(ctx context.Context, day time.Time, count int) (int, error)
+}
@@ -0,0 +1,21 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+// WebhookRepository keeps the webhooks and deliveries of the tenant in ctx,
+// except for DueDeliveries, which spans every tenant for the dispatcher.
+type WebhookRepository interface {
+	Save(ctx context.Context, webhook domain.Webhook) error
+	GetByID(ctx context.Context, id string) (domain.Webhook, error)
+	GetAll(ctx context.Context) ([]domain.Webhook, error)
+	DeleteByID(ctx context.Context, id string) error
+	SaveDelivery(ctx context.Context, delivery domain.WebhookDelivery) error
+	GetDelivery(ctx context.Context, webhookID string, id string) (domain.WebhookDelivery, error)
+	GetDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error)
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type WebhookSender interface {
+	Send(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery) domain.WebhookAttempt
+}
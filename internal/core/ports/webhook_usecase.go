@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type WebhookUseCase interface {
+	Create(ctx context.Context, url string, events []string, secret string) (domain.Webhook, error)
+	GetByID(ctx context.Context, id string) (domain.Webhook, error)
+	GetAll(ctx context.Context) ([]domain.Webhook, error)
+	DeleteByID(ctx context.Context, id string) error
+	GetDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error)
+	Redeliver(ctx context.Context, webhookID string, deliveryID string) (domain.WebhookDelivery, error)
+}
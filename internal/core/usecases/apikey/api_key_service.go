@@ -0,0 +1,223 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+)
+
+const (
+	keyPrefix     = "hexapi"
+	secretSize    = 32
+	saltSize      = 16
+	maxNameLength = 100
+
+	// lastUsedResolution bounds how often a key in use is written back.
+	lastUsedResolution = time.Minute
+)
+
+var (
+	errInvalidAPIKey      = errors.New("invalid api key")
+	errRevokedAPIKey      = errors.New("api key is revoked")
+	errExpiredAPIKey      = errors.New("api key is expired")
+	errInvalidName        = errors.New("name must have between 1 and 100 characters")
+	errInvalidScope       = errors.New("scopes must be non empty and without spaces")
+	errExpirationInPast   = errors.New("expires_at must be in the future")
+	errAdminScopeRequired = errors.New("the admin scope is required")
+	errNotFoundAPIKey     = errors.New("api key not found")
+)
+
+type apiKeyService struct {
+	uuidGenerator identifier.UUIDGenerator
+	clock         clock.Clock
+	repository    ports.APIKeyRepository
+	adminKeyHash  []byte
+}
+
+// NewAPIKeyService also accepts adminKey, unless it is empty, as a key with
+// the admin scope that is never stored, to create the first keys with.
+func NewAPIKeyService(uuidGenerator identifier.UUIDGenerator, clock clock.Clock, repository ports.APIKeyRepository, adminKey string) apiKeyService {
+	var adminKeyHash []byte
+	if adminKey != "" {
+		adminKeyHash = hashSecret(nil, adminKey)
+	}
+
+	return apiKeyService{
+		uuidGenerator: uuidGenerator,
+		clock:         clock,
+		repository:    repository,
+		adminKeyHash:  adminKeyHash,
+	}
+}
+
+func (a apiKeyService) Create(ctx context.Context, name string, scopes []string, expiresAt time.Time) (domain.APIKey, string, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return domain.APIKey{}, "", err
+	}
+	now := a.clock.Now()
+	if err := validateAPIKey(name, scopes, expiresAt, now); err != nil {
+		return domain.APIKey{}, "", err
+	}
+
+	secret, err := randomHex(secretSize)
+	if err != nil {
+		return domain.APIKey{}, "", errors.Join(apperrors.InternalServerError, err)
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return domain.APIKey{}, "", errors.Join(apperrors.InternalServerError, err)
+	}
+
+	if scopes == nil {
+		scopes = []string{}
+	}
+	key := domain.NewAPIKey(a.uuidGenerator.New(), name, scopes, salt, hashSecret(salt, secret), now, expiresAt).
+		InTenant(domain.TenantFromContext(ctx))
+	if err := a.repository.Save(ctx, key); err != nil {
+		return domain.APIKey{}, "", errors.Join(apperrors.InternalServerError, err)
+	}
+	return key, strings.Join([]string{keyPrefix, key.ID, secret}, "_"), nil
+}
+
+// GetAll only returns the keys of the tenant of the request.
+func (a apiKeyService) GetAll(ctx context.Context) ([]domain.APIKey, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	keys, err := a.repository.GetAll(ctx)
+	if err != nil {
+		return nil, errors.Join(apperrors.InternalServerError, err)
+	}
+
+	tenant := domain.TenantFromContext(ctx)
+	tenantKeys := make([]domain.APIKey, 0, len(keys))
+	for _, key := range keys {
+		if key.TenantOrDefault() == tenant {
+			tenantKeys = append(tenantKeys, key)
+		}
+	}
+	return tenantKeys, nil
+}
+
+// Revoke keeps the key, so that it is still listed, but rejects it from now
+// on. Revoking a revoked key does nothing, and the keys of other tenants are
+// not found.
+func (a apiKeyService) Revoke(ctx context.Context, id string) error {
+	if err := authorizeAdmin(ctx); err != nil {
+		return err
+	}
+
+	key, err := a.repository.GetByID(ctx, id)
+	if err != nil {
+		return classify(err)
+	}
+	if key.TenantOrDefault() != domain.TenantFromContext(ctx) {
+		return errors.Join(apperrors.NotFound, errNotFoundAPIKey)
+	}
+	if key.IsRevoked() {
+		return nil
+	}
+	if err := a.repository.Save(ctx, key.Revoke(a.clock.Now())); err != nil {
+		return errors.Join(apperrors.InternalServerError, err)
+	}
+	return nil
+}
+
+// Authenticate returns the principal of a key formatted as
+// hexapi_<id>_<secret>. Failing to record its use does not reject the key.
+func (a apiKeyService) Authenticate(ctx context.Context, secret string) (domain.Principal, error) {
+	if a.adminKeyHash != nil && subtle.ConstantTimeCompare(hashSecret(nil, secret), a.adminKeyHash) == 1 {
+		return domain.NewPrincipal("apikey:admin", []string{domain.ScopeAdmin}), nil
+	}
+
+	prefix, rest, _ := strings.Cut(secret, "_")
+	id, keySecret, found := strings.Cut(rest, "_")
+	if prefix != keyPrefix || !found {
+		return domain.Principal{}, errors.Join(apperrors.Unauthorized, errInvalidAPIKey)
+	}
+
+	key, err := a.repository.GetByID(ctx, id)
+	if errors.Is(err, apperrors.NotFound) {
+		return domain.Principal{}, errors.Join(apperrors.Unauthorized, errInvalidAPIKey)
+	}
+	if err != nil {
+		return domain.Principal{}, errors.Join(apperrors.InternalServerError, err)
+	}
+	if subtle.ConstantTimeCompare(hashSecret(key.Salt, keySecret), key.Hash) != 1 {
+		return domain.Principal{}, errors.Join(apperrors.Unauthorized, errInvalidAPIKey)
+	}
+
+	now := a.clock.Now()
+	if key.IsRevoked() {
+		return domain.Principal{}, errors.Join(apperrors.Unauthorized, errRevokedAPIKey)
+	}
+	if key.IsExpired(now) {
+		return domain.Principal{}, errors.Join(apperrors.Unauthorized, errExpiredAPIKey)
+	}
+
+	if now.Sub(key.LastUsedAt) >= lastUsedResolution {
+		_ = a.repository.Touch(ctx, key.ID, now)
+	}
+	return key.Principal(), nil
+}
+
+// authorizeAdmin lets requests without a principal through, as they only
+// reach the use case when authentication is disabled.
+func authorizeAdmin(ctx context.Context) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if ok && !slices.Contains(principal.Scopes, domain.ScopeAdmin) {
+		return errors.Join(apperrors.Forbidden, errAdminScopeRequired)
+	}
+	return nil
+}
+
+func validateAPIKey(name string, scopes []string, expiresAt time.Time, now time.Time) error {
+	if name == "" || len(name) > maxNameLength {
+		return errors.Join(apperrors.InvalidInput, errInvalidName)
+	}
+	for _, scope := range scopes {
+		if scope == "" || strings.IndexFunc(scope, unicode.IsSpace) >= 0 {
+			return errors.Join(apperrors.InvalidInput, errInvalidScope)
+		}
+	}
+	if !expiresAt.IsZero() && !expiresAt.After(now) {
+		return errors.Join(apperrors.InvalidInput, errExpirationInPast)
+	}
+	return nil
+}
+
+func hashSecret(salt []byte, secret string) []byte {
+	hash := sha256.New()
+	hash.Write(salt)
+	hash.Write([]byte(secret))
+	return hash.Sum(nil)
+}
+
+func randomHex(size int) (string, error) {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+func classify(err error) error {
+	if errors.Is(err, apperrors.NotFound) {
+		return err
+	}
+	return errors.Join(apperrors.InternalServerError, err)
+}
@@ -0,0 +1,223 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var fixedNow = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestCreate_ShouldReturnSecretThatAuthenticates(t *testing.T) {
+	ctx := context.Background()
+
+	var stored domain.APIKey
+	repositoryMock := new(mocks.APIKeyRepositoryMock)
+	repositoryMock.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(domain.APIKey)
+	}).Return(nil)
+
+	service := NewAPIKeyService(newUUIDGeneratorMock("key-id"), newClockMock(), repositoryMock, "")
+	key, secret, err := service.Create(ctx, "ci", []string{"messages:read"}, fixedNow.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, stored, key)
+	assert.Equal(t, "ci", key.Name)
+	assert.Equal(t, fixedNow.Add(time.Hour), key.ExpiresAt)
+	assert.Regexp(t, `^hexapi_key-id_[0-9a-f]{64}$`, secret)
+	assert.NotContains(t, string(key.Hash), secret)
+
+	repositoryMock.On("GetByID", ctx, "key-id").Return(stored, nil)
+	repositoryMock.On("Touch", ctx, "key-id", fixedNow).Return(nil)
+	principal, err := service.Authenticate(ctx, secret)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewPrincipal("apikey:key-id", []string{"messages:read"}).InTenant(domain.DefaultTenant), principal)
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestCreate_ShouldReturnErrorWhenInputIsInvalid(t *testing.T) {
+	tests := map[string]struct {
+		name      string
+		scopes    []string
+		expiresAt time.Time
+	}{
+		"empty name":      {name: ""},
+		"long name":       {name: string(make([]byte, 101))},
+		"empty scope":     {name: "ci", scopes: []string{""}},
+		"scope with tab":  {name: "ci", scopes: []string{"messages:\tread"}},
+		"past expiration": {name: "ci", expiresAt: fixedNow},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := NewAPIKeyService(nil, newClockMock(), nil, "")
+			_, secret, err := service.Create(context.Background(), test.name, test.scopes, test.expiresAt)
+
+			assert.ErrorIs(t, err, apperrors.InvalidInput)
+			assert.Empty(t, secret)
+		})
+	}
+}
+
+func TestCreate_ShouldReturnForbiddenWithoutAdminScope(t *testing.T) {
+	ctx := domain.ContextWithPrincipal(context.Background(), domain.NewPrincipal("user-1", []string{"messages:write"}))
+
+	service := NewAPIKeyService(nil, nil, nil, "")
+	_, _, err := service.Create(ctx, "ci", nil, time.Time{})
+
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+}
+
+func TestGetAll_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
+	ctx := domain.ContextWithPrincipal(context.Background(), domain.NewPrincipal("user-1", []string{domain.ScopeAdmin}))
+	unexpectedError := errors.New("unexpected error")
+
+	repositoryMock := new(mocks.APIKeyRepositoryMock)
+	repositoryMock.On("GetAll", ctx).Return([]domain.APIKey(nil), unexpectedError)
+
+	service := NewAPIKeyService(nil, nil, repositoryMock, "")
+	keys, err := service.GetAll(ctx)
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+	assert.ErrorIs(t, err, unexpectedError)
+	assert.Empty(t, keys)
+}
+
+func TestGetAll_ShouldOnlyReturnKeysOfTenant(t *testing.T) {
+	ctx := domain.ContextWithTenant(context.Background(), "acme")
+	acme := domain.NewAPIKey("acme-key", "ci", nil, nil, nil, fixedNow, time.Time{}).InTenant("acme")
+	other := domain.NewAPIKey("other-key", "ci", nil, nil, nil, fixedNow, time.Time{}).InTenant("other")
+	unbound := domain.NewAPIKey("default-key", "ci", nil, nil, nil, fixedNow, time.Time{})
+
+	repositoryMock := new(mocks.APIKeyRepositoryMock)
+	repositoryMock.On("GetAll", ctx).Return([]domain.APIKey{acme, other, unbound}, nil)
+
+	service := NewAPIKeyService(nil, nil, repositoryMock, "")
+	keys, err := service.GetAll(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.APIKey{acme}, keys)
+}
+
+func TestRevoke_ShouldReturnNotFoundWhenKeyBelongsToOtherTenant(t *testing.T) {
+	ctx := domain.ContextWithTenant(context.Background(), "acme")
+	key := domain.NewAPIKey("key-id", "ci", nil, nil, nil, fixedNow, time.Time{}).InTenant("other")
+
+	repositoryMock := new(mocks.APIKeyRepositoryMock)
+	repositoryMock.On("GetByID", ctx, "key-id").Return(key, nil)
+
+	service := NewAPIKeyService(nil, nil, repositoryMock, "")
+	err := service.Revoke(ctx, "key-id")
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	repositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestRevoke_ShouldMarkKeyAsRevoked(t *testing.T) {
+	ctx := context.Background()
+	key := domain.NewAPIKey("key-id", "ci", nil, nil, nil, fixedNow, time.Time{})
+
+	repositoryMock := new(mocks.APIKeyRepositoryMock)
+	repositoryMock.On("GetByID", ctx, "key-id").Return(key, nil).Once()
+	repositoryMock.On("Save", ctx, key.Revoke(fixedNow)).Return(nil).Once()
+	repositoryMock.On("GetByID", ctx, "key-id").Return(key.Revoke(fixedNow), nil).Once()
+
+	service := NewAPIKeyService(nil, newClockMock(), repositoryMock, "")
+	assert.NoError(t, service.Revoke(ctx, "key-id"))
+	assert.NoError(t, service.Revoke(ctx, "key-id"))
+
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestRevoke_ShouldReturnNotFoundWhenKeyDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+
+	repositoryMock := new(mocks.APIKeyRepositoryMock)
+	repositoryMock.On("GetByID", ctx, "key-id").Return(domain.APIKey{}, apperrors.NotFound)
+
+	service := NewAPIKeyService(nil, nil, repositoryMock, "")
+	err := service.Revoke(ctx, "key-id")
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestAuthenticate_ShouldRejectInvalidKeys(t *testing.T) {
+	ctx := context.Background()
+	salt := []byte("salt")
+	active := domain.NewAPIKey("active", "ci", nil, salt, hashSecret(salt, "secret"), fixedNow, time.Time{})
+	revoked := domain.NewAPIKey("revoked", "ci", nil, salt, hashSecret(salt, "secret"), fixedNow, time.Time{}).Revoke(fixedNow)
+	expired := domain.NewAPIKey("expired", "ci", nil, salt, hashSecret(salt, "secret"), fixedNow, fixedNow)
+
+	repositoryMock := new(mocks.APIKeyRepositoryMock)
+	repositoryMock.On("GetByID", ctx, "active").Return(active, nil)
+	repositoryMock.On("GetByID", ctx, "revoked").Return(revoked, nil)
+	repositoryMock.On("GetByID", ctx, "expired").Return(expired, nil)
+	repositoryMock.On("GetByID", ctx, "unknown").Return(domain.APIKey{}, apperrors.NotFound)
+	service := NewAPIKeyService(nil, newClockMock(), repositoryMock, "")
+
+	tests := map[string]struct {
+		key      string
+		expected error
+	}{
+		"malformed":    {key: "secret", expected: errInvalidAPIKey},
+		"other prefix": {key: "other_active_secret", expected: errInvalidAPIKey},
+		"unknown":      {key: "hexapi_unknown_secret", expected: errInvalidAPIKey},
+		"wrong secret": {key: "hexapi_active_other", expected: errInvalidAPIKey},
+		"revoked":      {key: "hexapi_revoked_secret", expected: errRevokedAPIKey},
+		"expired":      {key: "hexapi_expired_secret", expected: errExpiredAPIKey},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			principal, err := service.Authenticate(ctx, test.key)
+
+			assert.ErrorIs(t, err, apperrors.Unauthorized)
+			assert.ErrorIs(t, err, test.expected)
+			assert.Empty(t, principal)
+		})
+	}
+	repositoryMock.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthenticate_ShouldNotRecordUseAgainWithinResolution(t *testing.T) {
+	ctx := context.Background()
+	salt := []byte("salt")
+	key := domain.NewAPIKey("key-id", "ci", nil, salt, hashSecret(salt, "secret"), fixedNow, time.Time{})
+	key.LastUsedAt = fixedNow.Add(-lastUsedResolution / 2)
+
+	repositoryMock := new(mocks.APIKeyRepositoryMock)
+	repositoryMock.On("GetByID", ctx, "key-id").Return(key, nil)
+
+	service := NewAPIKeyService(nil, newClockMock(), repositoryMock, "")
+	_, err := service.Authenticate(ctx, "hexapi_key-id_secret")
+
+	assert.NoError(t, err)
+	repositoryMock.AssertNotCalled(t, "Touch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthenticate_ShouldAcceptAdminKey(t *testing.T) {
+	service := NewAPIKeyService(nil, nil, nil, "bootstrap-admin-key")
+	principal, err := service.Authenticate(context.Background(), "bootstrap-admin-key")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.ScopeAdmin}, principal.Scopes)
+}
+
+func newClockMock() *mocks.ClockMock {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(fixedNow)
+	return clockMock
+}
+
+func newUUIDGeneratorMock(ids ...string) *mocks.UUIDGeneratorMock {
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	for _, id := range ids {
+		identifierMock.On("New").Return(id).Once()
+	}
+	return identifierMock
+}
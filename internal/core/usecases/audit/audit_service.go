@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+type auditService struct {
+	repository ports.AuditRepository
+	policy     ports.MessagePolicy
+}
+
+func NewAuditService(repository ports.AuditRepository, policy ports.MessagePolicy) auditService {
+	return auditService{
+		repository: repository,
+		policy:     policy,
+	}
+}
+
+func (a auditService) ForEach(ctx context.Context, messageID string, fn func(entry domain.AuditEntry) error) error {
+	if err := a.policy.Authorize(ctx, domain.ActionAudit); err != nil {
+		return err
+	}
+
+	err := a.repository.ForEach(ctx, messageID, fn)
+	if err != nil && !errors.Is(err, apperrors.Forbidden) && !errors.Is(err, apperrors.InternalServerError) {
+		return errors.Join(apperrors.InternalServerError, err)
+	}
+	return err
+}
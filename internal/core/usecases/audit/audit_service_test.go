@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForEach_ShouldVisitEntriesOfMessage(t *testing.T) {
+	ctx := context.Background()
+	entries := []domain.AuditEntry{domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionCreate, "1", nil).Chain(domain.AuditEntry{})}
+
+	policyMock := new(mocks.MessagePolicyMock)
+	policyMock.On("Authorize", ctx, domain.ActionAudit).Return(nil)
+	repositoryMock := new(mocks.AuditRepositoryMock)
+	repositoryMock.On("ForEach", ctx, "1").Return(entries, nil)
+
+	var visited []domain.AuditEntry
+	err := NewAuditService(repositoryMock, policyMock).ForEach(ctx, "1", func(entry domain.AuditEntry) error {
+		visited = append(visited, entry)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, entries, visited)
+}
+
+func TestForEach_ShouldReturnErrorWhenPolicyDenies(t *testing.T) {
+	ctx := context.Background()
+
+	policyMock := new(mocks.MessagePolicyMock)
+	policyMock.On("Authorize", ctx, domain.ActionAudit).Return(apperrors.Forbidden)
+	repositoryMock := new(mocks.AuditRepositoryMock)
+
+	err := NewAuditService(repositoryMock, policyMock).ForEach(ctx, "", func(entry domain.AuditEntry) error { return nil })
+
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	repositoryMock.AssertNotCalled(t, "ForEach")
+}
+
+func TestForEach_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
+	ctx := context.Background()
+	unexpectedError := errors.New("unexpected error")
+
+	policyMock := new(mocks.MessagePolicyMock)
+	policyMock.On("Authorize", ctx, domain.ActionAudit).Return(nil)
+	repositoryMock := new(mocks.AuditRepositoryMock)
+	repositoryMock.On("ForEach", ctx, "").Return([]domain.AuditEntry{}, unexpectedError)
+
+	err := NewAuditService(repositoryMock, policyMock).ForEach(ctx, "", func(entry domain.AuditEntry) error { return nil })
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+	assert.ErrorIs(t, err, unexpectedError)
+}
@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"context"
+	"log"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+)
+
+// auditedMessageService records the failed attempts to create or delete
+// messages in the audit log and leaves reads to the decorated use case. The
+// changes themselves are recorded by the use case, in the unit of work that
+// makes them. A failed attempt changes nothing, so a failure to append its
+// entry is logged rather than returned.
+type auditedMessageService struct {
+	ports.MessageUseCase
+	recorder
+}
+
+func NewAuditedMessageService(service ports.MessageUseCase, repository ports.AuditRepository, clock clock.Clock) auditedMessageService {
+	return auditedMessageService{
+		MessageUseCase: service,
+		recorder:       recorder{repository: repository, clock: clock},
+	}
+}
+
+func (a auditedMessageService) Save(ctx context.Context, content string) (domain.Message, error) {
+	message, err := a.MessageUseCase.Save(ctx, content)
+	if err != nil {
+		a.append(ctx, a.entry(ctx, domain.ActionCreate, "", err))
+	}
+	return message, err
+}
+
+// SaveBatch records an entry per failed item, or a single one when the batch
+// was rejected as a whole.
+func (a auditedMessageService) SaveBatch(ctx context.Context, contents []string, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	results, err := a.MessageUseCase.SaveBatch(ctx, contents, mode)
+	if results == nil {
+		if err != nil {
+			a.append(ctx, a.entry(ctx, domain.ActionCreate, "", err))
+		}
+		return results, err
+	}
+
+	for _, result := range results {
+		if itemErr := itemError(result, err); itemErr != nil {
+			a.append(ctx, a.entry(ctx, domain.ActionCreate, result.ID, itemErr))
+		}
+	}
+	return results, err
+}
+
+func (a auditedMessageService) DeleteByID(ctx context.Context, id string) error {
+	err := a.MessageUseCase.DeleteByID(ctx, id)
+	if err != nil {
+		a.append(ctx, a.entry(ctx, domain.ActionDelete, id, err).WithSnapshots(a.snapshot(ctx, id), nil))
+	}
+	return err
+}
+
+func (a auditedMessageService) DeleteBatch(ctx context.Context, ids []string, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	results, err := a.MessageUseCase.DeleteBatch(ctx, ids, mode)
+	if results == nil {
+		if err != nil {
+			for _, id := range ids {
+				a.append(ctx, a.entry(ctx, domain.ActionDelete, id, err).WithSnapshots(a.snapshot(ctx, id), nil))
+			}
+		}
+		return results, err
+	}
+
+	for _, result := range results {
+		if itemErr := itemError(result, err); itemErr != nil {
+			a.append(ctx, a.entry(ctx, domain.ActionDelete, result.ID, itemErr).WithSnapshots(a.snapshot(ctx, result.ID), nil))
+		}
+	}
+	return results, err
+}
+
+// snapshot is the message as the principal may read it, if it can. The
+// message of a failed deletion is left as it was.
+func (a auditedMessageService) snapshot(ctx context.Context, id string) *domain.AuditSnapshot {
+	message, err := a.MessageUseCase.GetByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return domain.NewAuditSnapshot(message)
+}
+
+// recorder appends the entries of the audited use cases.
+type recorder struct {
+	repository ports.AuditRepository
+	clock      clock.Clock
+}
+
+func (a recorder) entry(ctx context.Context, action domain.Action, messageID string, err error) domain.AuditEntry {
+	return domain.NewAuditEntry(a.clock.Now(), domain.TenantFromContext(ctx), action, messageID, err).
+		By(subject(ctx), domain.RequestIDFromContext(ctx))
+}
+
+func (a recorder) append(ctx context.Context, entry domain.AuditEntry) {
+	if _, err := a.repository.Append(ctx, entry); err != nil {
+		log.Printf("[AUDIT] failed to record %s of message %q by %q: %v", entry.Action, entry.MessageID, entry.Principal, err)
+	}
+}
+
+// itemError is the error of an item, or that of the whole batch, which only
+// fails along with its items when it was aborted.
+func itemError(result domain.BatchResult, batchErr error) error {
+	if result.Err != nil {
+		return result.Err
+	}
+	return batchErr
+}
+
+func subject(ctx context.Context) string {
+	principal, _ := domain.PrincipalFromContext(ctx)
+	return principal.Subject
+}
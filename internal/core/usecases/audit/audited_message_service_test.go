@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var fixedNow = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestAuditedSave_ShouldLeaveCreatedMessageToService(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage("1", "content")
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", ctx, message.Content).Return(message, nil)
+	repositoryMock, entries := newAuditRepositoryMock()
+
+	actualMessage, err := NewAuditedMessageService(serviceMock, repositoryMock, newClockMock()).Save(ctx, message.Content)
+
+	assert.NoError(t, err)
+	assert.Equal(t, message, actualMessage)
+	assert.Empty(t, *entries)
+}
+
+func TestAuditedSave_ShouldRecordFailureWhenServiceFails(t *testing.T) {
+	ctx := context.Background()
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", ctx, "content").Return(domain.Message{}, apperrors.Forbidden)
+	repositoryMock, entries := newAuditRepositoryMock()
+
+	_, err := NewAuditedMessageService(serviceMock, repositoryMock, newClockMock()).Save(ctx, "content")
+
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	assert.Len(t, *entries, 1)
+	assert.Equal(t, domain.AuditOutcomeFailure, (*entries)[0].Outcome)
+	assert.Equal(t, apperrors.Forbidden.Error(), (*entries)[0].Error)
+	assert.Nil(t, (*entries)[0].After)
+}
+
+func TestAuditedSave_ShouldReturnServiceErrorWhenRecordingFails(t *testing.T) {
+	ctx := context.Background()
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", ctx, "content").Return(domain.Message{}, apperrors.Forbidden)
+	repositoryMock := new(mocks.AuditRepositoryMock)
+	repositoryMock.On("Append", ctx, mock.Anything).Return(domain.AuditEntry{}, errors.New("unexpected error"))
+
+	_, err := NewAuditedMessageService(serviceMock, repositoryMock, newClockMock()).Save(ctx, "content")
+
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestAuditedSaveBatch_ShouldRecordOutcomeOfEachItem(t *testing.T) {
+	ctx := context.Background()
+	results := []domain.BatchResult{
+		domain.NewBatchResult("1", nil),
+		domain.NewBatchResult("2", errors.New("invalid content")),
+	}
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("SaveBatch", ctx, []string{"first", "second"}, domain.BatchModeAtomic).
+		Return(results, apperrors.UnprocessableEntity)
+	repositoryMock, entries := newAuditRepositoryMock()
+
+	_, err := NewAuditedMessageService(serviceMock, repositoryMock, newClockMock()).SaveBatch(ctx, []string{"first", "second"}, domain.BatchModeAtomic)
+
+	assert.ErrorIs(t, err, apperrors.UnprocessableEntity)
+	assert.Equal(t, []domain.AuditEntry{
+		domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionCreate, "1", apperrors.UnprocessableEntity),
+		domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionCreate, "2", errors.New("invalid content")),
+	}, *entries)
+}
+
+func TestAuditedDeleteByID_ShouldRecordMessageThatWasNotDeleted(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage("1", "content").OwnedBy("user-2")
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", ctx, message.ID).Return(message, nil)
+	serviceMock.On("DeleteByID", ctx, message.ID).Return(apperrors.Forbidden)
+	repositoryMock, entries := newAuditRepositoryMock()
+
+	err := NewAuditedMessageService(serviceMock, repositoryMock, newClockMock()).DeleteByID(ctx, message.ID)
+
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	assert.Equal(t, []domain.AuditEntry{
+		domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionDelete, message.ID, apperrors.Forbidden).
+			WithSnapshots(domain.NewAuditSnapshot(message), nil),
+	}, *entries)
+}
+
+func TestAuditedDeleteBatch_ShouldRecordFailedItems(t *testing.T) {
+	ctx := context.Background()
+	results := []domain.BatchResult{
+		domain.NewBatchResult("1", nil),
+		domain.NewBatchResult("2", apperrors.NotFound),
+	}
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", ctx, "2").Return(domain.Message{}, apperrors.NotFound)
+	serviceMock.On("DeleteBatch", ctx, []string{"1", "2"}, domain.BatchModeBestEffort).Return(results, nil)
+	repositoryMock, entries := newAuditRepositoryMock()
+
+	_, err := NewAuditedMessageService(serviceMock, repositoryMock, newClockMock()).DeleteBatch(ctx, []string{"1", "2"}, domain.BatchModeBestEffort)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AuditEntry{
+		domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionDelete, "2", apperrors.NotFound),
+	}, *entries)
+}
+
+// newAuditRepositoryMock appends the recorded entries to the returned slice.
+func newAuditRepositoryMock() (*mocks.AuditRepositoryMock, *[]domain.AuditEntry) {
+	entries := &[]domain.AuditEntry{}
+	repositoryMock := new(mocks.AuditRepositoryMock)
+	repositoryMock.On("Append", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*entries = append(*entries, args.Get(1).(domain.AuditEntry))
+		}).
+		Return(domain.AuditEntry{}, nil)
+	return repositoryMock, entries
+}
+
+func newClockMock() *mocks.ClockMock {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(fixedNow)
+	return clockMock
+}
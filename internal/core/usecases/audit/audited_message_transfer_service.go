@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+)
+
+// auditedMessageTransferService records the records that could not be
+// imported, and the import itself when it stopped, like
+// auditedMessageService. Exports only read messages and are not recorded.
+type auditedMessageTransferService struct {
+	ports.MessageTransferUseCase
+	recorder
+}
+
+func NewAuditedMessageTransferService(service ports.MessageTransferUseCase, repository ports.AuditRepository, clock clock.Clock) auditedMessageTransferService {
+	return auditedMessageTransferService{
+		MessageTransferUseCase: service,
+		recorder:               recorder{repository: repository, clock: clock},
+	}
+}
+
+func (a auditedMessageTransferService) Import(ctx context.Context, next func() (domain.ImportRecord, error), options domain.ImportOptions) (domain.ImportReport, error) {
+	report, err := a.MessageTransferUseCase.Import(ctx, next, options)
+	for _, failure := range report.Failures {
+		a.append(ctx, a.entry(ctx, domain.ActionImport, failure.ID, failure.Err))
+	}
+	if err != nil {
+		a.append(ctx, a.entry(ctx, domain.ActionImport, "", err))
+	}
+	return report, err
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditedImport_ShouldRecordFailedRecordsAndStoppedImport(t *testing.T) {
+	ctx := context.Background()
+	options := domain.ImportOptions{IDs: domain.ImportIDsPreserve, Conflict: domain.ImportConflictFail}
+	invalid := errors.New("invalid record")
+	report := domain.ImportReport{Created: 1}
+	report.Fail(domain.ImportRecord{Line: 2, Message: domain.NewMessage("2", "")}, invalid)
+
+	serviceMock := new(mocks.MessageTransferUseCaseMock)
+	serviceMock.On("Import", ctx, []domain.ImportRecord(nil), options).Return(report, apperrors.TooManyRequests)
+	repositoryMock, entries := newAuditRepositoryMock()
+	next := func() (domain.ImportRecord, error) { return domain.ImportRecord{}, io.EOF }
+
+	actualReport, err := NewAuditedMessageTransferService(serviceMock, repositoryMock, newClockMock()).Import(ctx, next, options)
+
+	assert.ErrorIs(t, err, apperrors.TooManyRequests)
+	assert.Equal(t, report, actualReport)
+	assert.Equal(t, []domain.AuditEntry{
+		domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionImport, "2", invalid),
+		domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionImport, "", apperrors.TooManyRequests),
+	}, *entries)
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+)
+
+// auditedMessageTrashService records the failed attempts to restore a
+// message, like auditedMessageService. Purges record their entries along with
+// the deletions, and a failed purge leaves the messages for the next one.
+type auditedMessageTrashService struct {
+	ports.MessageTrashUseCase
+	recorder
+}
+
+func NewAuditedMessageTrashService(service ports.MessageTrashUseCase, repository ports.AuditRepository, clock clock.Clock) auditedMessageTrashService {
+	return auditedMessageTrashService{
+		MessageTrashUseCase: service,
+		recorder:            recorder{repository: repository, clock: clock},
+	}
+}
+
+func (a auditedMessageTrashService) Restore(ctx context.Context, id string) (domain.Message, error) {
+	message, err := a.MessageTrashUseCase.Restore(ctx, id)
+	if err != nil {
+		a.append(ctx, a.entry(ctx, domain.ActionRestore, id, err))
+	}
+	return message, err
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditedRestore_ShouldOnlyRecordFailures(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage("1", "content")
+
+	serviceMock := new(mocks.MessageTrashUseCaseMock)
+	serviceMock.On("Restore", ctx, message.ID).Return(message, nil)
+	serviceMock.On("Restore", ctx, "2").Return(domain.Message{}, apperrors.NotFound)
+	repositoryMock, entries := newAuditRepositoryMock()
+	service := NewAuditedMessageTrashService(serviceMock, repositoryMock, newClockMock())
+
+	actualMessage, err := service.Restore(ctx, message.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, message, actualMessage)
+	_, err = service.Restore(ctx, "2")
+	assert.ErrorIs(t, err, apperrors.NotFound)
+
+	assert.Equal(t, []domain.AuditEntry{
+		domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionRestore, "2", apperrors.NotFound),
+	}, *entries)
+}
@@ -3,60 +3,433 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
 	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
 	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
 	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
 )
 
+const maxBatchSize = 1000
+
+var (
+	errInvalidBatchMode = errors.New("batch mode must be atomic or best_effort")
+	errInvalidBatchSize = fmt.Errorf("batch must have between 1 and %d items", maxBatchSize)
+	errBatchAborted     = errors.New("batch was aborted because at least one item failed")
+	errQuotaExceeded    = errors.New("the daily message quota of the tenant is exceeded")
+	errMessageNotFound  = errors.New("message id not found")
+)
+
 type messageService struct {
 	uuidGenerator identifier.UUIDGenerator
+	clock         clock.Clock
 	repository    ports.MessageRepository
+	unitOfWork    ports.UnitOfWork
+	policy        ports.MessagePolicy
+	quotas        ports.TenantQuotas
 }
 
-func NewMessageService(uuidGenerator identifier.UUIDGenerator, repository ports.MessageRepository) messageService {
+func NewMessageService(
+	uuidGenerator identifier.UUIDGenerator,
+	clock clock.Clock,
+	repository ports.MessageRepository,
+	unitOfWork ports.UnitOfWork,
+	policy ports.MessagePolicy,
+) messageService {
 	return messageService{
 		uuidGenerator: uuidGenerator,
+		clock:         clock,
 		repository:    repository,
+		unitOfWork:    unitOfWork,
+		policy:        policy,
 	}
 }
 
+// WithQuotas returns a service that enforces the daily message quotas of the
+// tenants. Without quotas, tenants may create any number of messages.
+func (m messageService) WithQuotas(quotas ports.TenantQuotas) messageService {
+	m.quotas = quotas
+	return m
+}
+
 func (m messageService) Save(ctx context.Context, content string) (domain.Message, error) {
-	message := domain.NewMessage(m.uuidGenerator.New(), content)
-	err := m.repository.Save(ctx, message)
+	if err := m.policy.Authorize(ctx, domain.ActionCreate); err != nil {
+		return domain.Message{}, err
+	}
+
+	message := domain.NewMessage(m.uuidGenerator.New(), content).OwnedBy(owner(ctx))
+	err := m.transaction(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		err := repositories.Messages.Save(ctx, message)
+		if err != nil {
+			return errors.Join(apperrors.InvalidInput, err)
+		}
+		if err := m.consumeQuota(ctx, repositories, 1); err != nil {
+			return err
+		}
+
+		now := m.clock.Now()
+		if err := audit(ctx, repositories, auditEntry(ctx, now, domain.ActionCreate, nil, &message)); err != nil {
+			return err
+		}
+		return record(ctx, repositories, domain.NewMessageCreated(message, now))
+	})
 	if err != nil {
-		return domain.Message{}, errors.Join(apperrors.InvalidInput, err)
+		return domain.Message{}, err
 	}
 	return message, nil
 }
 
+func (m messageService) SaveBatch(ctx context.Context, contents []string, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	if err := validateBatch(len(contents), mode); err != nil {
+		return nil, err
+	}
+	if err := m.policy.Authorize(ctx, domain.ActionCreate); err != nil {
+		return nil, err
+	}
+
+	messages := make([]domain.Message, len(contents))
+	for i, content := range contents {
+		messages[i] = domain.NewMessage(m.uuidGenerator.New(), content).OwnedBy(owner(ctx))
+	}
+
+	var results []domain.BatchResult
+	err := m.transaction(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		var err error
+		results, err = repositories.Messages.SaveBatch(ctx, messages, mode)
+		if err != nil {
+			return errors.Join(apperrors.InternalServerError, err)
+		}
+		if err := batchError(results, mode); err != nil {
+			return err
+		}
+
+		now := m.clock.Now()
+		var events []domain.Event
+		var entries []domain.AuditEntry
+		for i, result := range results {
+			if result.Err == nil {
+				events = append(events, domain.NewMessageCreated(messages[i], now))
+				entries = append(entries, auditEntry(ctx, now, domain.ActionCreate, nil, &messages[i]))
+			}
+		}
+		if err := m.consumeQuota(ctx, repositories, len(events)); err != nil {
+			return err
+		}
+		if err := audit(ctx, repositories, entries...); err != nil {
+			return err
+		}
+		return record(ctx, repositories, events...)
+	})
+	return batchResults(results, err)
+}
+
 func (m messageService) GetByID(ctx context.Context, id string) (domain.Message, error) {
-	message, err := m.repository.GetByID(ctx, id)
+	if err := m.policy.Authorize(ctx, domain.ActionRead); err != nil {
+		return domain.Message{}, err
+	}
+
+	message, err := getMessage(ctx, m.repository, id)
 	if err != nil {
-		if errors.Is(err, apperrors.NotFound) {
-			return domain.Message{}, err
-		}
-		return domain.Message{}, errors.Join(apperrors.InternalServerError, err)
+		return domain.Message{}, err
+	}
+	if err := m.policy.AuthorizeMessage(ctx, domain.ActionRead, message); err != nil {
+		return domain.Message{}, err
 	}
 	return message, nil
 }
 
 func (m messageService) GetAll(ctx context.Context) ([]domain.Message, error) {
-	messages, err := m.repository.GetAll(ctx)
+	var messages []domain.Message
+	err := m.ForEach(ctx, "", func(message domain.Message) error {
+		messages = append(messages, message)
+		return nil
+	})
 	if err != nil {
-		return nil, errors.Join(apperrors.InternalServerError, err)
+		return nil, err
 	}
 	return messages, nil
 }
 
+func (m messageService) ForEach(ctx context.Context, after string, fn func(message domain.Message) error) error {
+	return m.forEach(ctx, after, false, fn)
+}
+
+// forEach visits either the deleted messages or the others, and skips the
+// messages the principal may not read rather than failing.
+func (m messageService) forEach(ctx context.Context, after string, deleted bool, fn func(message domain.Message) error) error {
+	if err := m.policy.Authorize(ctx, domain.ActionRead); err != nil {
+		return err
+	}
+
+	err := m.repository.ForEach(ctx, after, func(message domain.Message) error {
+		if message.IsDeleted() != deleted || m.policy.AuthorizeMessage(ctx, domain.ActionRead, message) != nil {
+			return nil
+		}
+		return fn(message)
+	})
+	if err != nil && !isClassified(err) {
+		return errors.Join(apperrors.InternalServerError, err)
+	}
+	return err
+}
+
+// DeleteByID moves the message to the trash, from where it can be restored
+// until it is purged.
 func (m messageService) DeleteByID(ctx context.Context, id string) error {
-	err := m.repository.DeleteByID(ctx, id)
-	if err != nil {
-		if errors.Is(err, apperrors.NotFound) {
+	if err := m.policy.Authorize(ctx, domain.ActionDelete); err != nil {
+		return err
+	}
+
+	return m.transaction(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		message, err := getMessage(ctx, repositories.Messages, id)
+		if err != nil {
+			return err
+		}
+		if err := m.policy.AuthorizeMessage(ctx, domain.ActionDelete, message); err != nil {
+			return err
+		}
+
+		now := m.clock.Now()
+		if err := repositories.Messages.Save(ctx, message.DeletedOn(now)); err != nil {
+			return errors.Join(apperrors.InternalServerError, err)
+		}
+		if err := audit(ctx, repositories, auditEntry(ctx, now, domain.ActionDelete, &message, nil)); err != nil {
+			return err
+		}
+		return record(ctx, repositories, domain.NewMessageDeleted(id, now).OwnedBy(message.Owner))
+	})
+}
+
+func (m messageService) DeleteBatch(ctx context.Context, ids []string, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	if err := validateBatch(len(ids), mode); err != nil {
+		return nil, err
+	}
+	if err := m.policy.Authorize(ctx, domain.ActionDelete); err != nil {
+		return nil, err
+	}
+
+	var results []domain.BatchResult
+	err := m.transaction(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		now := m.clock.Now()
+		var trashed map[string]domain.Message
+		var err error
+		results, trashed, err = m.trashAuthorized(ctx, repositories.Messages, ids, mode, now)
+		if err != nil {
+			return err
+		}
+		if err := batchError(results, mode); err != nil {
+			return err
+		}
+
+		var events []domain.Event
+		var entries []domain.AuditEntry
+		for _, result := range results {
+			if result.Err == nil {
+				message := trashed[result.ID]
+				events = append(events, domain.NewMessageDeleted(result.ID, now).OwnedBy(message.Owner))
+				entries = append(entries, auditEntry(ctx, now, domain.ActionDelete, &message, nil))
+			}
+		}
+		if err := audit(ctx, repositories, entries...); err != nil {
 			return err
 		}
+		return record(ctx, repositories, events...)
+	})
+	return batchResults(results, err)
+}
+
+// trashAuthorized reports the messages the principal may not delete as failed
+// items and moves the others to the trash, returning them by id as they were
+// before. Unknown, already deleted and repeated ids are reported as not found.
+func (m messageService) trashAuthorized(ctx context.Context, repository ports.MessageRepository, ids []string, mode domain.BatchMode, now time.Time) ([]domain.BatchResult, map[string]domain.Message, error) {
+	results := make([]domain.BatchResult, len(ids))
+	trashed := make([]domain.Message, 0, len(ids))
+	previous := make(map[string]domain.Message, len(ids))
+	for i, id := range ids {
+		message, err := getMessage(ctx, repository, id)
+		if errors.Is(err, apperrors.InternalServerError) {
+			return nil, nil, err
+		}
+		if _, seen := previous[id]; err == nil && seen {
+			err = errors.Join(apperrors.NotFound, errMessageNotFound)
+		}
+		if err == nil {
+			err = m.policy.AuthorizeMessage(ctx, domain.ActionDelete, message)
+		}
+		if err == nil {
+			previous[id] = message
+			trashed = append(trashed, message.DeletedOn(now))
+		}
+		results[i] = domain.NewBatchResult(id, err)
+	}
+
+	if mode == domain.BatchModeAtomic && domain.HasBatchFailures(results) {
+		return abortBatch(results), previous, nil
+	}
+	if len(trashed) == 0 {
+		return results, previous, nil
+	}
+
+	saved, err := repository.SaveBatch(ctx, trashed, mode)
+	if err != nil {
+		return nil, nil, errors.Join(apperrors.InternalServerError, err)
+	}
+	for i := range results {
+		if results[i].Err == nil {
+			results[i] = saved[0]
+			saved = saved[1:]
+		}
+	}
+	return results, previous, nil
+}
+
+// transaction runs fn in a unit of work. Errors already classified by fn are
+// kept as they are, anything else comes from the unit of work itself.
+func (m messageService) transaction(ctx context.Context, fn func(ctx context.Context, repositories ports.Repositories) error) error {
+	err := m.unitOfWork.Do(ctx, fn)
+	if err == nil || isClassified(err) {
+		return err
+	}
+	return errors.Join(apperrors.InternalServerError, err)
+}
+
+// consumeQuota counts created messages against the daily quota of the tenant,
+// failing once it is exceeded. It runs in the unit of work that creates them,
+// so that they are only counted when stored.
+func (m messageService) consumeQuota(ctx context.Context, repositories ports.Repositories, created int) error {
+	if m.quotas == nil || created == 0 {
+		return nil
+	}
+	quota := m.quotas.DailyMessages(ctx)
+	if quota <= 0 {
+		return nil
+	}
+
+	count, err := repositories.Usage.Add(ctx, m.clock.Now(), created)
+	if err != nil {
+		return errors.Join(apperrors.InternalServerError, err)
+	}
+	if count > quota {
+		return errors.Join(apperrors.TooManyRequests, errQuotaExceeded)
+	}
+	return nil
+}
+
+func record(ctx context.Context, repositories ports.Repositories, events ...domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	entries := make([]domain.OutboxEntry, len(events))
+	for i, event := range events {
+		entry, err := domain.NewOutboxEntry(domain.TenantFromContext(ctx), event)
+		if err != nil {
+			return errors.Join(apperrors.InternalServerError, err)
+		}
+		entries[i] = entry
+	}
+
+	if err := repositories.Outbox.Append(ctx, entries...); err != nil {
 		return errors.Join(apperrors.InternalServerError, err)
 	}
 	return nil
 }
+
+// audit appends the entries of the changes made in the unit of work, so that
+// a change is only committed along with its entry.
+func audit(ctx context.Context, repositories ports.Repositories, entries ...domain.AuditEntry) error {
+	for _, entry := range entries {
+		if _, err := repositories.Audit.Append(ctx, entry); err != nil {
+			return errors.Join(apperrors.InternalServerError, err)
+		}
+	}
+	return nil
+}
+
+// auditEntry records the principal in ctx changing a message from before to
+// after, either of which is nil when the message is created or deleted.
+func auditEntry(ctx context.Context, now time.Time, action domain.Action, before *domain.Message, after *domain.Message) domain.AuditEntry {
+	var id string
+	var beforeSnapshot, afterSnapshot *domain.AuditSnapshot
+	if before != nil {
+		id = before.ID
+		beforeSnapshot = domain.NewAuditSnapshot(*before)
+	}
+	if after != nil {
+		id = after.ID
+		afterSnapshot = domain.NewAuditSnapshot(*after)
+	}
+	return domain.NewAuditEntry(now, domain.TenantFromContext(ctx), action, id, nil).
+		By(owner(ctx), domain.RequestIDFromContext(ctx)).
+		WithSnapshots(beforeSnapshot, afterSnapshot)
+}
+
+func isClassified(err error) bool {
+	return errors.Is(err, apperrors.InternalServerError) ||
+		errors.Is(err, apperrors.Conflict) ||
+		errors.Is(err, apperrors.Forbidden) ||
+		errors.Is(err, apperrors.InvalidInput) ||
+		errors.Is(err, apperrors.NotFound) ||
+		errors.Is(err, apperrors.TooManyRequests) ||
+		errors.Is(err, apperrors.UnprocessableEntity)
+}
+
+// getMessage treats deleted messages as not found.
+func getMessage(ctx context.Context, repository ports.MessageRepository, id string) (domain.Message, error) {
+	message, err := repository.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, apperrors.NotFound) {
+			return domain.Message{}, err
+		}
+		return domain.Message{}, errors.Join(apperrors.InternalServerError, err)
+	}
+	if message.IsDeleted() {
+		return domain.Message{}, errors.Join(apperrors.NotFound, errMessageNotFound)
+	}
+	return message, nil
+}
+
+// owner is the subject of the principal in ctx, if any.
+func owner(ctx context.Context) string {
+	principal, _ := domain.PrincipalFromContext(ctx)
+	return principal.Subject
+}
+
+func validateBatch(size int, mode domain.BatchMode) error {
+	if !mode.IsValid() {
+		return errors.Join(apperrors.InvalidInput, errInvalidBatchMode)
+	}
+	if size == 0 || size > maxBatchSize {
+		return errors.Join(apperrors.InvalidInput, errInvalidBatchSize)
+	}
+	return nil
+}
+
+func batchError(results []domain.BatchResult, mode domain.BatchMode) error {
+	if mode == domain.BatchModeAtomic && domain.HasBatchFailures(results) {
+		return errors.Join(apperrors.UnprocessableEntity, errBatchAborted)
+	}
+	return nil
+}
+
+func abortBatch(results []domain.BatchResult) []domain.BatchResult {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = apperrors.Aborted
+		}
+	}
+	return results
+}
+
+func batchResults(results []domain.BatchResult, err error) ([]domain.BatchResult, error) {
+	if err != nil {
+		if errors.Is(err, apperrors.UnprocessableEntity) {
+			return results, err
+		}
+		return nil, err
+	}
+	return results, nil
+}
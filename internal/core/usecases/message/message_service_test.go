@@ -4,14 +4,19 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
 	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
 	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+var fixedNow = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
 func TestSave_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
 	ctx := context.Background()
 	messageID := uuid.NewString()
@@ -23,29 +28,95 @@ func TestSave_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
 	identifierMock.On("New").Return(messageID)
 	repositoryMock.On("Save", ctx, domain.NewMessage(messageID, content)).Return(unexpectedError)
 
-	service := NewMessageService(identifierMock, repositoryMock)
+	service := NewMessageService(identifierMock, nil, nil, newUnitOfWorkMock(repositoryMock, nil), newPolicyMock())
 	actualMessage, err := service.Save(ctx, content)
 
 	assert.ErrorIs(t, err, apperrors.InvalidInput)
 	assert.Empty(t, actualMessage)
 }
 
-func TestSave_ShouldSaveMessageWithSuccess(t *testing.T) {
+func TestSave_ShouldReturnErrorWhenOutboxFails(t *testing.T) {
 	ctx := context.Background()
 	messageID := uuid.NewString()
 	content := "message content"
+	unexpectedError := errors.New("unexpected error")
 
 	identifierMock := new(mocks.UUIDGeneratorMock)
 	repositoryMock := new(mocks.MessageRepositoryMock)
+	outboxMock := new(mocks.OutboxRepositoryMock)
 	identifierMock.On("New").Return(messageID)
 	repositoryMock.On("Save", ctx, domain.NewMessage(messageID, content)).Return(nil)
+	outboxMock.On("Append", ctx, mock.Anything).Return(unexpectedError)
+
+	service := NewMessageService(identifierMock, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, outboxMock), newPolicyMock())
+	actualMessage, err := service.Save(ctx, content)
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+	assert.ErrorIs(t, err, unexpectedError)
+	assert.Empty(t, actualMessage)
+}
+
+func TestSave_ShouldAuditCreatedMessageBeforeRecordingEvent(t *testing.T) {
+	ctx := domain.ContextWithRequestID(domain.ContextWithPrincipal(context.Background(), domain.NewPrincipal("user-1", nil)), "request-1")
+	message := domain.NewMessage(uuid.NewString(), "message content").OwnedBy("user-1")
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	auditMock := new(mocks.AuditRepositoryMock)
+	identifierMock.On("New").Return(message.ID)
+	repositoryMock.On("Save", ctx, message).Return(nil)
+	auditMock.On("Append", ctx, domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionCreate, message.ID, nil).
+		By("user-1", "request-1").
+		WithSnapshots(nil, domain.NewAuditSnapshot(message))).
+		Return(domain.AuditEntry{}, errors.New("unexpected error"))
+	unitOfWorkMock := &mocks.UnitOfWorkMock{Repositories: ports.Repositories{Messages: repositoryMock, Audit: auditMock}}
+	unitOfWorkMock.On("Do", ctx).Return(nil)
+
+	service := NewMessageService(identifierMock, newClockMock(), nil, unitOfWorkMock, newPolicyMock())
+	actualMessage, err := service.Save(ctx, message.Content)
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+	assert.Empty(t, actualMessage)
+	auditMock.AssertExpectations(t)
+}
+
+func TestSave_ShouldReturnErrorWhenUnitOfWorkFails(t *testing.T) {
+	ctx := context.Background()
+	unexpectedError := errors.New("unexpected error")
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	identifierMock.On("New").Return(uuid.NewString())
+	unitOfWorkMock := new(mocks.UnitOfWorkMock)
+	unitOfWorkMock.On("Do", ctx).Return(unexpectedError)
+
+	service := NewMessageService(identifierMock, nil, nil, unitOfWorkMock, newPolicyMock())
+	actualMessage, err := service.Save(ctx, "message content")
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+	assert.ErrorIs(t, err, unexpectedError)
+	assert.Empty(t, actualMessage)
+}
+
+func TestSave_ShouldSaveMessageWithSuccess(t *testing.T) {
+	ctx := context.Background()
+	messageID := uuid.NewString()
+	content := "message content"
+	expectedMessage := domain.NewMessage(messageID, content)
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	outboxMock := new(mocks.OutboxRepositoryMock)
+	identifierMock.On("New").Return(messageID)
+	repositoryMock.On("Save", ctx, expectedMessage).Return(nil)
+	outboxMock.On("Append", ctx, outboxEntries(t, domain.NewMessageCreated(expectedMessage, fixedNow))).Return(nil)
 
-	service := NewMessageService(identifierMock, repositoryMock)
+	service := NewMessageService(identifierMock, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, outboxMock), newPolicyMock())
 	actualMessage, err := service.Save(ctx, content)
 
 	assert.NoError(t, err)
 	assert.Equal(t, messageID, actualMessage.ID)
 	assert.Equal(t, content, actualMessage.Content)
+	outboxMock.AssertExpectations(t)
 }
 
 func TestGetByID_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
@@ -56,7 +127,7 @@ func TestGetByID_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
 	repositoryMock := new(mocks.MessageRepositoryMock)
 	repositoryMock.On("GetByID", ctx, messageID).Return(domain.Message{}, unexpectedError)
 
-	service := NewMessageService(nil, repositoryMock)
+	service := NewMessageService(nil, nil, repositoryMock, nil, newPolicyMock())
 	actualMessage, err := service.GetByID(ctx, messageID)
 
 	assert.ErrorIs(t, err, apperrors.InternalServerError)
@@ -70,13 +141,27 @@ func TestGetByID_ShouldReturnErrorWhenMessageNotFound(t *testing.T) {
 	repositoryMock := new(mocks.MessageRepositoryMock)
 	repositoryMock.On("GetByID", ctx, messageID).Return(domain.Message{}, apperrors.NotFound)
 
-	service := NewMessageService(nil, repositoryMock)
+	service := NewMessageService(nil, nil, repositoryMock, nil, newPolicyMock())
 	actualMessage, err := service.GetByID(ctx, messageID)
 
 	assert.ErrorIs(t, err, apperrors.NotFound)
 	assert.Empty(t, actualMessage)
 }
 
+func TestGetByID_ShouldReturnErrorWhenMessageIsDeleted(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage(uuid.NewString(), "message content").DeletedOn(fixedNow)
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	repositoryMock.On("GetByID", ctx, message.ID).Return(message, nil)
+
+	service := NewMessageService(nil, nil, repositoryMock, nil, newPolicyMock())
+	actualMessage, err := service.GetByID(ctx, message.ID)
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.Empty(t, actualMessage)
+}
+
 func TestGetByID_ShouldReturnMessageWithSuccess(t *testing.T) {
 	ctx := context.Background()
 	messageID := uuid.NewString()
@@ -85,7 +170,7 @@ func TestGetByID_ShouldReturnMessageWithSuccess(t *testing.T) {
 	repositoryMock := new(mocks.MessageRepositoryMock)
 	repositoryMock.On("GetByID", ctx, messageID).Return(expectedMessage, nil)
 
-	service := NewMessageService(nil, repositoryMock)
+	service := NewMessageService(nil, nil, repositoryMock, nil, newPolicyMock())
 	actualMessage, err := service.GetByID(ctx, messageID)
 
 	assert.NoError(t, err)
@@ -97,9 +182,9 @@ func TestGetAll_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
 	unexpectedError := errors.New("unexpected error")
 
 	repositoryMock := new(mocks.MessageRepositoryMock)
-	repositoryMock.On("GetAll", ctx).Return([]domain.Message{}, unexpectedError)
+	repositoryMock.On("ForEach", ctx).Return([]domain.Message{}, unexpectedError)
 
-	service := NewMessageService(nil, repositoryMock)
+	service := NewMessageService(nil, nil, repositoryMock, nil, newPolicyMock())
 	actualMessages, err := service.GetAll(ctx)
 
 	assert.ErrorIs(t, err, apperrors.InternalServerError)
@@ -113,24 +198,42 @@ func TestGetAll_ShouldReturnAllMessagesWithSuccess(t *testing.T) {
 	expectedMessages := []domain.Message{firstMessage, secondMessage}
 
 	repositoryMock := new(mocks.MessageRepositoryMock)
-	repositoryMock.On("GetAll", ctx).Return(expectedMessages, nil)
+	repositoryMock.On("ForEach", ctx).Return(expectedMessages, nil)
 
-	service := NewMessageService(nil, repositoryMock)
+	service := NewMessageService(nil, nil, repositoryMock, nil, newPolicyMock())
 	actualMessages, err := service.GetAll(ctx)
 
 	assert.NoError(t, err)
 	assert.Equal(t, expectedMessages, actualMessages)
 }
 
+func TestForEach_ShouldKeepClassifiedErrorsOfCallback(t *testing.T) {
+	ctx := context.Background()
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	repositoryMock.On("ForEach", ctx).Return([]domain.Message{domain.NewMessage("id1", "message content")}, nil)
+
+	service := NewMessageService(nil, nil, repositoryMock, nil, newPolicyMock())
+	err := service.ForEach(ctx, "", func(message domain.Message) error {
+		return apperrors.NotFound
+	})
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.NotErrorIs(t, err, apperrors.InternalServerError)
+}
+
 func TestDeleteByID_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
 	ctx := context.Background()
 	messageID := uuid.NewString()
 	unexpectedError := errors.New("unexpected error")
 
+	message := domain.NewMessage(messageID, "message content")
+
 	repositoryMock := new(mocks.MessageRepositoryMock)
-	repositoryMock.On("DeleteByID", ctx, messageID).Return(unexpectedError)
+	repositoryMock.On("GetByID", ctx, messageID).Return(message, nil)
+	repositoryMock.On("Save", ctx, message.DeletedOn(fixedNow)).Return(unexpectedError)
 
-	service := NewMessageService(nil, repositoryMock)
+	service := NewMessageService(nil, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, nil), newPolicyMock())
 	err := service.DeleteByID(ctx, messageID)
 
 	assert.ErrorIs(t, err, apperrors.InternalServerError)
@@ -141,23 +244,479 @@ func TestDeleteByID_ShouldReturnErrorWhenMessageNotFound(t *testing.T) {
 	messageID := uuid.NewString()
 
 	repositoryMock := new(mocks.MessageRepositoryMock)
-	repositoryMock.On("DeleteByID", ctx, messageID).Return(apperrors.NotFound)
+	repositoryMock.On("GetByID", ctx, messageID).Return(domain.Message{}, apperrors.NotFound)
 
-	service := NewMessageService(nil, repositoryMock)
+	service := NewMessageService(nil, nil, nil, newUnitOfWorkMock(repositoryMock, nil), newPolicyMock())
 	err := service.DeleteByID(ctx, messageID)
 
 	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.NotErrorIs(t, err, apperrors.InternalServerError)
 }
 
-func TestDeleteByID_ShouldDeleteMessageWithSuccess(t *testing.T) {
+func TestDeleteByID_ShouldReturnErrorWhenMessageIsDeleted(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage(uuid.NewString(), "message content").DeletedOn(fixedNow)
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	repositoryMock.On("GetByID", ctx, message.ID).Return(message, nil)
+
+	service := NewMessageService(nil, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, nil), newPolicyMock())
+	err := service.DeleteByID(ctx, message.ID)
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	repositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestDeleteByID_ShouldMoveMessageToTrash(t *testing.T) {
 	ctx := context.Background()
 	messageID := uuid.NewString()
+	message := domain.NewMessage(messageID, "message content").OwnedBy("user-1")
 
 	repositoryMock := new(mocks.MessageRepositoryMock)
-	repositoryMock.On("DeleteByID", ctx, messageID).Return(nil)
+	outboxMock := new(mocks.OutboxRepositoryMock)
+	repositoryMock.On("GetByID", ctx, messageID).Return(message, nil)
+	repositoryMock.On("Save", ctx, message.DeletedOn(fixedNow)).Return(nil)
+	outboxMock.On("Append", ctx, outboxEntries(t, domain.NewMessageDeleted(messageID, fixedNow).OwnedBy("user-1"))).Return(nil)
 
-	service := NewMessageService(nil, repositoryMock)
+	service := NewMessageService(nil, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, outboxMock), newPolicyMock())
 	err := service.DeleteByID(ctx, messageID)
 
 	assert.NoError(t, err)
+	repositoryMock.AssertExpectations(t)
+	outboxMock.AssertExpectations(t)
+}
+
+func TestSaveBatch_ShouldReturnErrorWhenModeIsInvalid(t *testing.T) {
+	service := NewMessageService(nil, nil, nil, nil, newPolicyMock())
+	results, err := service.SaveBatch(context.Background(), []string{"message content"}, domain.BatchMode("partial"))
+
+	assert.ErrorIs(t, err, apperrors.InvalidInput)
+	assert.Empty(t, results)
+}
+
+func TestSaveBatch_ShouldReturnErrorWhenBatchIsEmpty(t *testing.T) {
+	service := NewMessageService(nil, nil, nil, nil, newPolicyMock())
+	results, err := service.SaveBatch(context.Background(), []string{}, domain.BatchModeAtomic)
+
+	assert.ErrorIs(t, err, apperrors.InvalidInput)
+	assert.Empty(t, results)
+}
+
+func TestSaveBatch_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
+	ctx := context.Background()
+	messageID := uuid.NewString()
+	content := "message content"
+	unexpectedError := errors.New("unexpected error")
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	identifierMock.On("New").Return(messageID)
+	repositoryMock.On("SaveBatch", ctx, []domain.Message{domain.NewMessage(messageID, content)}, domain.BatchModeAtomic).
+		Return([]domain.BatchResult{}, unexpectedError)
+
+	service := NewMessageService(identifierMock, nil, nil, newUnitOfWorkMock(repositoryMock, nil), newPolicyMock())
+	results, err := service.SaveBatch(ctx, []string{content}, domain.BatchModeAtomic)
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+	assert.Empty(t, results)
+}
+
+func TestSaveBatch_ShouldReturnErrorWhenUnitOfWorkFails(t *testing.T) {
+	ctx := context.Background()
+	unexpectedError := errors.New("unexpected error")
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	identifierMock.On("New").Return(uuid.NewString())
+	unitOfWorkMock := new(mocks.UnitOfWorkMock)
+	unitOfWorkMock.On("Do", ctx).Return(unexpectedError)
+
+	service := NewMessageService(identifierMock, nil, nil, unitOfWorkMock, newPolicyMock())
+	results, err := service.SaveBatch(ctx, []string{"message content"}, domain.BatchModeAtomic)
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+	assert.ErrorIs(t, err, unexpectedError)
+	assert.Empty(t, results)
+}
+
+func TestSaveBatch_ShouldReturnErrorWhenAtomicBatchHasFailures(t *testing.T) {
+	ctx := context.Background()
+	messageID := uuid.NewString()
+	content := "message content"
+	expectedResults := []domain.BatchResult{domain.NewBatchResult(messageID, errors.New("unexpected error"))}
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	identifierMock.On("New").Return(messageID)
+	repositoryMock.On("SaveBatch", ctx, []domain.Message{domain.NewMessage(messageID, content)}, domain.BatchModeAtomic).
+		Return(expectedResults, nil)
+
+	service := NewMessageService(identifierMock, nil, nil, newUnitOfWorkMock(repositoryMock, nil), newPolicyMock())
+	actualResults, err := service.SaveBatch(ctx, []string{content}, domain.BatchModeAtomic)
+
+	assert.ErrorIs(t, err, apperrors.UnprocessableEntity)
+	assert.Equal(t, expectedResults, actualResults)
+}
+
+func TestSaveBatch_ShouldRecordEventsOnlyForSavedMessagesWhenBatchIsBestEffort(t *testing.T) {
+	ctx := context.Background()
+	firstMessage := domain.NewMessage(uuid.NewString(), "message content 1")
+	secondMessage := domain.NewMessage(uuid.NewString(), "message content 2")
+	expectedResults := []domain.BatchResult{
+		domain.NewBatchResult(firstMessage.ID, errors.New("unexpected error")),
+		domain.NewBatchResult(secondMessage.ID, nil),
+	}
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	outboxMock := new(mocks.OutboxRepositoryMock)
+	identifierMock.On("New").Return(firstMessage.ID).Once()
+	identifierMock.On("New").Return(secondMessage.ID).Once()
+	repositoryMock.On("SaveBatch", ctx, []domain.Message{firstMessage, secondMessage}, domain.BatchModeBestEffort).
+		Return(expectedResults, nil)
+	outboxMock.On("Append", ctx, outboxEntries(t, domain.NewMessageCreated(secondMessage, fixedNow))).Return(nil)
+
+	service := NewMessageService(identifierMock, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, outboxMock), newPolicyMock())
+	actualResults, err := service.SaveBatch(ctx, []string{firstMessage.Content, secondMessage.Content}, domain.BatchModeBestEffort)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResults, actualResults)
+	outboxMock.AssertExpectations(t)
+}
+
+func TestSaveBatch_ShouldSaveMessagesWithSuccess(t *testing.T) {
+	ctx := context.Background()
+	firstMessage := domain.NewMessage(uuid.NewString(), "message content 1")
+	secondMessage := domain.NewMessage(uuid.NewString(), "message content 2")
+	expectedResults := []domain.BatchResult{
+		domain.NewBatchResult(firstMessage.ID, nil),
+		domain.NewBatchResult(secondMessage.ID, nil),
+	}
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	outboxMock := new(mocks.OutboxRepositoryMock)
+	identifierMock.On("New").Return(firstMessage.ID).Once()
+	identifierMock.On("New").Return(secondMessage.ID).Once()
+	repositoryMock.On("SaveBatch", ctx, []domain.Message{firstMessage, secondMessage}, domain.BatchModeAtomic).
+		Return(expectedResults, nil)
+	outboxMock.On("Append", ctx, outboxEntries(t,
+		domain.NewMessageCreated(firstMessage, fixedNow),
+		domain.NewMessageCreated(secondMessage, fixedNow),
+	)).Return(nil)
+
+	service := NewMessageService(identifierMock, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, outboxMock), newPolicyMock())
+	actualResults, err := service.SaveBatch(ctx, []string{firstMessage.Content, secondMessage.Content}, domain.BatchModeAtomic)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResults, actualResults)
+	outboxMock.AssertExpectations(t)
+}
+
+func TestDeleteBatch_ShouldReturnErrorWhenModeIsInvalid(t *testing.T) {
+	service := NewMessageService(nil, nil, nil, nil, newPolicyMock())
+	results, err := service.DeleteBatch(context.Background(), []string{uuid.NewString()}, domain.BatchMode("partial"))
+
+	assert.ErrorIs(t, err, apperrors.InvalidInput)
+	assert.Empty(t, results)
+}
+
+func TestDeleteBatch_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
+	ctx := context.Background()
+	ids := []string{uuid.NewString()}
+	unexpectedError := errors.New("unexpected error")
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	repositoryMock.On("GetByID", ctx, ids[0]).Return(domain.NewMessage(ids[0], "message content"), nil)
+	repositoryMock.On("SaveBatch", ctx, mock.Anything, domain.BatchModeAtomic).Return([]domain.BatchResult{}, unexpectedError)
+
+	service := NewMessageService(nil, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, nil), newPolicyMock())
+	results, err := service.DeleteBatch(ctx, ids, domain.BatchModeAtomic)
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+	assert.Empty(t, results)
+}
+
+func TestDeleteBatch_ShouldReturnErrorWhenAtomicBatchHasFailures(t *testing.T) {
+	ctx := context.Background()
+	ids := []string{uuid.NewString(), uuid.NewString()}
+	expectedResults := []domain.BatchResult{
+		domain.NewBatchResult(ids[0], apperrors.Aborted),
+		domain.NewBatchResult(ids[1], apperrors.NotFound),
+	}
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	repositoryMock.On("GetByID", ctx, ids[0]).Return(domain.NewMessage(ids[0], "message content"), nil)
+	repositoryMock.On("GetByID", ctx, ids[1]).Return(domain.Message{}, apperrors.NotFound)
+
+	service := NewMessageService(nil, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, nil), newPolicyMock())
+	actualResults, err := service.DeleteBatch(ctx, ids, domain.BatchModeAtomic)
+
+	assert.ErrorIs(t, err, apperrors.UnprocessableEntity)
+	assert.Equal(t, expectedResults, actualResults)
+	repositoryMock.AssertNotCalled(t, "SaveBatch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteBatch_ShouldMoveMessagesToTrash(t *testing.T) {
+	ctx := context.Background()
+	messages := []domain.Message{
+		domain.NewMessage(uuid.NewString(), "message content 1"),
+		domain.NewMessage(uuid.NewString(), "message content 2"),
+	}
+	ids := []string{messages[0].ID, messages[1].ID}
+	expectedResults := []domain.BatchResult{
+		domain.NewBatchResult(ids[0], nil),
+		domain.NewBatchResult(ids[1], nil),
+	}
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	outboxMock := new(mocks.OutboxRepositoryMock)
+	repositoryMock.On("GetByID", ctx, ids[0]).Return(messages[0], nil)
+	repositoryMock.On("GetByID", ctx, ids[1]).Return(messages[1], nil)
+	repositoryMock.On("SaveBatch", ctx, []domain.Message{messages[0].DeletedOn(fixedNow), messages[1].DeletedOn(fixedNow)}, domain.BatchModeBestEffort).Return(expectedResults, nil)
+	outboxMock.On("Append", ctx, outboxEntries(t,
+		domain.NewMessageDeleted(ids[0], fixedNow),
+		domain.NewMessageDeleted(ids[1], fixedNow),
+	)).Return(nil)
+
+	service := NewMessageService(nil, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, outboxMock), newPolicyMock())
+	actualResults, err := service.DeleteBatch(ctx, ids, domain.BatchModeBestEffort)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResults, actualResults)
+	outboxMock.AssertExpectations(t)
+}
+
+func TestSave_ShouldRecordPrincipalAsOwner(t *testing.T) {
+	ctx := domain.ContextWithPrincipal(context.Background(), domain.NewPrincipal("user-1", nil))
+	messageID := uuid.NewString()
+	expectedMessage := domain.NewMessage(messageID, "message content").OwnedBy("user-1")
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	outboxMock := new(mocks.OutboxRepositoryMock)
+	identifierMock.On("New").Return(messageID)
+	repositoryMock.On("Save", ctx, expectedMessage).Return(nil)
+	outboxMock.On("Append", ctx, mock.Anything).Return(nil)
+
+	service := NewMessageService(identifierMock, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, outboxMock), newPolicyMock())
+	actualMessage, err := service.Save(ctx, "message content")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMessage, actualMessage)
+}
+
+func TestSave_ShouldReturnErrorWhenPolicyDenies(t *testing.T) {
+	ctx := context.Background()
+
+	policyMock := new(mocks.MessagePolicyMock)
+	policyMock.On("Authorize", ctx, domain.ActionCreate).Return(apperrors.Forbidden)
+
+	service := NewMessageService(nil, nil, nil, nil, policyMock)
+	actualMessage, err := service.Save(ctx, "message content")
+
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	assert.Empty(t, actualMessage)
+}
+
+func TestGetByID_ShouldReturnErrorWhenPolicyDeniesMessage(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage(uuid.NewString(), "message content").OwnedBy("user-2")
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	policyMock := new(mocks.MessagePolicyMock)
+	repositoryMock.On("GetByID", ctx, message.ID).Return(message, nil)
+	policyMock.On("Authorize", ctx, domain.ActionRead).Return(nil)
+	policyMock.On("AuthorizeMessage", ctx, domain.ActionRead, message).Return(apperrors.Forbidden)
+
+	service := NewMessageService(nil, nil, repositoryMock, nil, policyMock)
+	actualMessage, err := service.GetByID(ctx, message.ID)
+
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	assert.NotErrorIs(t, err, apperrors.InternalServerError)
+	assert.Empty(t, actualMessage)
+}
+
+func TestForEach_ShouldSkipMessagesPolicyDenies(t *testing.T) {
+	ctx := context.Background()
+	owned := domain.NewMessage("id1", "message content 1").OwnedBy("user-1")
+	other := domain.NewMessage("id2", "message content 2").OwnedBy("user-2")
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	policyMock := new(mocks.MessagePolicyMock)
+	repositoryMock.On("ForEach", ctx).Return([]domain.Message{owned, other}, nil)
+	policyMock.On("Authorize", ctx, domain.ActionRead).Return(nil)
+	policyMock.On("AuthorizeMessage", ctx, domain.ActionRead, owned).Return(nil)
+	policyMock.On("AuthorizeMessage", ctx, domain.ActionRead, other).Return(apperrors.Forbidden)
+
+	service := NewMessageService(nil, nil, repositoryMock, nil, policyMock)
+	actualMessages, err := service.GetAll(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{owned}, actualMessages)
+}
+
+func TestDeleteByID_ShouldNotDeleteWhenPolicyDeniesMessage(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage(uuid.NewString(), "message content").OwnedBy("user-2")
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	policyMock := new(mocks.MessagePolicyMock)
+	repositoryMock.On("GetByID", ctx, message.ID).Return(message, nil)
+	policyMock.On("Authorize", ctx, domain.ActionDelete).Return(nil)
+	policyMock.On("AuthorizeMessage", ctx, domain.ActionDelete, message).Return(apperrors.Forbidden)
+
+	service := NewMessageService(nil, nil, nil, newUnitOfWorkMock(repositoryMock, nil), policyMock)
+	err := service.DeleteByID(ctx, message.ID)
+
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	assert.NotErrorIs(t, err, apperrors.InternalServerError)
+	repositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestDeleteBatch_ShouldReportMessagesPolicyDeniesAsFailed(t *testing.T) {
+	ctx := context.Background()
+	owned := domain.NewMessage(uuid.NewString(), "message content 1").OwnedBy("user-1")
+	other := domain.NewMessage(uuid.NewString(), "message content 2").OwnedBy("user-2")
+	unknownID := uuid.NewString()
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	outboxMock := new(mocks.OutboxRepositoryMock)
+	policyMock := new(mocks.MessagePolicyMock)
+	repositoryMock.On("GetByID", ctx, owned.ID).Return(owned, nil)
+	repositoryMock.On("GetByID", ctx, other.ID).Return(other, nil)
+	repositoryMock.On("GetByID", ctx, unknownID).Return(domain.Message{}, apperrors.NotFound)
+	repositoryMock.On("SaveBatch", ctx, []domain.Message{owned.DeletedOn(fixedNow)}, domain.BatchModeBestEffort).Return([]domain.BatchResult{
+		domain.NewBatchResult(owned.ID, nil),
+	}, nil)
+	outboxMock.On("Append", ctx, outboxEntries(t, domain.NewMessageDeleted(owned.ID, fixedNow).OwnedBy("user-1"))).Return(nil)
+	policyMock.On("Authorize", ctx, domain.ActionDelete).Return(nil)
+	policyMock.On("AuthorizeMessage", ctx, domain.ActionDelete, owned).Return(nil)
+	policyMock.On("AuthorizeMessage", ctx, domain.ActionDelete, other).Return(apperrors.Forbidden)
+
+	service := NewMessageService(nil, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, outboxMock), policyMock)
+	results, err := service.DeleteBatch(ctx, []string{owned.ID, other.ID, unknownID}, domain.BatchModeBestEffort)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.BatchResult{
+		domain.NewBatchResult(owned.ID, nil),
+		domain.NewBatchResult(other.ID, apperrors.Forbidden),
+		domain.NewBatchResult(unknownID, apperrors.NotFound),
+	}, results)
+	outboxMock.AssertExpectations(t)
+}
+
+func TestSave_ShouldReturnErrorWhenDailyQuotaIsExceeded(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage(uuid.NewString(), "message content")
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	usageMock := new(mocks.UsageRepositoryMock)
+	quotasMock := new(mocks.TenantQuotasMock)
+	identifierMock.On("New").Return(message.ID)
+	repositoryMock.On("Save", ctx, message).Return(nil)
+	usageMock.On("Add", ctx, fixedNow, 1).Return(11, nil)
+	quotasMock.On("DailyMessages", ctx).Return(10)
+	unitOfWorkMock := &mocks.UnitOfWorkMock{Repositories: ports.Repositories{Messages: repositoryMock, Usage: usageMock}}
+	unitOfWorkMock.On("Do", ctx).Return(nil)
+
+	service := NewMessageService(identifierMock, newClockMock(), nil, unitOfWorkMock, newPolicyMock()).WithQuotas(quotasMock)
+	actualMessage, err := service.Save(ctx, message.Content)
+
+	assert.ErrorIs(t, err, apperrors.TooManyRequests)
+	assert.Empty(t, actualMessage)
+}
+
+func TestSave_ShouldNotCountUsageOfTenantWithoutQuota(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage(uuid.NewString(), "message content")
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	outboxMock := new(mocks.OutboxRepositoryMock)
+	usageMock := new(mocks.UsageRepositoryMock)
+	quotasMock := new(mocks.TenantQuotasMock)
+	identifierMock.On("New").Return(message.ID)
+	repositoryMock.On("Save", ctx, message).Return(nil)
+	outboxMock.On("Append", ctx, mock.Anything).Return(nil)
+	quotasMock.On("DailyMessages", ctx).Return(0)
+	unitOfWorkMock := &mocks.UnitOfWorkMock{Repositories: ports.Repositories{Messages: repositoryMock, Outbox: outboxMock, Usage: usageMock, Audit: newAuditMock()}}
+	unitOfWorkMock.On("Do", ctx).Return(nil)
+
+	service := NewMessageService(identifierMock, newClockMock(), nil, unitOfWorkMock, newPolicyMock()).WithQuotas(quotasMock)
+	_, err := service.Save(ctx, message.Content)
+
+	assert.NoError(t, err)
+	usageMock.AssertNotCalled(t, "Add", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSaveBatch_ShouldCountOnlySavedMessagesAgainstQuota(t *testing.T) {
+	ctx := context.Background()
+	firstMessage := domain.NewMessage(uuid.NewString(), "message content 1")
+	secondMessage := domain.NewMessage(uuid.NewString(), "message content 2")
+	expectedResults := []domain.BatchResult{
+		domain.NewBatchResult(firstMessage.ID, errors.New("unexpected error")),
+		domain.NewBatchResult(secondMessage.ID, nil),
+	}
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	outboxMock := new(mocks.OutboxRepositoryMock)
+	usageMock := new(mocks.UsageRepositoryMock)
+	quotasMock := new(mocks.TenantQuotasMock)
+	identifierMock.On("New").Return(firstMessage.ID).Once()
+	identifierMock.On("New").Return(secondMessage.ID).Once()
+	repositoryMock.On("SaveBatch", ctx, []domain.Message{firstMessage, secondMessage}, domain.BatchModeBestEffort).
+		Return(expectedResults, nil)
+	outboxMock.On("Append", ctx, mock.Anything).Return(nil)
+	usageMock.On("Add", ctx, fixedNow, 1).Return(10, nil)
+	quotasMock.On("DailyMessages", ctx).Return(10)
+	unitOfWorkMock := &mocks.UnitOfWorkMock{Repositories: ports.Repositories{Messages: repositoryMock, Outbox: outboxMock, Usage: usageMock, Audit: newAuditMock()}}
+	unitOfWorkMock.On("Do", ctx).Return(nil)
+
+	service := NewMessageService(identifierMock, newClockMock(), nil, unitOfWorkMock, newPolicyMock()).WithQuotas(quotasMock)
+	actualResults, err := service.SaveBatch(ctx, []string{firstMessage.Content, secondMessage.Content}, domain.BatchModeBestEffort)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResults, actualResults)
+	usageMock.AssertExpectations(t)
+}
+
+// newPolicyMock allows every action.
+func newPolicyMock() *mocks.MessagePolicyMock {
+	policyMock := new(mocks.MessagePolicyMock)
+	policyMock.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	policyMock.On("AuthorizeMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return policyMock
+}
+
+func newClockMock() *mocks.ClockMock {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(fixedNow)
+	return clockMock
+}
+
+func newUnitOfWorkMock(repository ports.MessageRepository, outbox ports.OutboxRepository) *mocks.UnitOfWorkMock {
+	unitOfWorkMock := &mocks.UnitOfWorkMock{Repositories: ports.Repositories{Messages: repository, Outbox: outbox, Audit: newAuditMock()}}
+	unitOfWorkMock.On("Do", mock.Anything).Return(nil)
+	return unitOfWorkMock
+}
+
+// newAuditMock accepts every entry.
+func newAuditMock() *mocks.AuditRepositoryMock {
+	auditMock := new(mocks.AuditRepositoryMock)
+	auditMock.On("Append", mock.Anything, mock.Anything).Return(domain.AuditEntry{}, nil)
+	return auditMock
+}
+
+func outboxEntries(t *testing.T, events ...domain.Event) []domain.OutboxEntry {
+	entries := make([]domain.OutboxEntry, len(events))
+	for i, event := range events {
+		entry, err := domain.NewOutboxEntry(domain.DefaultTenant, event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[i] = entry
+	}
+	return entries
 }
@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+// importChunkSize is the number of messages stored per unit of work, so an
+// import holds at most one chunk in memory.
+const importChunkSize = maxBatchSize
+
+var (
+	errInvalidImportIDMode   = fmt.Errorf("ids must be %s or %s", domain.ImportIDsPreserve, domain.ImportIDsRegenerate)
+	errInvalidConflictPolicy = fmt.Errorf("conflict must be %s, %s or %s", domain.ImportConflictSkip, domain.ImportConflictOverwrite, domain.ImportConflictFail)
+	errMissingImportID       = errors.New("id is required to preserve ids")
+	errInvalidImportID       = errors.New("id must be a UUID")
+	errMessageExists         = errors.New("message already exists")
+)
+
+func (m messageService) Export(ctx context.Context, fn func(message domain.Message) error) error {
+	return m.ForEach(ctx, "", fn)
+}
+
+// Import stores the records in chunks, each in its own unit of work. Records
+// that cannot be imported are reported and skipped, except for conflicts under
+// the fail policy, which roll back the current chunk and stop the import, as
+// does exceeding the daily quota of the tenant. The report only counts
+// committed chunks. New messages are owned by the importing
+// principal, while overwritten ones keep their owner. A deleted message is
+// replaced as if it did not exist.
+func (m messageService) Import(ctx context.Context, next func() (domain.ImportRecord, error), options domain.ImportOptions) (domain.ImportReport, error) {
+	if err := m.policy.Authorize(ctx, domain.ActionImport); err != nil {
+		return domain.ImportReport{}, err
+	}
+	if !options.IDs.IsValid() {
+		return domain.ImportReport{}, errors.Join(apperrors.InvalidInput, errInvalidImportIDMode)
+	}
+	if !options.Conflict.IsValid() {
+		return domain.ImportReport{}, errors.Join(apperrors.InvalidInput, errInvalidConflictPolicy)
+	}
+
+	var report domain.ImportReport
+	chunk := make([]domain.ImportRecord, 0, importChunkSize)
+	for {
+		item, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return report, errors.Join(apperrors.InvalidInput, err)
+		}
+
+		switch {
+		case item.Err != nil:
+			report.Fail(item, errors.Join(apperrors.InvalidInput, item.Err))
+			continue
+		case options.IDs == domain.ImportIDsRegenerate:
+			item.Message.ID = m.uuidGenerator.New()
+		case item.Message.ID == "":
+			report.Fail(item, errors.Join(apperrors.InvalidInput, errMissingImportID))
+			continue
+		default:
+			if _, err := uuid.Parse(item.Message.ID); err != nil {
+				report.Fail(item, errors.Join(apperrors.InvalidInput, errInvalidImportID, err))
+				continue
+			}
+		}
+
+		item.Message = item.Message.OwnedBy(owner(ctx))
+		chunk = append(chunk, item)
+		if len(chunk) < importChunkSize {
+			continue
+		}
+		if err := m.importChunk(ctx, chunk, options, &report); err != nil {
+			return report, err
+		}
+		chunk = chunk[:0]
+	}
+
+	if len(chunk) > 0 {
+		if err := m.importChunk(ctx, chunk, options, &report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+func (m messageService) importChunk(ctx context.Context, chunk []domain.ImportRecord, options domain.ImportOptions, report *domain.ImportReport) error {
+	var outcome domain.ImportReport
+	var conflicting domain.ImportRecord
+	err := m.transaction(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		outcome = domain.ImportReport{}
+		now := m.clock.Now()
+		var events []domain.Event
+		var entries []domain.AuditEntry
+		for _, item := range chunk {
+			previous, err := getMessage(ctx, repositories.Messages, item.Message.ID)
+			switch {
+			case errors.Is(err, apperrors.NotFound):
+				events = append(events, domain.NewMessageCreated(item.Message, now))
+				entries = append(entries, auditEntry(ctx, now, domain.ActionImport, nil, &item.Message))
+				outcome.Created++
+			case err != nil:
+				return err
+			case options.Conflict == domain.ImportConflictSkip:
+				outcome.Skipped++
+				continue
+			case options.Conflict == domain.ImportConflictFail:
+				conflicting = item
+				return errors.Join(apperrors.Conflict, fmt.Errorf("line %d: %w", item.Line, errMessageExists))
+			default:
+				item.Message = item.Message.OwnedBy(previous.Owner)
+				events = append(events, domain.NewMessageUpdated(previous, item.Message, now))
+				entries = append(entries, auditEntry(ctx, now, domain.ActionImport, &previous, &item.Message))
+				outcome.Overwritten++
+			}
+
+			if err := repositories.Messages.Save(ctx, item.Message); err != nil {
+				return errors.Join(apperrors.InternalServerError, err)
+			}
+		}
+		if err := m.consumeQuota(ctx, repositories, outcome.Created); err != nil {
+			return err
+		}
+		if err := audit(ctx, repositories, entries...); err != nil {
+			return err
+		}
+		return record(ctx, repositories, events...)
+	})
+	if err != nil {
+		if errors.Is(err, apperrors.Conflict) {
+			report.Fail(conflicting, err)
+		}
+		return err
+	}
+
+	report.Add(outcome)
+	return nil
+}
@@ -0,0 +1,241 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	existingImportID = "6f1c1a52-3a55-4a4e-9d51-3a1f1b0c7d21"
+	newImportID      = "0b8e4c1d-6a7f-4b3e-8c2d-5e9f1a2b3c4d"
+)
+
+func TestExport_ShouldVisitEveryMessage(t *testing.T) {
+	ctx := context.Background()
+	messages := []domain.Message{domain.NewMessage("1", "first"), domain.NewMessage("2", "second")}
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	repositoryMock.On("ForEach", ctx).Return(messages, nil)
+
+	var exported []domain.Message
+	service := NewMessageService(nil, nil, repositoryMock, nil, newPolicyMock())
+	err := service.Export(ctx, func(message domain.Message) error {
+		exported = append(exported, message)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, messages, exported)
+}
+
+func TestExport_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
+	ctx := context.Background()
+	unexpectedError := errors.New("unexpected error")
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	repositoryMock.On("ForEach", ctx).Return([]domain.Message{}, unexpectedError)
+
+	service := NewMessageService(nil, nil, repositoryMock, nil, newPolicyMock())
+	err := service.Export(ctx, func(message domain.Message) error { return nil })
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+	assert.ErrorIs(t, err, unexpectedError)
+}
+
+func TestImport_ShouldReturnErrorWhenOptionsAreInvalid(t *testing.T) {
+	service := NewMessageService(nil, nil, nil, nil, newPolicyMock())
+	_, err := service.Import(context.Background(), importRecords(), domain.NewImportOptions("keep", domain.ImportConflictSkip))
+
+	assert.ErrorIs(t, err, apperrors.InvalidInput)
+
+	_, err = service.Import(context.Background(), importRecords(), domain.NewImportOptions(domain.ImportIDsPreserve, "merge"))
+
+	assert.ErrorIs(t, err, apperrors.InvalidInput)
+}
+
+func TestImport_ShouldReturnErrorWhenPolicyDenies(t *testing.T) {
+	ctx := context.Background()
+
+	policyMock := new(mocks.MessagePolicyMock)
+	policyMock.On("Authorize", ctx, domain.ActionImport).Return(apperrors.Forbidden)
+
+	service := NewMessageService(nil, nil, nil, nil, policyMock)
+	report, err := service.Import(ctx, importRecords(), domain.NewImportOptions(domain.ImportIDsPreserve, domain.ImportConflictSkip))
+
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	assert.Empty(t, report)
+}
+
+func TestImport_ShouldApplyConflictPolicy(t *testing.T) {
+	ctx := context.Background()
+	existing := domain.NewMessage(existingImportID, "stored")
+	imported := domain.NewMessage(existingImportID, "imported")
+	created := domain.NewMessage(newImportID, "new")
+
+	tests := []struct {
+		conflict domain.ImportConflictPolicy
+		saved    []domain.Message
+		events   []domain.Event
+		audited  []domain.AuditEntry
+		report   domain.ImportReport
+	}{
+		{
+			conflict: domain.ImportConflictSkip,
+			saved:    []domain.Message{created},
+			events:   []domain.Event{domain.NewMessageCreated(created, fixedNow)},
+			audited: []domain.AuditEntry{
+				domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionImport, created.ID, nil).WithSnapshots(nil, domain.NewAuditSnapshot(created)),
+			},
+			report: domain.ImportReport{Created: 1, Skipped: 1},
+		},
+		{
+			conflict: domain.ImportConflictOverwrite,
+			saved:    []domain.Message{imported, created},
+			events:   []domain.Event{domain.NewMessageUpdated(existing, imported, fixedNow), domain.NewMessageCreated(created, fixedNow)},
+			audited: []domain.AuditEntry{
+				domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionImport, imported.ID, nil).WithSnapshots(domain.NewAuditSnapshot(existing), domain.NewAuditSnapshot(imported)),
+				domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionImport, created.ID, nil).WithSnapshots(nil, domain.NewAuditSnapshot(created)),
+			},
+			report: domain.ImportReport{Created: 1, Overwritten: 1},
+		},
+	}
+	for _, tt := range tests {
+		repositoryMock := new(mocks.MessageRepositoryMock)
+		outboxMock := new(mocks.OutboxRepositoryMock)
+		repositoryMock.On("GetByID", ctx, existing.ID).Return(existing, nil)
+		repositoryMock.On("GetByID", ctx, created.ID).Return(domain.Message{}, apperrors.NotFound)
+		for _, message := range tt.saved {
+			repositoryMock.On("Save", ctx, message).Return(nil).Once()
+		}
+		outboxMock.On("Append", ctx, outboxEntries(t, tt.events...)).Return(nil)
+		auditMock := new(mocks.AuditRepositoryMock)
+		for _, entry := range tt.audited {
+			auditMock.On("Append", ctx, entry).Return(entry, nil).Once()
+		}
+		unitOfWorkMock := &mocks.UnitOfWorkMock{Repositories: ports.Repositories{Messages: repositoryMock, Outbox: outboxMock, Audit: auditMock}}
+		unitOfWorkMock.On("Do", ctx).Return(nil)
+
+		service := NewMessageService(nil, newClockMock(), nil, unitOfWorkMock, newPolicyMock())
+		report, err := service.Import(ctx, importRecords(imported, created), domain.NewImportOptions(domain.ImportIDsPreserve, tt.conflict))
+
+		assert.NoError(t, err, tt.conflict)
+		assert.Equal(t, tt.report, report, tt.conflict)
+		repositoryMock.AssertExpectations(t)
+		outboxMock.AssertExpectations(t)
+		auditMock.AssertExpectations(t)
+	}
+}
+
+func TestImport_ShouldStopAtConflictWhenPolicyIsFail(t *testing.T) {
+	ctx := context.Background()
+	existing := domain.NewMessage(existingImportID, "stored")
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	repositoryMock.On("GetByID", ctx, existing.ID).Return(existing, nil)
+
+	service := NewMessageService(nil, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, nil), newPolicyMock())
+	report, err := service.Import(ctx, importRecords(existing), domain.NewImportOptions(domain.ImportIDsPreserve, domain.ImportConflictFail))
+
+	assert.ErrorIs(t, err, apperrors.Conflict)
+	assert.Len(t, report.Failures, 1)
+	assert.Equal(t, 1, report.Failures[0].Line)
+	assert.Equal(t, existing.ID, report.Failures[0].ID)
+	repositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestImport_ShouldStopWhenDailyQuotaIsExceeded(t *testing.T) {
+	ctx := context.Background()
+	existing := domain.NewMessage(existingImportID, "stored")
+	created := domain.NewMessage(newImportID, "new")
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	usageMock := new(mocks.UsageRepositoryMock)
+	quotasMock := new(mocks.TenantQuotasMock)
+	repositoryMock.On("GetByID", ctx, existing.ID).Return(existing, nil)
+	repositoryMock.On("GetByID", ctx, created.ID).Return(domain.Message{}, apperrors.NotFound)
+	repositoryMock.On("Save", ctx, created).Return(nil)
+	usageMock.On("Add", ctx, fixedNow, 1).Return(6, nil)
+	quotasMock.On("DailyMessages", ctx).Return(5)
+	unitOfWorkMock := &mocks.UnitOfWorkMock{Repositories: ports.Repositories{Messages: repositoryMock, Usage: usageMock}}
+	unitOfWorkMock.On("Do", ctx).Return(nil)
+
+	service := NewMessageService(nil, newClockMock(), nil, unitOfWorkMock, newPolicyMock()).WithQuotas(quotasMock)
+	report, err := service.Import(ctx, importRecords(existing, created), domain.NewImportOptions(domain.ImportIDsPreserve, domain.ImportConflictSkip))
+
+	assert.ErrorIs(t, err, apperrors.TooManyRequests)
+	assert.Empty(t, report)
+	usageMock.AssertExpectations(t)
+}
+
+func TestImport_ShouldRegenerateIDsAndReportInvalidRecords(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage("", "content")
+	regenerated := domain.NewMessage("generated", message.Content)
+	invalid := domain.NewImportRecord(2, domain.Message{}, errors.New("invalid character"))
+
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	outboxMock := new(mocks.OutboxRepositoryMock)
+	identifierMock.On("New").Return(regenerated.ID)
+	repositoryMock.On("GetByID", ctx, regenerated.ID).Return(domain.Message{}, apperrors.NotFound)
+	repositoryMock.On("Save", ctx, regenerated).Return(nil)
+	outboxMock.On("Append", ctx, outboxEntries(t, domain.NewMessageCreated(regenerated, fixedNow))).Return(nil)
+
+	records := []domain.ImportRecord{domain.NewImportRecord(1, message, nil), invalid}
+	service := NewMessageService(identifierMock, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, outboxMock), newPolicyMock())
+	report, err := service.Import(ctx, recordSource(records), domain.NewImportOptions(domain.ImportIDsRegenerate, domain.ImportConflictFail))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Len(t, report.Failures, 1)
+	assert.Equal(t, 2, report.Failures[0].Line)
+	assert.ErrorIs(t, report.Failures[0].Err, apperrors.InvalidInput)
+}
+
+func TestImport_ShouldRequireIDsWhenPreserving(t *testing.T) {
+	service := NewMessageService(nil, nil, nil, nil, newPolicyMock())
+	report, err := service.Import(context.Background(), importRecords(domain.NewMessage("", "content")), domain.NewImportOptions(domain.ImportIDsPreserve, domain.ImportConflictSkip))
+
+	assert.NoError(t, err)
+	assert.Zero(t, report.Created)
+	assert.Len(t, report.Failures, 1)
+}
+
+func TestImport_ShouldReportIDsThatAreNotUUIDs(t *testing.T) {
+	service := NewMessageService(nil, nil, nil, nil, newPolicyMock())
+	report, err := service.Import(context.Background(), importRecords(domain.NewMessage("not-a-uuid", "content")), domain.NewImportOptions(domain.ImportIDsPreserve, domain.ImportConflictSkip))
+
+	assert.NoError(t, err)
+	assert.Zero(t, report.Created)
+	assert.Len(t, report.Failures, 1)
+	assert.Equal(t, 1, report.Failures[0].Line)
+	assert.ErrorIs(t, report.Failures[0].Err, apperrors.InvalidInput)
+}
+
+func importRecords(messages ...domain.Message) func() (domain.ImportRecord, error) {
+	records := make([]domain.ImportRecord, len(messages))
+	for i, message := range messages {
+		records[i] = domain.NewImportRecord(i+1, message, nil)
+	}
+	return recordSource(records)
+}
+
+func recordSource(records []domain.ImportRecord) func() (domain.ImportRecord, error) {
+	return func() (domain.ImportRecord, error) {
+		if len(records) == 0 {
+			return domain.ImportRecord{}, io.EOF
+		}
+		record := records[0]
+		records = records[1:]
+		return record, nil
+	}
+}
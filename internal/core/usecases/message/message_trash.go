@@ -0,0 +1,132 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+// purgeChunkSize is the number of messages purged per unit of work.
+const purgeChunkSize = maxBatchSize
+
+var errMessageNotTrashed = errors.New("message is not in the trash")
+
+func (m messageService) ForEachTrashed(ctx context.Context, fn func(message domain.Message) error) error {
+	return m.forEach(ctx, "", true, fn)
+}
+
+// Restore takes the message out of the trash, which publishes it as created
+// again.
+func (m messageService) Restore(ctx context.Context, id string) (domain.Message, error) {
+	if err := m.policy.Authorize(ctx, domain.ActionRestore); err != nil {
+		return domain.Message{}, err
+	}
+
+	var restored domain.Message
+	err := m.transaction(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		message, err := repositories.Messages.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, apperrors.NotFound) {
+				return err
+			}
+			return errors.Join(apperrors.InternalServerError, err)
+		}
+		if !message.IsDeleted() {
+			return errors.Join(apperrors.NotFound, errMessageNotTrashed)
+		}
+		if err := m.policy.AuthorizeMessage(ctx, domain.ActionRestore, message); err != nil {
+			return err
+		}
+
+		restored = message.Restored()
+		if err := repositories.Messages.Save(ctx, restored); err != nil {
+			return errors.Join(apperrors.InternalServerError, err)
+		}
+
+		now := m.clock.Now()
+		if err := audit(ctx, repositories, auditEntry(ctx, now, domain.ActionRestore, nil, &restored)); err != nil {
+			return err
+		}
+		return record(ctx, repositories, domain.NewMessageCreated(restored, now))
+	})
+	if err != nil {
+		return domain.Message{}, err
+	}
+	return restored, nil
+}
+
+// Purge deletes the expired messages in chunks, each in its own unit of work
+// along with their audit entries, so that a failure only leaves the remaining
+// ones for the next purge. The expired ids are collected beforehand, outside
+// of any unit of work, and each message is read again before it is deleted,
+// in case it was restored meanwhile. No event is recorded, as the deletion was
+// already published.
+func (m messageService) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	var ids []string
+	err := m.repository.ForEach(ctx, "", func(message domain.Message) error {
+		if isExpired(message, deletedBefore) {
+			ids = append(ids, message.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Join(apperrors.InternalServerError, err)
+	}
+
+	purged := 0
+	for start := 0; start < len(ids); start += purgeChunkSize {
+		chunk := ids[start:min(start+purgeChunkSize, len(ids))]
+		count, err := m.purgeChunk(ctx, chunk, deletedBefore)
+		if err != nil {
+			return purged, err
+		}
+		purged += count
+	}
+	return purged, nil
+}
+
+func (m messageService) purgeChunk(ctx context.Context, chunk []string, deletedBefore time.Time) (int, error) {
+	var purged int
+	err := m.transaction(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		now := m.clock.Now()
+		ids := make([]string, 0, len(chunk))
+		entries := make([]domain.AuditEntry, 0, len(chunk))
+		for _, id := range chunk {
+			message, err := repositories.Messages.GetByID(ctx, id)
+			if errors.Is(err, apperrors.NotFound) {
+				continue
+			}
+			if err != nil {
+				return errors.Join(apperrors.InternalServerError, err)
+			}
+			if !isExpired(message, deletedBefore) {
+				continue
+			}
+			ids = append(ids, id)
+			entries = append(entries, auditEntry(ctx, now, domain.ActionPurge, &message, nil))
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if _, err := repositories.Messages.DeleteBatch(ctx, ids, domain.BatchModeBestEffort); err != nil {
+			return errors.Join(apperrors.InternalServerError, err)
+		}
+		purged = len(ids)
+		return audit(ctx, repositories, entries...)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+// isExpired reports whether the message was moved to the trash before
+// deletedBefore.
+func isExpired(message domain.Message, deletedBefore time.Time) bool {
+	return message.IsDeleted() && message.DeletedAt.Before(deletedBefore)
+}
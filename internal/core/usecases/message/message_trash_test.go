@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestForEach_ShouldSkipDeletedMessages(t *testing.T) {
+	ctx := context.Background()
+	kept := domain.NewMessage("id1", "message content 1")
+	deleted := domain.NewMessage("id2", "message content 2").DeletedOn(fixedNow)
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	repositoryMock.On("ForEach", ctx).Return([]domain.Message{kept, deleted}, nil)
+	service := NewMessageService(nil, nil, repositoryMock, nil, newPolicyMock())
+
+	messages, err := service.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{kept}, messages)
+
+	var trashed []domain.Message
+	err = service.ForEachTrashed(ctx, func(message domain.Message) error {
+		trashed = append(trashed, message)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{deleted}, trashed)
+}
+
+func TestRestore_ShouldReturnErrorWhenMessageIsNotDeleted(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage(uuid.NewString(), "message content")
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	repositoryMock.On("GetByID", ctx, message.ID).Return(message, nil)
+
+	service := NewMessageService(nil, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, nil), newPolicyMock())
+	actualMessage, err := service.Restore(ctx, message.ID)
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.Empty(t, actualMessage)
+	repositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestRestore_ShouldReturnErrorWhenPolicyDeniesMessage(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage(uuid.NewString(), "message content").OwnedBy("user-2").DeletedOn(fixedNow)
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	policyMock := new(mocks.MessagePolicyMock)
+	repositoryMock.On("GetByID", ctx, message.ID).Return(message, nil)
+	policyMock.On("Authorize", ctx, domain.ActionRestore).Return(nil)
+	policyMock.On("AuthorizeMessage", ctx, domain.ActionRestore, message).Return(apperrors.Forbidden)
+
+	service := NewMessageService(nil, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, nil), policyMock)
+	_, err := service.Restore(ctx, message.ID)
+
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	repositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestRestore_ShouldRestoreMessageAndRecordItAsCreated(t *testing.T) {
+	ctx := context.Background()
+	restored := domain.NewMessage(uuid.NewString(), "message content")
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	outboxMock := new(mocks.OutboxRepositoryMock)
+	repositoryMock.On("GetByID", ctx, restored.ID).Return(restored.DeletedOn(fixedNow), nil)
+	repositoryMock.On("Save", ctx, restored).Return(nil)
+	outboxMock.On("Append", ctx, outboxEntries(t, domain.NewMessageCreated(restored, fixedNow))).Return(nil)
+
+	service := NewMessageService(nil, newClockMock(), nil, newUnitOfWorkMock(repositoryMock, outboxMock), newPolicyMock())
+	actualMessage, err := service.Restore(ctx, restored.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, restored, actualMessage)
+	repositoryMock.AssertExpectations(t)
+	outboxMock.AssertExpectations(t)
+}
+
+func TestPurge_ShouldDeleteAndAuditMessagesDeletedBeforeCutoff(t *testing.T) {
+	ctx := context.Background()
+	expired := domain.NewMessage("id1", "message content 1").DeletedOn(fixedNow.AddDate(0, 0, -31))
+	recent := domain.NewMessage("id2", "message content 2").DeletedOn(fixedNow.AddDate(0, 0, -1))
+	kept := domain.NewMessage("id3", "message content 3")
+	restored := domain.NewMessage("id4", "message content 4")
+	entry := domain.NewAuditEntry(fixedNow, domain.DefaultTenant, domain.ActionPurge, expired.ID, nil).WithSnapshots(domain.NewAuditSnapshot(expired), nil)
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	auditMock := new(mocks.AuditRepositoryMock)
+	repositoryMock.On("ForEach", ctx).Return([]domain.Message{expired, recent, kept, restored.DeletedOn(fixedNow.AddDate(0, 0, -31))}, nil)
+	repositoryMock.On("GetByID", ctx, expired.ID).Return(expired, nil)
+	repositoryMock.On("GetByID", ctx, restored.ID).Return(restored, nil)
+	repositoryMock.On("DeleteBatch", ctx, []string{expired.ID}, domain.BatchModeBestEffort).Return([]domain.BatchResult{domain.NewBatchResult(expired.ID, nil)}, nil)
+	auditMock.On("Append", ctx, entry).Return(entry, nil).Once()
+	unitOfWorkMock := &mocks.UnitOfWorkMock{Repositories: ports.Repositories{Messages: repositoryMock, Audit: auditMock}}
+	unitOfWorkMock.On("Do", ctx).Return(nil)
+
+	service := NewMessageService(nil, newClockMock(), repositoryMock, unitOfWorkMock, nil)
+	purged, err := service.Purge(ctx, fixedNow.AddDate(0, 0, -30))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	repositoryMock.AssertExpectations(t)
+	auditMock.AssertExpectations(t)
+}
+
+func TestPurge_ShouldDeleteEveryChunkInItsOwnUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+	expired := make([]domain.Message, purgeChunkSize+1)
+	for i := range expired {
+		expired[i] = domain.NewMessage(uuid.NewString(), "message content").DeletedOn(fixedNow.AddDate(0, 0, -31))
+	}
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	repositoryMock.On("ForEach", ctx).Return(expired, nil)
+	for _, message := range expired {
+		repositoryMock.On("GetByID", ctx, message.ID).Return(message, nil)
+	}
+	repositoryMock.On("DeleteBatch", ctx, mock.Anything, domain.BatchModeBestEffort).Return([]domain.BatchResult{}, nil)
+	unitOfWorkMock := newUnitOfWorkMock(repositoryMock, nil)
+
+	service := NewMessageService(nil, newClockMock(), repositoryMock, unitOfWorkMock, nil)
+	purged, err := service.Purge(ctx, fixedNow.AddDate(0, 0, -30))
+
+	assert.NoError(t, err)
+	assert.Equal(t, len(expired), purged)
+	unitOfWorkMock.AssertNumberOfCalls(t, "Do", 2)
+	repositoryMock.AssertNumberOfCalls(t, "ForEach", 1)
+}
+
+func TestPurge_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
+	ctx := context.Background()
+	unexpectedError := errors.New("unexpected error")
+
+	repositoryMock := new(mocks.MessageRepositoryMock)
+	repositoryMock.On("ForEach", ctx).Return([]domain.Message{}, unexpectedError)
+
+	service := NewMessageService(nil, nil, repositoryMock, newUnitOfWorkMock(repositoryMock, nil), nil)
+	purged, err := service.Purge(ctx, fixedNow)
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+	assert.Zero(t, purged)
+}
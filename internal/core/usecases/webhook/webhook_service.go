@@ -0,0 +1,219 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+)
+
+const secretSize = 32
+
+var (
+	errInvalidWebhookURL  = errors.New("webhook url must be an absolute http or https url")
+	errAdminScopeRequired = errors.New("the admin scope is required")
+	errPrivateWebhookURL  = errors.New("webhook url must not name a loopback, link-local or private address")
+)
+
+type webhookService struct {
+	uuidGenerator identifier.UUIDGenerator
+	clock         clock.Clock
+	repository    ports.WebhookRepository
+	allowPrivate  bool
+}
+
+func NewWebhookService(uuidGenerator identifier.UUIDGenerator, clock clock.Clock, repository ports.WebhookRepository) webhookService {
+	return webhookService{
+		uuidGenerator: uuidGenerator,
+		clock:         clock,
+		repository:    repository,
+	}
+}
+
+// WithPrivateAddresses lets webhooks be registered for loopback, link-local
+// and private addresses, for development and tests.
+func (w webhookService) WithPrivateAddresses() webhookService {
+	w.allowPrivate = true
+	return w
+}
+
+func (w webhookService) Create(ctx context.Context, rawURL string, events []string, secret string) (domain.Webhook, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return domain.Webhook{}, err
+	}
+	if err := validateWebhook(rawURL, events, w.allowPrivate); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	if secret == "" {
+		var err error
+		secret, err = generateSecret()
+		if err != nil {
+			return domain.Webhook{}, errors.Join(apperrors.InternalServerError, err)
+		}
+	}
+
+	webhook := domain.NewWebhook(w.uuidGenerator.New(), domain.TenantFromContext(ctx), rawURL, secret, events, w.clock.Now())
+	if err := w.repository.Save(ctx, webhook); err != nil {
+		return domain.Webhook{}, errors.Join(apperrors.InternalServerError, err)
+	}
+	return webhook, nil
+}
+
+func (w webhookService) GetByID(ctx context.Context, id string) (domain.Webhook, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return domain.Webhook{}, err
+	}
+
+	webhook, err := w.repository.GetByID(ctx, id)
+	if err != nil {
+		return domain.Webhook{}, classify(err)
+	}
+	return webhook, nil
+}
+
+func (w webhookService) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := w.repository.GetAll(ctx)
+	if err != nil {
+		return nil, errors.Join(apperrors.InternalServerError, err)
+	}
+	return webhooks, nil
+}
+
+func (w webhookService) DeleteByID(ctx context.Context, id string) error {
+	if err := authorizeAdmin(ctx); err != nil {
+		return err
+	}
+
+	if err := w.repository.DeleteByID(ctx, id); err != nil {
+		return classify(err)
+	}
+	return nil
+}
+
+func (w webhookService) GetDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	if _, err := w.GetByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := w.repository.GetDeliveries(ctx, webhookID)
+	if err != nil {
+		return nil, errors.Join(apperrors.InternalServerError, err)
+	}
+	return deliveries, nil
+}
+
+func (w webhookService) Redeliver(ctx context.Context, webhookID string, deliveryID string) (domain.WebhookDelivery, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+
+	delivery, err := w.repository.GetDelivery(ctx, webhookID, deliveryID)
+	if err != nil {
+		return domain.WebhookDelivery{}, classify(err)
+	}
+
+	redelivery := delivery.Redeliver(w.uuidGenerator.New(), w.clock.Now())
+	if err := w.repository.SaveDelivery(ctx, redelivery); err != nil {
+		return domain.WebhookDelivery{}, errors.Join(apperrors.InternalServerError, err)
+	}
+	return redelivery, nil
+}
+
+// Notify queues a delivery of the event for every webhook of the tenant in
+// ctx subscribed to it. The deliveries are sent later by the webhook
+// dispatcher. Unlike the other methods, it is called by the event bus rather
+// than on behalf of a principal, so it is not authorized.
+func (w webhookService) Notify(ctx context.Context, event domain.Event) error {
+	webhooks, err := w.repository.GetAll(ctx)
+	if err != nil {
+		return errors.Join(apperrors.InternalServerError, err)
+	}
+
+	now := w.clock.Now()
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(event.EventName()) {
+			continue
+		}
+
+		delivery, err := domain.NewWebhookDelivery(w.uuidGenerator.New(), webhook, event, now)
+		if err != nil {
+			return errors.Join(apperrors.InternalServerError, err)
+		}
+		if err := w.repository.SaveDelivery(ctx, delivery); err != nil {
+			return errors.Join(apperrors.InternalServerError, err)
+		}
+	}
+	return nil
+}
+
+// authorizeAdmin lets requests without a principal through, as they only
+// reach the use case when authentication is disabled.
+func authorizeAdmin(ctx context.Context) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if ok && !slices.Contains(principal.Scopes, domain.ScopeAdmin) {
+		return errors.Join(apperrors.Forbidden, errAdminScopeRequired)
+	}
+	return nil
+}
+
+func validateWebhook(rawURL string, events []string, allowPrivate bool) error {
+	parsedURL, err := url.ParseRequestURI(rawURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return errors.Join(apperrors.InvalidInput, errInvalidWebhookURL)
+	}
+	if !allowPrivate && !publicHost(parsedURL.Hostname()) {
+		return errors.Join(apperrors.InvalidInput, errPrivateWebhookURL)
+	}
+
+	for _, event := range events {
+		if !domain.IsKnownEvent(event) {
+			return errors.Join(apperrors.InvalidInput, fmt.Errorf("unknown event %q", event))
+		}
+	}
+	return nil
+}
+
+// publicHost rejects the addresses and localhost names that are not public.
+// Other names are only resolved when sending, where the sender checks the
+// address it connects to.
+func publicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return domain.IsPublicAddress(addr)
+	}
+	return true
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func classify(err error) error {
+	if errors.Is(err, apperrors.NotFound) {
+		return err
+	}
+	return errors.Join(apperrors.InternalServerError, err)
+}
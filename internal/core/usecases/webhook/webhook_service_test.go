@@ -0,0 +1,298 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var fixedNow = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestCreate_ShouldReturnErrorWhenURLIsInvalid(t *testing.T) {
+	for _, rawURL := range []string{"", "localhost:8080/hook", "ftp://localhost/hook", "http://"} {
+		service := NewWebhookService(nil, nil, nil)
+		webhook, err := service.Create(context.Background(), rawURL, nil, "")
+
+		assert.ErrorIs(t, err, apperrors.InvalidInput, rawURL)
+		assert.Empty(t, webhook)
+	}
+}
+
+func TestCreate_ShouldReturnErrorWhenURLNamesPrivateAddress(t *testing.T) {
+	for _, rawURL := range []string{
+		"http://localhost/hook",
+		"http://hooks.localhost./hook",
+		"http://127.0.0.1:8080/hook",
+		"http://[::1]/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[fe80::1]/hook",
+		"http://10.0.0.1/hook",
+		"http://192.168.1.10/hook",
+		"http://[fd00::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+		"http://0.0.0.0/hook",
+	} {
+		service := NewWebhookService(nil, nil, nil)
+		webhook, err := service.Create(context.Background(), rawURL, nil, "")
+
+		assert.ErrorIs(t, err, apperrors.InvalidInput, rawURL)
+		assert.Empty(t, webhook)
+	}
+}
+
+func TestCreate_ShouldAcceptPrivateAddressWhenAllowed(t *testing.T) {
+	ctx := context.Background()
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("Save", ctx, mock.Anything).Return(nil)
+
+	service := NewWebhookService(newUUIDGeneratorMock("webhook-id"), newClockMock(), repositoryMock).WithPrivateAddresses()
+	webhook, err := service.Create(ctx, "http://127.0.0.1:8080/hook", nil, "secret")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:8080/hook", webhook.URL)
+}
+
+func TestCreate_ShouldReturnErrorWhenEventIsUnknown(t *testing.T) {
+	service := NewWebhookService(nil, nil, nil)
+	webhook, err := service.Create(context.Background(), "http://localhost/hook", []string{"message.archived"}, "")
+
+	assert.ErrorIs(t, err, apperrors.InvalidInput)
+	assert.Empty(t, webhook)
+}
+
+func TestWebhookService_ShouldReturnForbiddenWithoutAdminScope(t *testing.T) {
+	ctx := domain.ContextWithPrincipal(context.Background(), domain.NewPrincipal("user-1", []string{string(domain.RoleWriter)}))
+	service := NewWebhookService(nil, nil, nil)
+
+	_, err := service.Create(ctx, "https://example.com/hook", nil, "")
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	_, err = service.GetAll(ctx)
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	_, err = service.GetByID(ctx, "webhook-id")
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	err = service.DeleteByID(ctx, "webhook-id")
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	_, err = service.GetDeliveries(ctx, "webhook-id")
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	_, err = service.Redeliver(ctx, "webhook-id", "delivery-id")
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+}
+
+func TestCreate_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
+	ctx := context.Background()
+	unexpectedError := errors.New("unexpected error")
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("Save", ctx, mock.Anything).Return(unexpectedError)
+
+	service := NewWebhookService(newUUIDGeneratorMock("webhook-id"), newClockMock(), repositoryMock)
+	webhook, err := service.Create(ctx, "http://example.com/hook", nil, "secret")
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+	assert.ErrorIs(t, err, unexpectedError)
+	assert.Empty(t, webhook)
+}
+
+func TestCreate_ShouldSaveWebhookWithSuccess(t *testing.T) {
+	ctx := context.Background()
+	expectedWebhook := domain.NewWebhook("webhook-id", domain.DefaultTenant, "https://example.com/hook", "secret", []string{domain.EventMessageCreated}, fixedNow)
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("Save", ctx, expectedWebhook).Return(nil)
+
+	service := NewWebhookService(newUUIDGeneratorMock("webhook-id"), newClockMock(), repositoryMock)
+	webhook, err := service.Create(ctx, expectedWebhook.URL, expectedWebhook.Events, "secret")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedWebhook, webhook)
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestCreate_ShouldGenerateSecretWhenEmpty(t *testing.T) {
+	ctx := context.Background()
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("Save", ctx, mock.Anything).Return(nil)
+
+	service := NewWebhookService(newUUIDGeneratorMock("webhook-id"), newClockMock(), repositoryMock)
+	webhook, err := service.Create(ctx, "http://example.com/hook", nil, "")
+
+	assert.NoError(t, err)
+	assert.Len(t, webhook.Secret, 2*secretSize)
+}
+
+func TestGetByID_ShouldReturnNotFoundWhenWebhookDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("GetByID", ctx, "webhook-id").Return(domain.Webhook{}, apperrors.NotFound)
+
+	service := NewWebhookService(nil, nil, repositoryMock)
+	webhook, err := service.GetByID(ctx, "webhook-id")
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.NotErrorIs(t, err, apperrors.InternalServerError)
+	assert.Empty(t, webhook)
+}
+
+func TestGetByID_ShouldReturnInternalServerErrorWhenRepositoryFails(t *testing.T) {
+	ctx := context.Background()
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("GetByID", ctx, "webhook-id").Return(domain.Webhook{}, errors.New("unexpected error"))
+
+	service := NewWebhookService(nil, nil, repositoryMock)
+	_, err := service.GetByID(ctx, "webhook-id")
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+}
+
+func TestGetAll_ShouldReturnWebhooks(t *testing.T) {
+	ctx := context.Background()
+	expectedWebhooks := []domain.Webhook{domain.NewWebhook("webhook-id", domain.DefaultTenant, "http://localhost/hook", "secret", nil, fixedNow)}
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("GetAll", ctx).Return(expectedWebhooks, nil)
+
+	service := NewWebhookService(nil, nil, repositoryMock)
+	webhooks, err := service.GetAll(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedWebhooks, webhooks)
+}
+
+func TestDeleteByID_ShouldReturnNotFoundWhenWebhookDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("DeleteByID", ctx, "webhook-id").Return(apperrors.NotFound)
+
+	service := NewWebhookService(nil, nil, repositoryMock)
+	err := service.DeleteByID(ctx, "webhook-id")
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestGetDeliveries_ShouldReturnNotFoundWhenWebhookDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("GetByID", ctx, "webhook-id").Return(domain.Webhook{}, apperrors.NotFound)
+
+	service := NewWebhookService(nil, nil, repositoryMock)
+	deliveries, err := service.GetDeliveries(ctx, "webhook-id")
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.Nil(t, deliveries)
+	repositoryMock.AssertNotCalled(t, "GetDeliveries", mock.Anything, mock.Anything)
+}
+
+func TestGetDeliveries_ShouldReturnDeliveries(t *testing.T) {
+	ctx := context.Background()
+	expectedDeliveries := []domain.WebhookDelivery{{ID: "delivery-id", WebhookID: "webhook-id"}}
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("GetByID", ctx, "webhook-id").Return(domain.Webhook{ID: "webhook-id"}, nil)
+	repositoryMock.On("GetDeliveries", ctx, "webhook-id").Return(expectedDeliveries, nil)
+
+	service := NewWebhookService(nil, nil, repositoryMock)
+	deliveries, err := service.GetDeliveries(ctx, "webhook-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDeliveries, deliveries)
+}
+
+func TestRedeliver_ShouldReturnNotFoundWhenDeliveryDoesNotExist(t *testing.T) {
+	ctx := context.Background()
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("GetDelivery", ctx, "webhook-id", "delivery-id").Return(domain.WebhookDelivery{}, apperrors.NotFound)
+
+	service := NewWebhookService(nil, nil, repositoryMock)
+	_, err := service.Redeliver(ctx, "webhook-id", "delivery-id")
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestRedeliver_ShouldSaveNewPendingDelivery(t *testing.T) {
+	ctx := context.Background()
+	original := domain.WebhookDelivery{
+		ID:        "delivery-id",
+		WebhookID: "webhook-id",
+		EventName: domain.EventMessageCreated,
+		Payload:   []byte(`{}`),
+		Status:    domain.DeliveryStatusFailed,
+	}
+	expectedDelivery := original.Redeliver("redelivery-id", fixedNow)
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("GetDelivery", ctx, "webhook-id", "delivery-id").Return(original, nil)
+	repositoryMock.On("SaveDelivery", ctx, expectedDelivery).Return(nil)
+
+	service := NewWebhookService(newUUIDGeneratorMock("redelivery-id"), newClockMock(), repositoryMock)
+	delivery, err := service.Redeliver(ctx, "webhook-id", "delivery-id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedDelivery, delivery)
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestNotify_ShouldQueueDeliveriesForSubscribedWebhooks(t *testing.T) {
+	ctx := context.Background()
+	event := domain.NewMessageDeleted("message-id", fixedNow)
+	webhooks := []domain.Webhook{
+		domain.NewWebhook("all-events", domain.DefaultTenant, "http://localhost/all", "secret", nil, fixedNow),
+		domain.NewWebhook("created-only", domain.DefaultTenant, "http://localhost/created", "secret", []string{domain.EventMessageCreated}, fixedNow),
+		domain.NewWebhook("deleted-only", domain.DefaultTenant, "http://localhost/deleted", "secret", []string{domain.EventMessageDeleted}, fixedNow),
+	}
+	firstDelivery, _ := domain.NewWebhookDelivery("first-delivery", webhooks[0], event, fixedNow)
+	secondDelivery, _ := domain.NewWebhookDelivery("second-delivery", webhooks[2], event, fixedNow)
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("GetAll", ctx).Return(webhooks, nil)
+	repositoryMock.On("SaveDelivery", ctx, firstDelivery).Return(nil).Once()
+	repositoryMock.On("SaveDelivery", ctx, secondDelivery).Return(nil).Once()
+
+	service := NewWebhookService(newUUIDGeneratorMock("first-delivery", "second-delivery"), newClockMock(), repositoryMock)
+	err := service.Notify(ctx, event)
+
+	assert.NoError(t, err)
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestNotify_ShouldReturnErrorWhenDeliveryCannotBeSaved(t *testing.T) {
+	ctx := context.Background()
+	unexpectedError := errors.New("unexpected error")
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("GetAll", ctx).Return([]domain.Webhook{{ID: "webhook-id"}}, nil)
+	repositoryMock.On("SaveDelivery", ctx, mock.Anything).Return(unexpectedError)
+
+	service := NewWebhookService(newUUIDGeneratorMock("delivery-id"), newClockMock(), repositoryMock)
+	err := service.Notify(ctx, domain.NewMessageDeleted("message-id", fixedNow))
+
+	assert.ErrorIs(t, err, apperrors.InternalServerError)
+	assert.ErrorIs(t, err, unexpectedError)
+}
+
+func newClockMock() *mocks.ClockMock {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(fixedNow)
+	return clockMock
+}
+
+func newUUIDGeneratorMock(ids ...string) *mocks.UUIDGeneratorMock {
+	identifierMock := new(mocks.UUIDGeneratorMock)
+	for _, id := range ids {
+		identifierMock.On("New").Return(id).Once()
+	}
+	return identifierMock
+}
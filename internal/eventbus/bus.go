@@ -0,0 +1,154 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type Mode int
+
+const (
+	Sync Mode = iota
+	Async
+)
+
+const (
+	allEvents       = "*"
+	asyncBufferSize = 256
+)
+
+var errBusClosed = errors.New("event bus is closed")
+
+type Handler func(ctx context.Context, event domain.Event) error
+
+type Bus struct {
+	mode        Mode
+	onError     func(event domain.Event, err error)
+	handlersMu  sync.RWMutex
+	subscribers map[string][]Handler
+	stateMu     sync.RWMutex
+	queue       chan delivery
+	closed      bool
+	done        chan struct{}
+}
+
+type delivery struct {
+	ctx   context.Context
+	event domain.Event
+}
+
+// NewBus creates an in-process bus. Sync buses run the subscribers inside
+// Publish and return their errors; Async buses queue the events to a single
+// worker, keeping the publishing order, and report failures to onError.
+func NewBus(mode Mode, onError func(event domain.Event, err error)) *Bus {
+	if onError == nil {
+		onError = func(domain.Event, error) {}
+	}
+
+	bus := &Bus{
+		mode:        mode,
+		onError:     onError,
+		subscribers: make(map[string][]Handler),
+		done:        make(chan struct{}),
+	}
+	if mode == Async {
+		bus.queue = make(chan delivery, asyncBufferSize)
+		go bus.run()
+	} else {
+		close(bus.done)
+	}
+	return bus
+}
+
+func Subscribe[E domain.Event](bus *Bus, handler func(ctx context.Context, event E) error) {
+	var zero E
+	bus.subscribe(zero.EventName(), func(ctx context.Context, event domain.Event) error {
+		typed, ok := event.(E)
+		if !ok {
+			return nil
+		}
+		return handler(ctx, typed)
+	})
+}
+
+func (b *Bus) SubscribeAll(handler Handler) {
+	b.subscribe(allEvents, handler)
+}
+
+func (b *Bus) Publish(ctx context.Context, events ...domain.Event) error {
+	b.stateMu.RLock()
+	defer b.stateMu.RUnlock()
+
+	if b.closed {
+		return errBusClosed
+	}
+
+	if b.mode == Sync {
+		var errs []error
+		for _, event := range events {
+			errs = append(errs, b.dispatch(ctx, event))
+		}
+		return errors.Join(errs...)
+	}
+
+	for _, event := range events {
+		b.queue <- delivery{ctx: context.WithoutCancel(ctx), event: event}
+	}
+	return nil
+}
+
+// Close stops accepting events and waits until the queued ones are handled.
+func (b *Bus) Close() {
+	b.stateMu.Lock()
+	if !b.closed {
+		b.closed = true
+		if b.queue != nil {
+			close(b.queue)
+		}
+	}
+	b.stateMu.Unlock()
+
+	<-b.done
+}
+
+func (b *Bus) subscribe(eventName string, handler Handler) {
+	b.handlersMu.Lock()
+	defer b.handlersMu.Unlock()
+
+	b.subscribers[eventName] = append(b.subscribers[eventName], handler)
+}
+
+func (b *Bus) run() {
+	defer close(b.done)
+
+	for delivery := range b.queue {
+		if err := b.dispatch(delivery.ctx, delivery.event); err != nil {
+			b.onError(delivery.event, err)
+		}
+	}
+}
+
+func (b *Bus) dispatch(ctx context.Context, event domain.Event) error {
+	b.handlersMu.RLock()
+	handlers := append(append([]Handler(nil), b.subscribers[event.EventName()]...), b.subscribers[allEvents]...)
+	b.handlersMu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		errs = append(errs, safeHandle(ctx, handler, event))
+	}
+	return errors.Join(errs...)
+}
+
+func safeHandle(ctx context.Context, handler Handler, event domain.Event) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("subscriber panicked handling %s: %v", event.EventName(), recovered)
+		}
+	}()
+	return handler(ctx, event)
+}
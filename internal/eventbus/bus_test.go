@@ -0,0 +1,141 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublish_ShouldDeliverEventsToTypedSubscribers(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	created := domain.NewMessageCreated(domain.NewMessage("id", "message content"), now)
+	deleted := domain.NewMessageDeleted("id", now)
+
+	var createdEvents []domain.MessageCreated
+	var deletedEvents []domain.MessageDeleted
+	bus := NewBus(Sync, nil)
+	Subscribe(bus, func(ctx context.Context, event domain.MessageCreated) error {
+		createdEvents = append(createdEvents, event)
+		return nil
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.MessageDeleted) error {
+		deletedEvents = append(deletedEvents, event)
+		return nil
+	})
+
+	err := bus.Publish(ctx, created, deleted)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.MessageCreated{created}, createdEvents)
+	assert.Equal(t, []domain.MessageDeleted{deleted}, deletedEvents)
+}
+
+func TestPublish_ShouldDeliverEveryEventToMultipleSubscribers(t *testing.T) {
+	ctx := context.Background()
+	created := domain.NewMessageCreated(domain.NewMessage("id", "message content"), time.Now())
+	deleted := domain.NewMessageDeleted("id", time.Now())
+
+	var firstEvents, secondEvents []domain.Event
+	bus := NewBus(Sync, nil)
+	bus.SubscribeAll(func(ctx context.Context, event domain.Event) error {
+		firstEvents = append(firstEvents, event)
+		return nil
+	})
+	bus.SubscribeAll(func(ctx context.Context, event domain.Event) error {
+		secondEvents = append(secondEvents, event)
+		return nil
+	})
+
+	err := bus.Publish(ctx, created, deleted)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Event{created, deleted}, firstEvents)
+	assert.Equal(t, []domain.Event{created, deleted}, secondEvents)
+}
+
+func TestPublish_ShouldReturnErrorWhenSyncSubscriberFails(t *testing.T) {
+	unexpectedError := errors.New("unexpected error")
+	delivered := false
+
+	bus := NewBus(Sync, nil)
+	Subscribe(bus, func(ctx context.Context, event domain.MessageCreated) error {
+		return unexpectedError
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.MessageCreated) error {
+		delivered = true
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), domain.NewMessageCreated(domain.Message{}, time.Now()))
+
+	assert.ErrorIs(t, err, unexpectedError)
+	assert.True(t, delivered)
+}
+
+func TestPublish_ShouldReturnErrorWhenSubscriberPanics(t *testing.T) {
+	bus := NewBus(Sync, nil)
+	Subscribe(bus, func(ctx context.Context, event domain.MessageCreated) error {
+		panic("unexpected panic")
+	})
+
+	err := bus.Publish(context.Background(), domain.NewMessageCreated(domain.Message{}, time.Now()))
+
+	assert.ErrorContains(t, err, "unexpected panic")
+}
+
+func TestPublish_ShouldDeliverAsyncEventsInOrderBeforeClosing(t *testing.T) {
+	ctx := context.Background()
+	events := []domain.Event{
+		domain.NewMessageCreated(domain.NewMessage("id1", "message content 1"), time.Now()),
+		domain.NewMessageCreated(domain.NewMessage("id2", "message content 2"), time.Now()),
+		domain.NewMessageDeleted("id1", time.Now()),
+	}
+
+	var received []domain.Event
+	bus := NewBus(Async, nil)
+	bus.SubscribeAll(func(ctx context.Context, event domain.Event) error {
+		received = append(received, event)
+		return nil
+	})
+
+	err := bus.Publish(ctx, events...)
+	bus.Close()
+
+	assert.NoError(t, err)
+	assert.Equal(t, events, received)
+}
+
+func TestPublish_ShouldReportAsyncSubscriberErrors(t *testing.T) {
+	unexpectedError := errors.New("unexpected error")
+	event := domain.NewMessageDeleted("id", time.Now())
+
+	var reportedEvent domain.Event
+	var reportedError error
+	bus := NewBus(Async, func(event domain.Event, err error) {
+		reportedEvent, reportedError = event, err
+	})
+	Subscribe(bus, func(ctx context.Context, event domain.MessageDeleted) error {
+		return unexpectedError
+	})
+
+	err := bus.Publish(context.Background(), event)
+	bus.Close()
+
+	assert.NoError(t, err)
+	assert.Equal(t, event, reportedEvent)
+	assert.ErrorIs(t, reportedError, unexpectedError)
+}
+
+func TestPublish_ShouldReturnErrorWhenBusIsClosed(t *testing.T) {
+	bus := NewBus(Async, nil)
+	bus.Close()
+
+	err := bus.Publish(context.Background(), domain.NewMessageDeleted("id", time.Now()))
+
+	assert.ErrorIs(t, err, errBusClosed)
+}
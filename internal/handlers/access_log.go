@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const redactedQueryValue = "REDACTED"
+
+// accessLog writes a line per request to output, like the logger of
+// gin.Default, but with the access_token query parameter redacted, so that
+// bearer tokens sent by browsers do not end up in the logs.
+func accessLog(output io.Writer) gin.HandlerFunc {
+	return gin.LoggerWithConfig(gin.LoggerConfig{
+		Output: output,
+		Formatter: func(param gin.LogFormatterParams) string {
+			return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v\n%s",
+				param.TimeStamp.Format(time.RFC3339),
+				param.StatusCode,
+				param.Latency,
+				param.ClientIP,
+				param.Method,
+				redactQuery(param.Path),
+				param.ErrorMessage,
+			)
+		},
+	})
+}
+
+// redactQuery replaces the value of the access_token parameters in the query
+// of path, keeping the other parameters as they were sent.
+func redactQuery(path string) string {
+	route, query, found := strings.Cut(path, "?")
+	if !found {
+		return path
+	}
+
+	parameters := strings.Split(query, "&")
+	for i, parameter := range parameters {
+		name, _, _ := strings.Cut(parameter, "=")
+		if unescaped, err := url.QueryUnescape(name); err == nil && unescaped == accessTokenQuery {
+			parameters[i] = name + "=" + redactedQueryValue
+		}
+	}
+	return route + "?" + strings.Join(parameters, "&")
+}
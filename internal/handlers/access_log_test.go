@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessLog_ShouldRedactAccessTokenFromQuery(t *testing.T) {
+	output := &bytes.Buffer{}
+	router := gin.New()
+	router.Use(accessLog(output))
+	router.GET("/messages/stream", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := httptest.NewRequest(http.MethodGet, "/messages/stream?after=1&access_token=secret-token&access%5Ftoken=other-token", nil)
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	assert.NotContains(t, output.String(), "secret-token")
+	assert.NotContains(t, output.String(), "other-token")
+	assert.Contains(t, output.String(), "/messages/stream?after=1&access_token=REDACTED&access%5Ftoken=REDACTED")
+	assert.Contains(t, output.String(), "GET")
+}
+
+func TestRedactQuery_ShouldKeepPathWithoutQuery(t *testing.T) {
+	assert.Equal(t, "/messages", redactQuery("/messages"))
+	assert.Equal(t, "/messages?limit=10", redactQuery("/messages?limit=10"))
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+type apiKeyHandler struct {
+	service ports.APIKeyUseCase
+}
+
+func NewAPIKeyHandler(service ports.APIKeyUseCase) apiKeyHandler {
+	return apiKeyHandler{
+		service: service,
+	}
+}
+
+func (h apiKeyHandler) createAPIKey(c *gin.Context) {
+	var apiKeyReqDto dto.CreateAPIKeyRequest
+	err := c.BindJSON(&apiKeyReqDto)
+	if err != nil {
+		c.JSON(400, gin.H{"error": errors.Join(apperrors.InvalidInput, err).Error()})
+		return
+	}
+
+	var expiresAt time.Time
+	if apiKeyReqDto.ExpiresAt != nil {
+		expiresAt = *apiKeyReqDto.ExpiresAt
+	}
+	key, secret, err := h.service.Create(c.Request.Context(), apiKeyReqDto.Name, apiKeyReqDto.Scopes, expiresAt)
+	if err != nil {
+		respondAPIKeyError(c, err)
+		return
+	}
+
+	c.JSON(201, dto.BuildResponseCreateAPIKey(key, secret))
+}
+
+func (h apiKeyHandler) getAPIKeys(c *gin.Context) {
+	keys, err := h.service.GetAll(c.Request.Context())
+	if err != nil {
+		respondAPIKeyError(c, err)
+		return
+	}
+
+	c.JSON(200, dto.BuildResponseGetAPIKeys(keys))
+}
+
+func (h apiKeyHandler) revokeAPIKey(c *gin.Context) {
+	err := h.service.Revoke(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondAPIKeyError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondAPIKeyError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, apperrors.InvalidInput):
+		c.JSON(400, gin.H{"error": err.Error()})
+	case errors.Is(err, apperrors.Forbidden):
+		c.JSON(403, gin.H{"error": err.Error()})
+	case errors.Is(err, apperrors.NotFound):
+		c.JSON(404, gin.H{"error": err.Error()})
+	default:
+		c.JSON(500, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/auth"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	apikeyusecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/apikey"
+	"github.com/hiago-balbino/hex-architecture-template/internal/repositories/memory"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+const testAdminAPIKey = "admin-0123456789abcdef0123456789abcdef"
+
+func TestCreateAPIKey_ShouldReturnErrorWhenBindingRequestParams(t *testing.T) {
+	server := httptest.NewServer(setupAPIKeyHandler(nil))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/admin/api-keys").
+		WithJSON(`{`).
+		Expect().Status(http.StatusBadRequest).
+		Body().Contains(apperrors.InvalidInput.Error())
+}
+
+func TestCreateAPIKey_ShouldReturnKeyOnCreation(t *testing.T) {
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	body := dto.CreateAPIKeyRequest{Name: "ci", Scopes: []string{"messages:read"}, ExpiresAt: &expiresAt}
+	key := domain.NewAPIKey("key-id", body.Name, body.Scopes, []byte("salt"), []byte("hash"), time.Now().UTC(), expiresAt)
+
+	serviceMock := new(mocks.APIKeyUseCaseMock)
+	serviceMock.On("Create", mock.Anything, body.Name, body.Scopes, expiresAt).Return(key, "hexapi_key-id_secret", nil)
+
+	server := httptest.NewServer(setupAPIKeyHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	response := e.POST("/admin/api-keys").
+		WithJSON(body).
+		Expect().Status(http.StatusCreated).
+		JSON().Object()
+
+	response.Value("id").IsEqual(key.ID)
+	response.Value("key").IsEqual("hexapi_key-id_secret")
+	response.Value("expires_at").IsEqual("2030-01-01T00:00:00Z")
+	response.NotContainsKey("salt")
+	response.NotContainsKey("hash")
+	response.NotContainsKey("revoked_at")
+}
+
+func TestGetAPIKeys_ShouldReturnForbiddenWithoutAdminScope(t *testing.T) {
+	serviceMock := new(mocks.APIKeyUseCaseMock)
+	serviceMock.On("GetAll", mock.Anything).Return([]domain.APIKey(nil), apperrors.Forbidden)
+
+	server := httptest.NewServer(setupAPIKeyHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/admin/api-keys").
+		Expect().Status(http.StatusForbidden).
+		Body().Contains(apperrors.Forbidden.Error())
+}
+
+func TestRevokeAPIKey_ShouldReturnErrorWhenKeyNotFound(t *testing.T) {
+	serviceMock := new(mocks.APIKeyUseCaseMock)
+	serviceMock.On("Revoke", mock.Anything, "key-id").Return(apperrors.NotFound)
+
+	server := httptest.NewServer(setupAPIKeyHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.DELETE("/admin/api-keys/key-id").
+		Expect().Status(http.StatusNotFound)
+}
+
+func TestRevokeAPIKey_ShouldReturnErrorWhenServiceFails(t *testing.T) {
+	unexpectedError := errors.New("unexpected error")
+
+	serviceMock := new(mocks.APIKeyUseCaseMock)
+	serviceMock.On("Revoke", mock.Anything, "key-id").Return(unexpectedError)
+
+	server := httptest.NewServer(setupAPIKeyHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.DELETE("/admin/api-keys/key-id").
+		Expect().Status(http.StatusInternalServerError).
+		Body().Contains(unexpectedError.Error())
+}
+
+func TestAPIKeys_ShouldAuthenticateUntilRevoked(t *testing.T) {
+	server := httptest.NewServer(setupAPIKeyAuthentication())
+	t.Cleanup(server.Close)
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/admin/api-keys").
+		Expect().Status(http.StatusUnauthorized).
+		Header("WWW-Authenticate").IsEqual(`APIKey realm="hexapi"`)
+
+	admin := e.POST("/admin/api-keys").WithHeader("X-API-Key", testAdminAPIKey).
+		WithJSON(dto.CreateAPIKeyRequest{Name: "operator", Scopes: []string{domain.ScopeAdmin}}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object()
+	adminKey := admin.Value("key").String().Raw()
+	reader := e.POST("/admin/api-keys").WithHeader("X-API-Key", adminKey).
+		WithJSON(dto.CreateAPIKeyRequest{Name: "reader", Scopes: []string{"messages:read"}}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object()
+	readerKey := reader.Value("key").String().Raw()
+
+	e.GET("/admin/api-keys").WithHeader("X-API-Key", readerKey).
+		Expect().Status(http.StatusForbidden)
+	keys := e.GET("/admin/api-keys").WithHeader("X-API-Key", adminKey).
+		Expect().Status(http.StatusOK).
+		JSON().Array()
+	keys.Length().IsEqual(2)
+	for _, key := range keys.Iter() {
+		key.Object().ContainsKey("last_used_at")
+	}
+
+	e.DELETE("/admin/api-keys/"+reader.Value("id").String().Raw()).WithHeader("X-API-Key", adminKey).
+		Expect().Status(http.StatusNoContent)
+	e.GET("/admin/api-keys").WithHeader("X-API-Key", readerKey).
+		Expect().Status(http.StatusUnauthorized).
+		Body().Contains("api key is revoked")
+}
+
+func setupAPIKeyHandler(service ports.APIKeyUseCase) *gin.Engine {
+	server := Server{apikeyhdl: NewAPIKeyHandler(service)}
+	return server.setupRoutes()
+}
+
+func setupAPIKeyAuthentication() *gin.Engine {
+	service := apikeyusecases.NewAPIKeyService(identifier.NewUUIDGenerator(), clock.NewSystemClock(), memory.NewAPIKeyStorage(), testAdminAPIKey)
+	server := Server{
+		apikeyhdl:         NewAPIKeyHandler(service),
+		authentication:    NewAuthMiddleware(auth.Verifier{}, service),
+		validateResponses: true,
+	}
+	return server.setupRoutes()
+}
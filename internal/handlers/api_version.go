@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiDeprecation announces the retirement of an API version on each of its
+// responses. The zero value leaves the version current.
+type apiDeprecation struct {
+	deprecatedAt time.Time
+	sunsetAt     time.Time
+	successor    string
+}
+
+func NewAPIDeprecation(deprecatedAt time.Time, sunsetAt time.Time, successor string) apiDeprecation {
+	return apiDeprecation{
+		deprecatedAt: deprecatedAt,
+		sunsetAt:     sunsetAt,
+		successor:    successor,
+	}
+}
+
+func (d apiDeprecation) deprecated() bool {
+	return !d.deprecatedAt.IsZero()
+}
+
+// handle sets the Deprecation header of RFC 9745, the Sunset header of
+// RFC 8594 and a link to the version replacing this one.
+func (d apiDeprecation) handle(c *gin.Context) {
+	if d.deprecated() {
+		c.Header("Deprecation", "@"+strconv.FormatInt(d.deprecatedAt.Unix(), 10))
+		if d.successor != "" {
+			c.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", d.successor))
+		}
+	}
+	if !d.sunsetAt.IsZero() {
+		c.Header("Sunset", d.sunsetAt.UTC().Format(http.TimeFormat))
+	}
+	c.Next()
+}
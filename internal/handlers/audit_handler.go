@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+type auditHandler struct {
+	service ports.AuditUseCase
+}
+
+func NewAuditHandler(service ports.AuditUseCase) auditHandler {
+	return auditHandler{
+		service: service,
+	}
+}
+
+func (h auditHandler) getAuditEntries(c *gin.Context) {
+	entries := []dto.AuditEntryResponse{}
+	err := h.service.ForEach(c.Request.Context(), c.Query("message_id"), func(entry domain.AuditEntry) error {
+		entries = append(entries, dto.BuildResponseAuditEntry(entry))
+		return nil
+	})
+	if err != nil {
+		respondAuditError(c, err)
+		return
+	}
+
+	c.JSON(200, entries)
+}
+
+// exportAuditEntries streams the whole audit log of the tenant as NDJSON,
+// which hexctl audit verify checks. Errors after the first entry can only cut
+// the stream short, which the missing X-Export-Count trailer tells.
+func (h auditHandler) exportAuditEntries(c *gin.Context) {
+	exported := 0
+	encoder := json.NewEncoder(c.Writer)
+	err := h.service.ForEach(c.Request.Context(), "", func(entry domain.AuditEntry) error {
+		if exported == 0 {
+			startExport(c)
+		}
+		exported++
+		return encoder.Encode(dto.BuildResponseAuditEntry(entry))
+	})
+	if err != nil {
+		if exported == 0 {
+			respondAuditError(c, err)
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	if exported == 0 {
+		startExport(c)
+	}
+	c.Writer.Header().Set(exportCountTrailer, strconv.Itoa(exported))
+}
+
+func respondAuditError(c *gin.Context, err error) {
+	if errors.Is(err, apperrors.Forbidden) {
+		c.JSON(403, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(500, gin.H{"error": err.Error()})
+}
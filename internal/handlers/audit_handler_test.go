@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/auth"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	auditusecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/audit"
+	usecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/message"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/internal/repositories/memory"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudit_ShouldRecordMessageChangesWithPrincipalAndRequestID(t *testing.T) {
+	server := httptest.NewServer(setupAuditedHandler(t))
+	t.Cleanup(server.Close)
+	writer := "Bearer " + signToken(t, map[string]any{"sub": "user-1", "scope": "writer", "exp": time.Now().Add(time.Hour).Unix()})
+	admin := "Bearer " + signToken(t, map[string]any{"sub": "user-2", "scope": domain.ScopeAdmin, "exp": time.Now().Add(time.Hour).Unix()})
+
+	e := httpexpect.Default(t, server.URL)
+	id := e.POST("/v2/messages").WithHeader("Authorization", writer).WithHeader(requestIDHeader, "request-1").
+		WithJSON(map[string]string{"content": "message content"}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object().Value("id").String().Raw()
+	e.DELETE("/v2/messages/"+id).WithHeader("Authorization", writer).WithHeader(requestIDHeader, "request-2").
+		Expect().Status(http.StatusNoContent)
+	e.POST("/v2/messages").WithHeader("Authorization", writer).
+		WithJSON(map[string]string{"content": "other content"}).
+		Expect().Status(http.StatusCreated)
+
+	entries := e.GET("/audit").WithHeader("Authorization", admin).WithQuery("message_id", id).
+		Expect().Status(http.StatusOK).
+		JSON().Array()
+	entries.Length().IsEqual(2)
+	created := entries.Value(0).Object()
+	created.Value("sequence").IsEqual(1)
+	created.Value("action").IsEqual("create")
+	created.Value("principal").IsEqual("user-1")
+	created.Value("request_id").IsEqual("request-1")
+	created.Value("outcome").IsEqual("success")
+	created.Value("after").Object().Value("content").IsEqual("message content")
+	deleted := entries.Value(1).Object()
+	deleted.Value("action").IsEqual("delete")
+	deleted.Value("request_id").IsEqual("request-2")
+	deleted.Value("before").Object().Value("content").IsEqual("message content")
+	deleted.NotContainsKey("after")
+}
+
+func TestAudit_ShouldOnlyShowLogToAdmins(t *testing.T) {
+	server := httptest.NewServer(setupAuditedHandler(t))
+	t.Cleanup(server.Close)
+	writer := "Bearer " + signToken(t, map[string]any{"sub": "user-1", "scope": "writer", "exp": time.Now().Add(time.Hour).Unix()})
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/audit").WithHeader("Authorization", writer).
+		Expect().Status(http.StatusForbidden)
+	e.GET("/audit/export").WithHeader("Authorization", writer).
+		Expect().Status(http.StatusForbidden)
+}
+
+func TestAudit_ShouldExportChainThatVerifies(t *testing.T) {
+	server := httptest.NewServer(setupAuditedHandler(t))
+	t.Cleanup(server.Close)
+	admin := "Bearer " + signToken(t, map[string]any{"sub": "user-2", "scope": domain.ScopeAdmin, "exp": time.Now().Add(time.Hour).Unix()})
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/v2/messages:batch").WithHeader("Authorization", admin).
+		WithJSON(map[string]any{"messages": []map[string]string{{"content": "first content"}, {"content": "second content"}}}).
+		Expect().Status(http.StatusCreated)
+	e.DELETE("/v2/messages/6ba7b810-9dad-11d1-80b4-00c04fd430c8").WithHeader("Authorization", admin).
+		Expect().Status(http.StatusNotFound)
+
+	response := e.GET("/audit/export").WithHeader("Authorization", admin).
+		Expect().Status(http.StatusOK)
+	response.Header("Content-Type").HasPrefix(mediaTypeNDJSON)
+
+	var previous domain.AuditEntry
+	scanner := bufio.NewScanner(strings.NewReader(response.Body().Raw()))
+	for scanner.Scan() {
+		var entry domain.AuditEntry
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		assert.NoError(t, entry.Verify(previous))
+		previous = entry
+	}
+	assert.Equal(t, uint64(3), previous.Sequence)
+	assert.Equal(t, domain.ActionDelete, previous.Action)
+}
+
+func setupAuditedHandler(t *testing.T) *gin.Engine {
+	key, err := auth.NewHMACKey("", testSecret)
+	assert.NoError(t, err)
+	messages := memory.NewMessageStorage()
+	audit := memory.NewAuditStorage()
+	service := usecases.NewMessageService(identifier.NewUUIDGenerator(), clock.NewSystemClock(), messages, memory.NewUnitOfWork(messages, memory.NewOutboxStorage(), audit), policy.NewRolePolicy())
+	server := Server{
+		messagehdl:        NewMessageHandler(auditusecases.NewAuditedMessageService(service, audit, clock.NewSystemClock())),
+		audithdl:          NewAuditHandler(auditusecases.NewAuditService(audit, policy.NewRolePolicy())),
+		authentication:    NewAuthMiddleware(auth.NewVerifier([]auth.Key{key}, "", "", time.Minute, clock.NewSystemClock()), nil),
+		requestID:         NewRequestIDMiddleware(identifier.NewUUIDGenerator()),
+		validateResponses: true,
+	}
+	return server.setupRoutes()
+}
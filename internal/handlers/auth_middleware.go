@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/auth"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/openapi"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+const (
+	authRealm        = "hexapi"
+	accessTokenQuery = "access_token"
+	apiKeyHeader     = "X-API-Key"
+)
+
+var (
+	errMissingCredentials = errors.New("missing credentials")
+
+	bearerAuth = openapi.SecurityScheme{
+		Name:         "bearerAuth",
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+	}
+	apiKeyAuth = openapi.SecurityScheme{
+		Name:          "apiKeyAuth",
+		Type:          "apiKey",
+		ParameterName: apiKeyHeader,
+		In:            openapi.InHeader,
+	}
+)
+
+// authMiddleware authenticates requests with a bearer JWT or, when apiKeys is
+// set, an API key. The zero value, whose verifier has no keys, lets every
+// request through.
+type authMiddleware struct {
+	verifier auth.Verifier
+	apiKeys  ports.APIKeyUseCase
+}
+
+func NewAuthMiddleware(verifier auth.Verifier, apiKeys ports.APIKeyUseCase) authMiddleware {
+	return authMiddleware{verifier: verifier, apiKeys: apiKeys}
+}
+
+// handle puts the principal of the credentials in the request context.
+// queryToken also accepts the token in the access_token query parameter of
+// RFC 6750, for browsers, which cannot set headers on EventSource and
+// WebSocket requests.
+func (m authMiddleware) handle(queryToken bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !m.verifier.Enabled() && m.apiKeys == nil {
+			c.Next()
+			return
+		}
+
+		if key := c.GetHeader(apiKeyHeader); key != "" && m.apiKeys != nil {
+			principal, err := m.apiKeys.Authenticate(c.Request.Context(), key)
+			if errors.Is(err, apperrors.Unauthorized) {
+				c.Header("WWW-Authenticate", fmt.Sprintf("APIKey realm=%q, error=\"invalid_key\"", authRealm))
+				c.AbortWithStatusJSON(401, dto.ErrorResponse{Error: err.Error()})
+				return
+			}
+			if err != nil {
+				c.AbortWithStatusJSON(500, dto.ErrorResponse{Error: err.Error()})
+				return
+			}
+			m.authenticated(c, principal)
+			return
+		}
+
+		token, ok := bearerToken(c, queryToken)
+		if !ok || !m.verifier.Enabled() {
+			// RFC 6750 leaves the error out when the request has no credentials.
+			c.Header("WWW-Authenticate", m.challenge())
+			c.AbortWithStatusJSON(401, dto.ErrorResponse{Error: errors.Join(apperrors.Unauthorized, errMissingCredentials).Error()})
+			return
+		}
+
+		principal, err := m.verifier.Verify(token)
+		if err != nil {
+			c.Header("WWW-Authenticate", fmt.Sprintf("Bearer realm=%q, error=\"invalid_token\", error_description=%q", authRealm, err.Error()))
+			c.AbortWithStatusJSON(401, dto.ErrorResponse{Error: errors.Join(apperrors.Unauthorized, err).Error()})
+			return
+		}
+		m.authenticated(c, principal)
+	}
+}
+
+func (m authMiddleware) authenticated(c *gin.Context, principal domain.Principal) {
+	c.Request = c.Request.WithContext(domain.ContextWithPrincipal(c.Request.Context(), principal))
+	c.Next()
+}
+
+// challenge lists a challenge per accepted kind of credentials.
+func (m authMiddleware) challenge() string {
+	var challenges []string
+	if m.verifier.Enabled() {
+		challenges = append(challenges, fmt.Sprintf("Bearer realm=%q", authRealm))
+	}
+	if m.apiKeys != nil {
+		challenges = append(challenges, fmt.Sprintf("APIKey realm=%q", authRealm))
+	}
+	return strings.Join(challenges, ", ")
+}
+
+func bearerToken(c *gin.Context, queryToken bool) (string, bool) {
+	scheme, token, found := strings.Cut(c.GetHeader("Authorization"), " ")
+	if found && strings.EqualFold(scheme, "Bearer") {
+		token = strings.TrimSpace(token)
+		return token, token != ""
+	}
+	if queryToken {
+		token := c.Query(accessTokenQuery)
+		return token, token != ""
+	}
+	return "", false
+}
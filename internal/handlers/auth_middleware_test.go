@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/auth"
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	usecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/message"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/internal/repositories/memory"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func TestAuthentication_ShouldChallengeRequestsWithoutToken(t *testing.T) {
+	server := httptest.NewServer(setupAuthenticatedHandler(t, nil))
+	t.Cleanup(server.Close)
+
+	e := httpexpect.Default(t, server.URL)
+	response := e.DELETE("/v2/messages/6ba7b810-9dad-11d1-80b4-00c04fd430c8").
+		Expect().Status(http.StatusUnauthorized)
+	response.Header("WWW-Authenticate").IsEqual(`Bearer realm="hexapi"`)
+	response.JSON().Object().Value("error").String().HasPrefix(apperrors.Unauthorized.Error())
+}
+
+func TestAuthentication_ShouldRejectInvalidToken(t *testing.T) {
+	server := httptest.NewServer(setupAuthenticatedHandler(t, nil))
+	t.Cleanup(server.Close)
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/v2/messages").
+		WithHeader("Authorization", "Bearer "+signToken(t, map[string]any{"sub": "user-1", "exp": time.Now().Add(-time.Hour).Unix()})).
+		Expect().Status(http.StatusUnauthorized).
+		Header("WWW-Authenticate").IsEqual(`Bearer realm="hexapi", error="invalid_token", error_description="token is expired"`)
+}
+
+func TestAuthentication_ShouldPassPrincipalToService(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.MatchedBy(func(ctx context.Context) bool {
+		principal, ok := domain.PrincipalFromContext(ctx)
+		return ok && principal.Subject == "user-1" && assert.ObjectsAreEqual([]string{"messages:write"}, principal.Scopes)
+	}), "message content").Return(domain.NewMessage("6ba7b810-9dad-11d1-80b4-00c04fd430c8", "message content"), nil)
+	server := httptest.NewServer(setupAuthenticatedHandler(t, serviceMock))
+	t.Cleanup(server.Close)
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/v2/messages").
+		WithHeader("Authorization", "Bearer "+signToken(t, map[string]any{"sub": "user-1", "scope": "messages:write", "exp": time.Now().Add(time.Hour).Unix()})).
+		WithJSON(map[string]string{"content": "message content"}).
+		Expect().Status(http.StatusCreated)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestAuthentication_ShouldOnlyAcceptQueryTokenOnStreamingRoutes(t *testing.T) {
+	token := signToken(t, map[string]any{"sub": "user-1", "exp": time.Now().Add(time.Hour).Unix()})
+	server := httptest.NewServer(setupAuthenticatedHandler(t, nil))
+	t.Cleanup(server.Close)
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/v2/messages").WithQuery("access_token", token).
+		Expect().Status(http.StatusUnauthorized)
+	// Getting past authentication, the invalid header is rejected by the stream.
+	e.GET("/messages/stream").WithQuery("access_token", token).WithHeader("Last-Event-ID", "abc").
+		Expect().Status(http.StatusBadRequest)
+}
+
+func TestAuthentication_ShouldLeavePublicRoutesOpen(t *testing.T) {
+	server := httptest.NewServer(setupAuthenticatedHandler(t, nil))
+	t.Cleanup(server.Close)
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/openapi.json").Expect().Status(http.StatusOK)
+}
+
+func TestAuthorization_ShouldRestrictDeletesToOwner(t *testing.T) {
+	server := httptest.NewServer(setupAuthorizedHandler(t))
+	t.Cleanup(server.Close)
+	owner := "Bearer " + signToken(t, map[string]any{"sub": "user-1", "scope": "writer", "exp": time.Now().Add(time.Hour).Unix()})
+	other := "Bearer " + signToken(t, map[string]any{"sub": "user-2", "scope": "writer", "exp": time.Now().Add(time.Hour).Unix()})
+	admin := "Bearer " + signToken(t, map[string]any{"sub": "user-3", "scope": domain.ScopeAdmin, "exp": time.Now().Add(time.Hour).Unix()})
+	reader := "Bearer " + signToken(t, map[string]any{"sub": "user-1", "scope": "reader", "exp": time.Now().Add(time.Hour).Unix()})
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/v2/messages").WithHeader("Authorization", reader).
+		WithJSON(map[string]string{"content": "message content"}).
+		Expect().Status(http.StatusForbidden)
+	created := e.POST("/v2/messages").WithHeader("Authorization", owner).
+		WithJSON(map[string]string{"content": "message content"}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object()
+	created.Value("owner").IsEqual("user-1")
+	id := created.Value("id").String().Raw()
+
+	e.GET("/v2/messages/"+id).WithHeader("Authorization", reader).
+		Expect().Status(http.StatusOK)
+	e.GET("/v2/messages/"+id).WithHeader("Authorization", other).
+		Expect().Status(http.StatusOK)
+	e.GET("/v2/messages").WithHeader("Authorization", other).
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("messages").Array().Length().IsEqual(1)
+	e.DELETE("/v2/messages/"+id).WithHeader("Authorization", other).
+		Expect().Status(http.StatusForbidden)
+	e.DELETE("/v2/messages/"+id).WithHeader("Authorization", admin).
+		Expect().Status(http.StatusNoContent)
+}
+
+func setupAuthenticatedHandler(t *testing.T, service *mocks.MessageUseCaseMock) *gin.Engine {
+	key, err := auth.NewHMACKey("", testSecret)
+	assert.NoError(t, err)
+	server := Server{
+		messagehdl:        NewMessageHandler(service),
+		streamhdl:         NewStreamHandler(changefeed.NewFeed(10, 10, policy.NewRolePolicy()), time.Minute),
+		authentication:    NewAuthMiddleware(auth.NewVerifier([]auth.Key{key}, "", "", time.Minute, clock.NewSystemClock()), nil),
+		validateResponses: true,
+	}
+	return server.setupRoutes()
+}
+
+func setupAuthorizedHandler(t *testing.T) *gin.Engine {
+	key, err := auth.NewHMACKey("", testSecret)
+	assert.NoError(t, err)
+	messages := memory.NewMessageStorage()
+	service := usecases.NewMessageService(identifier.NewUUIDGenerator(), clock.NewSystemClock(), messages, memory.NewUnitOfWork(messages, memory.NewOutboxStorage(), memory.NewAuditStorage()), policy.NewRolePolicy())
+	server := Server{
+		messagehdl:        NewMessageHandler(service),
+		authentication:    NewAuthMiddleware(auth.NewVerifier([]auth.Key{key}, "", "", time.Minute, clock.NewSystemClock()), nil),
+		validateResponses: true,
+	}
+	return server.setupRoutes()
+}
+
+func signToken(t *testing.T, claims map[string]any) string {
+	header, err := json.Marshal(map[string]any{"alg": auth.HS256})
+	assert.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	assert.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, testSecret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
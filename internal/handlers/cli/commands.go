@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// importBatchSize matches the largest batch accepted by messageService.
+const importBatchSize = 1000
+
+var (
+	errBatchFailures = errors.New("some messages failed")
+	errInvalidMode   = fmt.Errorf("mode must be %s or %s", domain.BatchModeAtomic, domain.BatchModeBestEffort)
+	errInvalidFormat = fmt.Errorf("format must be %s or %s", outputJSON, outputYAML)
+	errAuditTenants  = errors.New("entries of more than one tenant")
+)
+
+func (a *app) newCreateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "create <content>",
+		Short: "Create a message",
+		Args:  cobra.ExactArgs(1),
+		RunE: a.run(func(ctx context.Context, cmd *cobra.Command, service ports.MessageUseCase, args []string) error {
+			message, err := service.Save(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			return printMessages(cmd.OutOrStdout(), a.output, dto.BuildResponseGetMessages([]domain.Message{message}))
+		}),
+	}
+}
+
+func (a *app) newGetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "get <id>",
+		Short:             "Show a message",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: a.completeMessageIDs,
+		RunE: a.run(func(ctx context.Context, cmd *cobra.Command, service ports.MessageUseCase, args []string) error {
+			message, err := service.GetByID(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			return printMessages(cmd.OutOrStdout(), a.output, dto.BuildResponseGetMessages([]domain.Message{message}))
+		}),
+	}
+}
+
+func (a *app) newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List every message",
+		Args:  cobra.NoArgs,
+		RunE: a.run(func(ctx context.Context, cmd *cobra.Command, service ports.MessageUseCase, args []string) error {
+			messages, err := service.GetAll(ctx)
+			if err != nil {
+				return err
+			}
+			sortMessages(messages)
+			return printMessages(cmd.OutOrStdout(), a.output, dto.BuildResponseGetMessages(messages))
+		}),
+	}
+}
+
+func (a *app) newDeleteCommand() *cobra.Command {
+	var mode string
+
+	cmd := &cobra.Command{
+		Use:               "delete <id>...",
+		Short:             "Delete messages",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: a.completeMessageIDs,
+		RunE: a.run(func(ctx context.Context, cmd *cobra.Command, service ports.MessageUseCase, args []string) error {
+			batchMode := domain.BatchMode(mode)
+			if !batchMode.IsValid() {
+				return errInvalidMode
+			}
+
+			results, err := service.DeleteBatch(ctx, args, batchMode)
+			return a.printBatch(cmd, batchMode, results, err, dto.BatchItemStatusDeleted)
+		}),
+	}
+	addModeFlag(cmd, &mode)
+	return cmd
+}
+
+func (a *app) newImportCommand() *cobra.Command {
+	var mode string
+
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Create the messages of a JSON or YAML list, as written by export",
+		Long:  "Create the messages of a JSON or YAML list, read from file or from stdin when it is omitted or -. Only the content of each message is used and new ids are generated. Messages are created in batches of up to 1000, so the mode applies to each batch.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: a.run(func(ctx context.Context, cmd *cobra.Command, service ports.MessageUseCase, args []string) error {
+			batchMode := domain.BatchMode(mode)
+			if !batchMode.IsValid() {
+				return errInvalidMode
+			}
+
+			contents, err := readContents(cmd.InOrStdin(), args)
+			if err != nil {
+				return err
+			}
+
+			var results []domain.BatchResult
+			for start := 0; start < len(contents) && err == nil; start += importBatchSize {
+				var batch []domain.BatchResult
+				batch, err = service.SaveBatch(ctx, contents[start:min(start+importBatchSize, len(contents))], batchMode)
+				results = append(results, batch...)
+			}
+			return a.printBatch(cmd, batchMode, results, err, dto.BatchItemStatusCreated)
+		}),
+	}
+	addModeFlag(cmd, &mode)
+	return cmd
+}
+
+func (a *app) newExportCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Write every message as a JSON or YAML list",
+		Long:  "Write every message as a JSON or YAML list to file, or to stdout when it is omitted or -.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: a.run(func(ctx context.Context, cmd *cobra.Command, service ports.MessageUseCase, args []string) (err error) {
+			if format != outputJSON && format != outputYAML {
+				return errInvalidFormat
+			}
+
+			messages, err := service.GetAll(ctx)
+			if err != nil {
+				return err
+			}
+			sortMessages(messages)
+
+			w := cmd.OutOrStdout()
+			if len(args) == 1 && args[0] != "-" {
+				file, err := os.Create(args[0])
+				if err != nil {
+					return err
+				}
+				defer func() {
+					if closeErr := file.Close(); err == nil {
+						err = closeErr
+					}
+				}()
+				w = file
+			}
+			return encode(w, format, dto.BuildResponseGetMessages(messages))
+		}),
+	}
+	cmd.Flags().StringVar(&format, "format", outputJSON, "file format: json or yaml")
+	_ = cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{outputJSON, outputYAML}, cobra.ShellCompDirectiveNoFileComp))
+	return cmd
+}
+
+func (a *app) newAuditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Work with the audit log",
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "verify [file]",
+		Short: "Check the hash chain of an audit log export",
+		Long:  "Check the hash chain of the NDJSON written by GET /audit/export, read from file or from stdin when it is omitted or -. The export must hold the whole log of a single tenant, from its first entry. Entries removed from the end of the log cannot be told apart from a shorter log.",
+		Args:  cobra.MaximumNArgs(1),
+		// The export is checked offline, without connecting to the storage or the API.
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verified, err := verifyAuditLog(cmd.InOrStdin(), args)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%d entries verified\n", verified)
+			return nil
+		},
+	})
+	return cmd
+}
+
+// printBatch prints the results the use case returned, even for an aborted
+// atomic batch, and fails the command when any item failed.
+func (a *app) printBatch(cmd *cobra.Command, mode domain.BatchMode, results []domain.BatchResult, err error, succeededStatus string) error {
+	if results == nil && err != nil {
+		return err
+	}
+	if printErr := printBatch(cmd.OutOrStdout(), a.output, dto.BuildResponseBatchMessages(mode, results, succeededStatus)); printErr != nil {
+		return printErr
+	}
+	if err != nil {
+		return err
+	}
+	if domain.HasBatchFailures(results) {
+		return errBatchFailures
+	}
+	return nil
+}
+
+func addModeFlag(cmd *cobra.Command, mode *string) {
+	cmd.Flags().StringVar(mode, "mode", string(domain.BatchModeAtomic), "batch mode: atomic or best_effort")
+	_ = cmd.RegisterFlagCompletionFunc("mode", cobra.FixedCompletions([]string{string(domain.BatchModeAtomic), string(domain.BatchModeBestEffort)}, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// readContents accepts JSON as well, since it is valid YAML.
+func readContents(stdin io.Reader, args []string) ([]string, error) {
+	r := stdin
+	if len(args) == 1 && args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	var messages []dto.CreateMessageRequest
+	if err := yaml.NewDecoder(r).Decode(&messages); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("decode messages: %w", err)
+	}
+
+	contents := make([]string, len(messages))
+	for i, message := range messages {
+		contents[i] = message.Content
+	}
+	return contents, nil
+}
+
+func sortMessages(messages []domain.Message) {
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+}
+
+// verifyAuditLog checks each entry against the one before it, starting from
+// the zero entry, and returns how many entries were verified.
+func verifyAuditLog(stdin io.Reader, args []string) (int, error) {
+	r := stdin
+	if len(args) == 1 && args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return 0, err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	var previous domain.AuditEntry
+	verified := 0
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry domain.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return verified, fmt.Errorf("line %d: decode entry: %w", line, err)
+		}
+		if verified > 0 && entry.Tenant != previous.Tenant {
+			return verified, fmt.Errorf("line %d: %w", line, errAuditTenants)
+		}
+		if err := entry.Verify(previous); err != nil {
+			return verified, fmt.Errorf("line %d: %w", line, err)
+		}
+		previous = entry
+		verified++
+	}
+	return verified, scanner.Err()
+}
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreate_ShouldPrintCreatedMessageAsTable(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, "message content").Return(domain.NewMessage("message-id", "message content"), nil)
+
+	output, err := execute(t, serviceMock, "", "create", "message content")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ID          CONTENT\nmessage-id  message content\n", output)
+}
+
+func TestGet_ShouldPrintMessageAsYAML(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.Anything, "message-id").Return(domain.NewMessage("message-id", "123"), nil)
+
+	output, err := execute(t, serviceMock, "", "get", "message-id", "-o", "yaml")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "- id: message-id\n  content: \"123\"\n", output)
+}
+
+func TestGet_ShouldReturnErrorWhenMessageDoesNotExist(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.Anything, "message-id").Return(domain.Message{}, apperrors.NotFound)
+
+	_, err := execute(t, serviceMock, "", "get", "message-id")
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestList_ShouldPrintMessagesSortedByIDAsJSON(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetAll", mock.Anything).Return([]domain.Message{
+		domain.NewMessage("id2", "second content"),
+		domain.NewMessage("id1", "first content"),
+	}, nil)
+
+	output, err := execute(t, serviceMock, "", "list", "-o", "json")
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"id": "id1", "content": "first content"}, {"id": "id2", "content": "second content"}]`, output)
+}
+
+func TestList_ShouldReturnErrorWhenOutputIsInvalid(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+
+	_, err := execute(t, serviceMock, "", "list", "-o", "xml")
+
+	assert.ErrorIs(t, err, errInvalidOutput)
+	serviceMock.AssertNotCalled(t, "GetAll", mock.Anything)
+}
+
+func TestDelete_ShouldPrintResultsAndFailWhenAnyItemFails(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("DeleteBatch", mock.Anything, []string{"id1", "id2"}, domain.BatchModeBestEffort).Return([]domain.BatchResult{
+		domain.NewBatchResult("id1", nil),
+		domain.NewBatchResult("id2", errors.Join(apperrors.NotFound, errors.New("message id not found"))),
+	}, nil)
+
+	output, err := execute(t, serviceMock, "", "delete", "id1", "id2", "--mode", "best_effort")
+
+	assert.ErrorIs(t, err, errBatchFailures)
+	assert.Contains(t, output, "0      id1  deleted")
+	assert.Contains(t, output, "1      id2  failed   not_found: message id not found")
+}
+
+func TestDelete_ShouldReturnErrorWhenModeIsInvalid(t *testing.T) {
+	_, err := execute(t, new(mocks.MessageUseCaseMock), "", "delete", "id1", "--mode", "eventually")
+
+	assert.ErrorIs(t, err, errInvalidMode)
+}
+
+func TestImport_ShouldCreateContentsReadFromStdin(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("SaveBatch", mock.Anything, []string{"first content", "second content"}, domain.BatchModeAtomic).Return([]domain.BatchResult{
+		domain.NewBatchResult("id1", nil),
+		domain.NewBatchResult("id2", nil),
+	}, nil)
+
+	stdin := `[{"id": "old-id", "content": "first content"}, {"content": "second content"}]`
+	output, err := execute(t, serviceMock, stdin, "import", "-o", "json")
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"mode": "atomic", "results": [
+		{"index": 0, "id": "id1", "status": "created"},
+		{"index": 1, "id": "id2", "status": "created"}
+	]}`, output)
+}
+
+func TestImport_ShouldPrintAbortedResultsWhenAtomicBatchFails(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("SaveBatch", mock.Anything, []string{"first content", "second content"}, domain.BatchModeAtomic).Return([]domain.BatchResult{
+		domain.NewBatchResult("id1", apperrors.Aborted),
+		domain.NewBatchResult("id2", errors.New("unexpected error")),
+	}, apperrors.UnprocessableEntity)
+
+	path := filepath.Join(t.TempDir(), "messages.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("- content: first content\n- content: second content\n"), 0o600))
+
+	output, err := execute(t, serviceMock, "", "import", path)
+
+	assert.ErrorIs(t, err, apperrors.UnprocessableEntity)
+	assert.Contains(t, output, "aborted")
+	assert.Contains(t, output, "unexpected error")
+}
+
+func TestExport_ShouldWriteMessagesToFile(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetAll", mock.Anything).Return([]domain.Message{domain.NewMessage("id1", "first content")}, nil)
+
+	path := filepath.Join(t.TempDir(), "messages.json")
+	output, err := execute(t, serviceMock, "", "export", path)
+	assert.NoError(t, err)
+	assert.Empty(t, output)
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"id": "id1", "content": "first content"}]`, string(content))
+}
+
+func TestAuditVerify_ShouldCountEntriesOfValidChain(t *testing.T) {
+	output, err := execute(t, new(mocks.MessageUseCaseMock), auditLog(t, "second content"), "audit", "verify")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2 entries verified\n", output)
+}
+
+func TestAuditVerify_ShouldReturnErrorWhenEntryWasAltered(t *testing.T) {
+	stdin := strings.Replace(auditLog(t, "second content"), "second content", "altered content", 1)
+
+	_, err := execute(t, new(mocks.MessageUseCaseMock), stdin, "audit", "verify", "-")
+
+	assert.ErrorContains(t, err, "line 2: entry 2 does not match its hash")
+}
+
+func TestAuditVerify_ShouldReturnErrorWhenEntryWasRemoved(t *testing.T) {
+	lines := strings.SplitAfter(auditLog(t, "second content"), "\n")
+
+	_, err := execute(t, new(mocks.MessageUseCaseMock), lines[1], "audit", "verify")
+
+	assert.ErrorContains(t, err, "line 1: entry 2 follows entry 0")
+}
+
+func TestRootCommand_ShouldPassServerTokenAndTimeoutToConnector(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetAll", mock.Anything).Return([]domain.Message{}, nil)
+
+	var server, token string
+	var timeout time.Duration
+	cmd := NewRootCommand(func(s string, tk string, t time.Duration) (ports.MessageUseCase, func() error, error) {
+		server, token, timeout = s, tk, t
+		return serviceMock, func() error { return nil }, nil
+	})
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetArgs([]string{"list", "--server", "http://localhost:8080", "--token", "a.b.c", "--timeout", "3s"})
+
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "http://localhost:8080", server)
+	assert.Equal(t, "a.b.c", token)
+	assert.Equal(t, 3*time.Second, timeout)
+}
+
+func TestRootCommand_ShouldCompleteMessageIDs(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetAll", mock.Anything).Return([]domain.Message{domain.NewMessage("id1", "first content")}, nil)
+
+	output, err := execute(t, serviceMock, "", "__complete", "get", "")
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(output, "id1\tfirst content\n"))
+}
+
+func execute(t *testing.T, service ports.MessageUseCase, stdin string, args ...string) (string, error) {
+	t.Setenv("HEXCTL_SERVER", "")
+
+	cmd := NewRootCommand(func(string, string, time.Duration) (ports.MessageUseCase, func() error, error) {
+		return service, func() error { return nil }, nil
+	})
+	output := new(bytes.Buffer)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(output)
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+func auditLog(t *testing.T, secondContent string) string {
+	at := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	first := domain.NewAuditEntry(at, "acme", domain.ActionCreate, "id1", nil).
+		WithSnapshots(nil, domain.NewAuditSnapshot(domain.NewMessage("id1", "first content"))).
+		Chain(domain.AuditEntry{})
+	second := domain.NewAuditEntry(at, "acme", domain.ActionCreate, "id2", nil).
+		WithSnapshots(nil, domain.NewAuditSnapshot(domain.NewMessage("id2", secondContent))).
+		Chain(first)
+
+	log := new(bytes.Buffer)
+	encoder := json.NewEncoder(log)
+	assert.NoError(t, encoder.Encode(first))
+	assert.NoError(t, encoder.Encode(second))
+	return log.String()
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/config"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	auditusecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/audit"
+	usecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/message"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/internal/repositories/boltdb"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/client"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+)
+
+var errOfflineStorage = fmt.Errorf("offline mode needs HEXAPI_STORAGE=%s, or set --server to use a running hexapi", config.StorageBolt)
+
+// Connector returns the message use case the commands run against and a
+// function releasing it.
+type Connector func(server string, token string, timeout time.Duration) (ports.MessageUseCase, func() error, error)
+
+// Connect talks to the hexapi at server when it is set, authenticating with
+// token when there is one, otherwise it opens the storage configured through
+// the HEXAPI_* variables directly.
+func Connect(server string, token string, timeout time.Duration) (ports.MessageUseCase, func() error, error) {
+	if server != "" {
+		service := NewRemoteMessageService(client.New(server, client.WithHTTPClient(&http.Client{Timeout: timeout}), client.WithBearerToken(token)))
+		return service, func() error { return nil }, nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	return openOffline(cfg)
+}
+
+// openOffline records events in the outbox like the API does, so they are
+// relayed the next time hexapi runs against the same database.
+func openOffline(cfg config.Config) (ports.MessageUseCase, func() error, error) {
+	if cfg.Storage != config.StorageBolt {
+		return nil, nil, errOfflineStorage
+	}
+
+	db, err := boltdb.Open(cfg.BoltPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open %s: %w", cfg.BoltPath, err)
+	}
+
+	messageRepository, err := boltdb.NewMessageStorage(db)
+	if err != nil {
+		return nil, nil, errors.Join(err, db.Close())
+	}
+	if _, err := boltdb.NewOutboxStorage(db); err != nil {
+		return nil, nil, errors.Join(err, db.Close())
+	}
+
+	auditRepository, err := boltdb.NewAuditStorage(db)
+	if err != nil {
+		return nil, nil, errors.Join(err, db.Close())
+	}
+
+	systemClock := clock.NewSystemClock()
+	service := usecases.NewMessageService(identifier.NewUUIDGenerator(), systemClock, messageRepository, boltdb.NewUnitOfWork(db), policy.NewRolePolicy())
+	return auditusecases.NewAuditedMessageService(service, auditRepository, systemClock), db.Close, nil
+}
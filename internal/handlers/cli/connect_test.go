@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenOffline_ShouldReturnErrorWhenStorageIsMemory(t *testing.T) {
+	_, _, err := openOffline(config.Config{Storage: config.StorageMemory})
+
+	assert.ErrorIs(t, err, errOfflineStorage)
+}
+
+func TestOpenOffline_ShouldPersistMessagesInBoltDatabase(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.Config{Storage: config.StorageBolt, BoltPath: filepath.Join(t.TempDir(), "hexapi.db")}
+
+	service, release, err := openOffline(cfg)
+	assert.NoError(t, err)
+	message, err := service.Save(ctx, "message content")
+	assert.NoError(t, err)
+	assert.NoError(t, release())
+
+	service, release, err = openOffline(cfg)
+	assert.NoError(t, err)
+	defer release()
+
+	stored, err := service.GetByID(ctx, message.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, message, stored)
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var errInvalidOutput = fmt.Errorf("output must be %s, %s or %s", outputTable, outputJSON, outputYAML)
+
+func validOutput(output string) bool {
+	return output == outputTable || output == outputJSON || output == outputYAML
+}
+
+func printMessages(w io.Writer, output string, messages []dto.GetMessageResponse) error {
+	if output != outputTable {
+		return encode(w, output, messages)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ID\tCONTENT")
+	for _, message := range messages {
+		fmt.Fprintf(table, "%s\t%s\n", message.ID, message.Content)
+	}
+	return table.Flush()
+}
+
+func printBatch(w io.Writer, output string, batch dto.BatchMessagesResponse) error {
+	if output != outputTable {
+		return encode(w, output, batch)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "INDEX\tID\tSTATUS\tERROR")
+	for _, item := range batch.Results {
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\n", item.Index, item.ID, item.Status, strings.ReplaceAll(item.Error, "\n", ": "))
+	}
+	return table.Flush()
+}
+
+// encode writes YAML through the JSON encoding so that both formats share the
+// field names, order and omitempty rules of the dto tags.
+func encode(w io.Writer, output string, value any) error {
+	payload, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	if output == outputJSON {
+		_, err := fmt.Fprintln(w, string(payload))
+		return err
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(payload, &document); err != nil {
+		return err
+	}
+	blockStyle(&document)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// blockStyle drops the flow and quoting styles the JSON input was parsed with.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
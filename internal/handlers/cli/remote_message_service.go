@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/client"
+)
+
+// remoteMessageService implements ports.MessageUseCase on top of the HTTP API
+// of a running hexapi, so the commands do not depend on the mode.
+type remoteMessageService struct {
+	client *client.Client
+}
+
+func NewRemoteMessageService(client *client.Client) remoteMessageService {
+	return remoteMessageService{
+		client: client,
+	}
+}
+
+func (r remoteMessageService) Save(ctx context.Context, content string) (domain.Message, error) {
+	response, err := r.client.CreateMessage(ctx, client.CreateMessageRequest{Content: content})
+	if err != nil {
+		return domain.Message{}, err
+	}
+	return domain.NewMessage(response.ID, content), nil
+}
+
+func (r remoteMessageService) SaveBatch(ctx context.Context, contents []string, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	request := client.BatchCreateMessagesRequest{Mode: string(mode), Messages: make([]client.CreateMessageRequest, len(contents))}
+	for i, content := range contents {
+		request.Messages[i] = client.CreateMessageRequest{Content: content}
+	}
+	return batchResults(r.client.CreateMessages(ctx, request))
+}
+
+func (r remoteMessageService) GetByID(ctx context.Context, id string) (domain.Message, error) {
+	response, err := r.client.GetMessage(ctx, id)
+	if err != nil {
+		return domain.Message{}, err
+	}
+	return domain.NewMessage(response.ID, response.Content), nil
+}
+
+func (r remoteMessageService) GetAll(ctx context.Context) ([]domain.Message, error) {
+	response, err := r.client.ListMessages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]domain.Message, len(response))
+	for i, message := range response {
+		messages[i] = domain.NewMessage(message.ID, message.Content)
+	}
+	return messages, nil
+}
+
+// ForEach lists every message first, since the HTTP API has no cursor.
+func (r remoteMessageService) ForEach(ctx context.Context, after string, fn func(message domain.Message) error) error {
+	messages, err := r.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		if after != "" && message.ID <= after {
+			continue
+		}
+		if err := fn(message); err != nil {
+			if errors.Is(err, ports.ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (r remoteMessageService) DeleteByID(ctx context.Context, id string) error {
+	return r.client.DeleteMessage(ctx, id)
+}
+
+func (r remoteMessageService) DeleteBatch(ctx context.Context, ids []string, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	return batchResults(r.client.DeleteMessages(ctx, client.BatchDeleteMessagesRequest{Mode: string(mode), IDs: ids}))
+}
+
+// batchResults keeps the results of an aborted atomic batch, as messageService
+// does.
+func batchResults(response client.BatchMessagesResponse, err error) ([]domain.BatchResult, error) {
+	if err != nil && !errors.Is(err, apperrors.UnprocessableEntity) {
+		return nil, err
+	}
+
+	results := make([]domain.BatchResult, len(response.Results))
+	for i, item := range response.Results {
+		results[i] = domain.NewBatchResult(item.ID, batchItemError(item))
+	}
+	return results, err
+}
+
+func batchItemError(item client.BatchItemResponse) error {
+	switch item.Status {
+	case client.BatchItemStatusAborted:
+		return apperrors.Aborted
+	case client.BatchItemStatusFailed:
+		return errors.New(item.Error)
+	default:
+		return nil
+	}
+}
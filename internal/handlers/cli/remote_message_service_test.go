@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteSave_ShouldPostContent(t *testing.T) {
+	service := setupRemoteService(t, func(w http.ResponseWriter, r *http.Request) {
+		var request dto.CreateMessageRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "POST /v1/message", r.Method+" "+r.URL.Path)
+		assert.Equal(t, "message content", request.Content)
+
+		writeJSON(w, http.StatusCreated, dto.CreateMessageResponse{ID: "message-id"})
+	})
+
+	message, err := service.Save(context.Background(), "message content")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.NewMessage("message-id", "message content"), message)
+}
+
+func TestRemoteGetByID_ShouldReturnNotFoundWithServerMessage(t *testing.T) {
+	service := setupRemoteService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/message/message%20id", r.URL.EscapedPath())
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "not_found\nmessage id not found"})
+	})
+
+	_, err := service.GetByID(context.Background(), "message id")
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.EqualError(t, err, "not_found\nmessage id not found")
+}
+
+func TestRemoteGetAll_ShouldReturnMessages(t *testing.T) {
+	service := setupRemoteService(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, []dto.GetMessageResponse{{ID: "id1", Content: "first content"}})
+	})
+
+	messages, err := service.GetAll(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{domain.NewMessage("id1", "first content")}, messages)
+}
+
+func TestRemoteForEach_ShouldStopWhenAskedTo(t *testing.T) {
+	service := setupRemoteService(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, []dto.GetMessageResponse{{ID: "id1", Content: "first content"}, {ID: "id2", Content: "second content"}})
+	})
+
+	var visited []domain.Message
+	err := service.ForEach(context.Background(), "", func(message domain.Message) error {
+		visited = append(visited, message)
+		return ports.ErrStopIteration
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{domain.NewMessage("id1", "first content")}, visited)
+}
+
+func TestRemoteSaveBatch_ShouldReturnResultsWhenAtomicBatchIsAborted(t *testing.T) {
+	service := setupRemoteService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST /v1/messages:batch", r.Method+" "+r.URL.Path)
+		writeJSON(w, http.StatusUnprocessableEntity, dto.BatchMessagesResponse{
+			Mode: string(domain.BatchModeAtomic),
+			Results: []dto.BatchItemResponse{
+				{Index: 0, ID: "id1", Status: dto.BatchItemStatusAborted},
+				{Index: 1, ID: "id2", Status: dto.BatchItemStatusFailed, Error: "unexpected error"},
+			},
+		})
+	})
+
+	results, err := service.SaveBatch(context.Background(), []string{"first content", "second content"}, domain.BatchModeAtomic)
+
+	assert.ErrorIs(t, err, apperrors.UnprocessableEntity)
+	assert.Len(t, results, 2)
+	assert.ErrorIs(t, results[0].Err, apperrors.Aborted)
+	assert.EqualError(t, results[1].Err, "unexpected error")
+}
+
+func TestRemoteDeleteBatch_ShouldReturnErrorWhenServerFails(t *testing.T) {
+	service := setupRemoteService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	results, err := service.DeleteBatch(context.Background(), []string{"id1"}, domain.BatchModeAtomic)
+
+	assert.EqualError(t, err, "500 Internal Server Error")
+	assert.Nil(t, results)
+}
+
+func setupRemoteService(t *testing.T, handler http.HandlerFunc) remoteMessageService {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewRemoteMessageService(client.New(server.URL, client.WithHTTPClient(&http.Client{Timeout: time.Second}), client.WithRetries(0, 0, 0)))
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
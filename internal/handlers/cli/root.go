@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/spf13/cobra"
+)
+
+const defaultTimeout = 10 * time.Second
+
+type app struct {
+	connect Connector
+	server  string
+	token   string
+	timeout time.Duration
+	output  string
+}
+
+// NewRootCommand builds hexctl. Every command runs against the use case
+// returned by connect, whether it is backed by the storage or by the API.
+func NewRootCommand(connect Connector) *cobra.Command {
+	a := &app{connect: connect}
+
+	root := &cobra.Command{
+		Use:           "hexctl",
+		Short:         "Manage hexapi messages",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if !validOutput(a.output) {
+				return errInvalidOutput
+			}
+			return nil
+		},
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&a.server, "server", os.Getenv("HEXCTL_SERVER"), "URL of a running hexapi; the configured storage is used directly when empty")
+	flags.StringVar(&a.token, "token", os.Getenv("HEXCTL_TOKEN"), "bearer token sent to the server")
+	flags.DurationVar(&a.timeout, "timeout", defaultTimeout, "timeout of each request to the server")
+	flags.StringVarP(&a.output, "output", "o", outputTable, "output format: table, json or yaml")
+	_ = root.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{outputTable, outputJSON, outputYAML}, cobra.ShellCompDirectiveNoFileComp))
+
+	root.AddCommand(
+		a.newCreateCommand(),
+		a.newGetCommand(),
+		a.newListCommand(),
+		a.newDeleteCommand(),
+		a.newImportCommand(),
+		a.newExportCommand(),
+		a.newAuditCommand(),
+	)
+	return root
+}
+
+// run connects only when a command actually runs, so help and completion
+// never open the storage.
+func (a *app) run(fn func(ctx context.Context, cmd *cobra.Command, service ports.MessageUseCase, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		service, release, err := a.connect(a.server, a.token, a.timeout)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if releaseErr := release(); err == nil {
+				err = releaseErr
+			}
+		}()
+
+		return fn(cmd.Context(), cmd, service, args)
+	}
+}
+
+// completeMessageIDs suggests the ids of the existing messages.
+func (a *app) completeMessageIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var ids []string
+	err := a.run(func(ctx context.Context, cmd *cobra.Command, service ports.MessageUseCase, args []string) error {
+		messages, err := service.GetAll(ctx)
+		for _, message := range messages {
+			ids = append(ids, message.ID+"\t"+message.Content)
+		}
+		return err
+	})(cmd, args)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return ids, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,137 @@
+// Package codec encodes response bodies and decodes request bodies in the
+// media types the HTTP API can negotiate.
+package codec
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	MediaTypeJSON     = "application/json"
+	MediaTypeMsgPack  = "application/msgpack"
+	MediaTypeProtobuf = "application/x-protobuf"
+	MediaTypeCSV      = "text/csv"
+)
+
+// ErrUnsupportedValue is returned by a codec for values its media type cannot
+// represent, such as an error body in CSV.
+var ErrUnsupportedValue = errors.New("value cannot be represented in this media type")
+
+type Codec interface {
+	MediaType() string
+	// ContentType is the Content-Type header of encoded bodies.
+	ContentType() string
+	Encode(w io.Writer, value any) error
+	// Decode fills value, a pointer to a request DTO.
+	Decode(data []byte, value any) error
+}
+
+// Registry holds the codecs in order of server preference.
+type Registry struct {
+	codecs []Codec
+}
+
+func NewRegistry(codecs ...Codec) Registry {
+	return Registry{codecs: codecs}
+}
+
+// Lookup returns the codec of a Content-Type header, ignoring its parameters.
+func (r Registry) Lookup(contentType string) (Codec, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+	for _, codec := range r.codecs {
+		if codec.MediaType() == mediaType {
+			return codec, true
+		}
+	}
+	return nil, false
+}
+
+// Negotiate picks the codec of one of the offered media types for an Accept
+// header, following RFC 9110: the most specific matching range gives each
+// media type its quality, the highest quality wins and ties go to the first
+// offered. An empty header accepts anything.
+func (r Registry) Negotiate(accept string, offered []string) (Codec, bool) {
+	ranges := parseAccept(accept)
+
+	var best Codec
+	bestQuality := 0.0
+	for _, mediaType := range offered {
+		codec, ok := r.Lookup(mediaType)
+		if !ok {
+			continue
+		}
+		if quality := ranges.quality(mediaType); quality > bestQuality {
+			best, bestQuality = codec, quality
+		}
+	}
+	return best, best != nil
+}
+
+type mediaRange struct {
+	mediaType string
+	quality   float64
+}
+
+type mediaRanges []mediaRange
+
+func parseAccept(accept string) mediaRanges {
+	if strings.TrimSpace(accept) == "" {
+		return mediaRanges{{mediaType: "*/*", quality: 1}}
+	}
+
+	var ranges mediaRanges
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+	// Exact types before type/* before */*.
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return specificity(ranges[i].mediaType) > specificity(ranges[j].mediaType)
+	})
+	return ranges
+}
+
+func (ranges mediaRanges) quality(mediaType string) float64 {
+	for _, r := range ranges {
+		if matches(r.mediaType, mediaType) {
+			return r.quality
+		}
+	}
+	return 0
+}
+
+func matches(mediaRange string, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(mediaRange, "/*")
+	return ok && strings.HasPrefix(mediaType, prefix+"/")
+}
+
+func specificity(mediaRange string) int {
+	switch {
+	case mediaRange == "*/*":
+		return 0
+	case strings.HasSuffix(mediaRange, "/*"):
+		return 1
+	default:
+		return 2
+	}
+}
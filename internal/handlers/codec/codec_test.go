@@ -0,0 +1,97 @@
+package codec
+
+import (
+	"bytes"
+	"testing"
+
+	messagev1 "github.com/hiago-balbino/hex-architecture-template/api/proto/message/v1"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+var registry = NewRegistry(JSON{}, MsgPack{}, Protobuf{}, CSV{})
+
+func TestNegotiate_ShouldFollowAcceptHeader(t *testing.T) {
+	offered := []string{MediaTypeJSON, MediaTypeMsgPack, MediaTypeCSV}
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{accept: "", want: MediaTypeJSON},
+		{accept: "*/*", want: MediaTypeJSON},
+		{accept: "application/msgpack", want: MediaTypeMsgPack},
+		{accept: "text/*", want: MediaTypeCSV},
+		{accept: "application/json;q=0.5, text/csv", want: MediaTypeCSV},
+		{accept: "*/*;q=0.1, application/msgpack;q=0.2", want: MediaTypeMsgPack},
+		{accept: "application/*, application/json;q=0", want: MediaTypeMsgPack},
+	}
+	for _, tt := range tests {
+		codec, ok := registry.Negotiate(tt.accept, offered)
+		assert.True(t, ok, tt.accept)
+		assert.Equal(t, tt.want, codec.MediaType(), tt.accept)
+	}
+}
+
+func TestNegotiate_ShouldFailWhenNothingIsAcceptable(t *testing.T) {
+	_, ok := registry.Negotiate("application/xml, text/*;q=0", []string{MediaTypeJSON, MediaTypeCSV})
+
+	assert.False(t, ok)
+}
+
+func TestLookup_ShouldIgnoreParameters(t *testing.T) {
+	codec, ok := registry.Lookup("application/json; charset=utf-8")
+	assert.True(t, ok)
+	assert.Equal(t, MediaTypeJSON, codec.MediaType())
+
+	_, ok = registry.Lookup("application/xml")
+	assert.False(t, ok)
+}
+
+func TestMsgPack_ShouldRoundTripWithJSONFieldNames(t *testing.T) {
+	var buffer bytes.Buffer
+	err := MsgPack{}.Encode(&buffer, dto.CreateMessageRequest{Content: "message content"})
+	assert.NoError(t, err)
+
+	var fields map[string]any
+	assert.NoError(t, MsgPack{}.Decode(buffer.Bytes(), &fields))
+	assert.Equal(t, map[string]any{"content": "message content"}, fields)
+
+	var request dto.CreateMessageRequest
+	assert.NoError(t, MsgPack{}.Decode(buffer.Bytes(), &request))
+	assert.Equal(t, "message content", request.Content)
+}
+
+func TestProtobuf_ShouldEncodeMessageResponses(t *testing.T) {
+	var buffer bytes.Buffer
+	err := Protobuf{}.Encode(&buffer, []dto.GetMessageResponse{{ID: "1", Content: "first"}})
+	assert.NoError(t, err)
+
+	var list messagev1.ListResponse
+	assert.NoError(t, proto.Unmarshal(buffer.Bytes(), &list))
+	assert.Len(t, list.GetMessages(), 1)
+	assert.Equal(t, "first", list.GetMessages()[0].GetContent())
+}
+
+func TestProtobuf_ShouldRejectUnknownValues(t *testing.T) {
+	err := Protobuf{}.Encode(&bytes.Buffer{}, map[string]string{"error": "not_found"})
+
+	assert.ErrorIs(t, err, ErrUnsupportedValue)
+}
+
+func TestCSV_ShouldWriteHeaderAndRows(t *testing.T) {
+	var buffer bytes.Buffer
+	err := CSV{}.Encode(&buffer, dto.ListMessagesResponseV2{Messages: []dto.MessageResponseV2{
+		{ID: "1", Content: "first", Owner: "user-1"},
+		{ID: "2", Content: "with, comma"},
+	}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "id,content,owner\n1,first,user-1\n2,\"with, comma\",\n", buffer.String())
+}
+
+func TestCSV_ShouldRejectNonTabularValues(t *testing.T) {
+	err := CSV{}.Encode(&bytes.Buffer{}, dto.GetMessageResponse{ID: "1"})
+
+	assert.ErrorIs(t, err, ErrUnsupportedValue)
+}
@@ -0,0 +1,106 @@
+package codec
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// CSV encodes a slice of structs, or a struct wrapping one, as a header of
+// the json field names followed by a row per element. Fields must be scalars.
+type CSV struct{}
+
+func (CSV) MediaType() string {
+	return MediaTypeCSV
+}
+
+func (CSV) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (CSV) Encode(w io.Writer, value any) error {
+	rows, ok := csvRows(reflect.ValueOf(value))
+	if !ok {
+		return ErrUnsupportedValue
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func (CSV) Decode(data []byte, value any) error {
+	return ErrUnsupportedValue
+}
+
+func csvRows(value reflect.Value) ([][]string, bool) {
+	if value.Kind() == reflect.Struct {
+		list, ok := wrappedSlice(value)
+		if !ok {
+			return nil, false
+		}
+		value = list
+	}
+	if value.Kind() != reflect.Slice || value.Type().Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	element := value.Type().Elem()
+	var header []string
+	var fields []int
+	for i := 0; i < element.NumField(); i++ {
+		field := element.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if !isScalar(field.Type.Kind()) {
+			return nil, false
+		}
+		if name == "" {
+			name = field.Name
+		}
+		header = append(header, name)
+		fields = append(fields, i)
+	}
+
+	rows := [][]string{header}
+	for i := 0; i < value.Len(); i++ {
+		row := make([]string, len(fields))
+		for j, field := range fields {
+			row[j] = fmt.Sprint(value.Index(i).Field(field).Interface())
+		}
+		rows = append(rows, row)
+	}
+	return rows, true
+}
+
+func wrappedSlice(value reflect.Value) (reflect.Value, bool) {
+	var list reflect.Value
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Type().Field(i).IsExported() {
+			continue
+		}
+		if value.Field(i).Kind() != reflect.Slice || list.IsValid() {
+			return reflect.Value{}, false
+		}
+		list = value.Field(i)
+	}
+	return list, list.IsValid()
+}
+
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
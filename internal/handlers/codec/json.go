@@ -0,0 +1,30 @@
+package codec
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type JSON struct{}
+
+func (JSON) MediaType() string {
+	return MediaTypeJSON
+}
+
+func (JSON) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+// Encode writes the same bytes as gin's c.JSON.
+func (JSON) Encode(w io.Writer, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (JSON) Decode(data []byte, value any) error {
+	return json.Unmarshal(data, value)
+}
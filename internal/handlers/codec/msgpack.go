@@ -0,0 +1,34 @@
+package codec
+
+import (
+	"io"
+
+	"github.com/ugorji/go/codec"
+)
+
+// MsgPack uses the json tags of the DTOs for the map keys, so its bodies have
+// the same shape as the JSON ones.
+type MsgPack struct{}
+
+func (MsgPack) MediaType() string {
+	return MediaTypeMsgPack
+}
+
+func (MsgPack) ContentType() string {
+	return MediaTypeMsgPack
+}
+
+func (MsgPack) Encode(w io.Writer, value any) error {
+	return codec.NewEncoder(w, msgpackHandle()).Encode(value)
+}
+
+func (MsgPack) Decode(data []byte, value any) error {
+	return codec.NewDecoderBytes(data, msgpackHandle()).Decode(value)
+}
+
+func msgpackHandle() *codec.MsgpackHandle {
+	handle := &codec.MsgpackHandle{}
+	handle.WriteExt = true
+	handle.RawToString = true
+	return handle
+}
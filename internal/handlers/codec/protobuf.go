@@ -0,0 +1,76 @@
+package codec
+
+import (
+	"io"
+
+	messagev1 "github.com/hiago-balbino/hex-architecture-template/api/proto/message/v1"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"google.golang.org/protobuf/proto"
+)
+
+// Protobuf encodes the message DTOs as the messages of the gRPC API, so both
+// share api/proto/message/v1/message.proto.
+type Protobuf struct{}
+
+func (Protobuf) MediaType() string {
+	return MediaTypeProtobuf
+}
+
+func (Protobuf) ContentType() string {
+	return MediaTypeProtobuf
+}
+
+func (Protobuf) Encode(w io.Writer, value any) error {
+	message, ok := toProto(value)
+	if !ok {
+		return ErrUnsupportedValue
+	}
+
+	data, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (Protobuf) Decode(data []byte, value any) error {
+	switch request := value.(type) {
+	case *dto.CreateMessageRequest:
+		var message messagev1.CreateRequest
+		if err := proto.Unmarshal(data, &message); err != nil {
+			return err
+		}
+		request.Content = message.GetContent()
+		return nil
+	default:
+		return ErrUnsupportedValue
+	}
+}
+
+func toProto(value any) (proto.Message, bool) {
+	switch response := value.(type) {
+	case proto.Message:
+		return response, true
+	case dto.CreateMessageResponse:
+		return &messagev1.Message{Id: response.ID}, true
+	case dto.GetMessageResponse:
+		return &messagev1.Message{Id: response.ID, Content: response.Content}, true
+	case dto.MessageResponseV2:
+		return &messagev1.Message{Id: response.ID, Content: response.Content}, true
+	case []dto.GetMessageResponse:
+		list := &messagev1.ListResponse{}
+		for _, message := range response {
+			list.Messages = append(list.Messages, &messagev1.Message{Id: message.ID, Content: message.Content})
+		}
+		return list, true
+	case dto.ListMessagesResponseV2:
+		list := &messagev1.ListResponse{}
+		for _, message := range response.Messages {
+			list.Messages = append(list.Messages, &messagev1.Message{Id: message.ID, Content: message.Content})
+		}
+		return list, true
+	default:
+		return nil, false
+	}
+}
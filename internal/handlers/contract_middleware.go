@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/codec"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/openapi"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+var (
+	errRequestViolatesContract  = errors.New("request does not match the API contract")
+	errResponseViolatesContract = errors.New("response does not match the API contract")
+	errUndocumentedRoute        = errors.New("route is not in the API contract")
+)
+
+// contractMiddleware validates requests against the OpenAPI operation of the
+// matched route before they reach the handlers. Responses are validated too
+// when validateResponses is set, which buffers them and is meant for tests.
+type contractMiddleware struct {
+	docs              *openAPIHandler
+	validateResponses bool
+}
+
+func NewContractMiddleware(docs *openAPIHandler, validateResponses bool) contractMiddleware {
+	return contractMiddleware{
+		docs:              docs,
+		validateResponses: validateResponses,
+	}
+}
+
+func (m contractMiddleware) handle(c *gin.Context) {
+	document := m.docs.document
+	operation, ok := document.Operation(c.Request.Method, c.FullPath())
+	if !ok {
+		c.AbortWithStatusJSON(500, dto.ErrorResponse{Error: errors.Join(apperrors.InternalServerError, errUndocumentedRoute).Error()})
+		return
+	}
+
+	// Other bodies, such as NDJSON imports, are streamed to the handler.
+	var body []byte
+	if operation.HasJSONBody() {
+		var err error
+		body, err = io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(400, dto.ErrorResponse{Error: errors.Join(apperrors.InvalidInput, err).Error()})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	pathParameters := map[string]string{}
+	for _, param := range c.Params {
+		pathParameters[param.Key] = param.Value
+	}
+	if violations := document.ValidateRequest(operation, c.Request, pathParameters, body); len(violations) > 0 {
+		c.AbortWithStatusJSON(400, violationsResponse(errors.Join(apperrors.InvalidInput, errRequestViolatesContract), violations))
+		return
+	}
+
+	if !m.validateResponses {
+		c.Next()
+		return
+	}
+
+	buffer := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+	c.Writer = buffer
+	c.Next()
+	c.Writer = buffer.ResponseWriter
+
+	// The document only describes JSON bodies, other encodings are checked by their codecs.
+	if !isJSON(buffer.Header().Get("Content-Type")) {
+		buffer.flush()
+		return
+	}
+	if violations := document.ValidateResponse(operation, buffer.status, buffer.body.Bytes()); len(violations) > 0 {
+		c.JSON(500, violationsResponse(errors.Join(apperrors.InternalServerError, errResponseViolatesContract), violations))
+		return
+	}
+	buffer.flush()
+}
+
+func violationsResponse(err error, violations []openapi.Violation) dto.ErrorResponse {
+	response := dto.ErrorResponse{Error: err.Error()}
+	for _, violation := range violations {
+		response.Violations = append(response.Violations, dto.ViolationResponse{
+			In:      violation.In,
+			Field:   violation.Field,
+			Message: violation.Message,
+		})
+	}
+	return response
+}
+
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == codec.MediaTypeJSON
+}
+
+// bufferedWriter holds the response back until it has been validated.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(data string) (int, error) {
+	return w.body.WriteString(data)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.body.Len() > 0
+}
+
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.body.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
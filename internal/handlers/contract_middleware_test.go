@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/openapi"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestContractMiddleware_ShouldRejectMalformedMessageID(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	var response dto.ErrorResponse
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/message/{id}").
+		WithPath("id", "not-a-uuid").
+		Expect().Status(http.StatusBadRequest).
+		JSON().Decode(&response)
+
+	assert.Contains(t, response.Error, apperrors.InvalidInput.Error())
+	assert.Equal(t, []dto.ViolationResponse{{In: openapi.InPath, Field: "id", Message: "must be a UUID"}}, response.Violations)
+	serviceMock.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestContractMiddleware_ShouldRejectBodyNotMatchingSchema(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	var response dto.ErrorResponse
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/messages:batch").
+		WithJSON(map[string]any{"mode": "eventually", "messages": []any{map[string]any{"content": 1}, map[string]any{}}}).
+		Expect().Status(http.StatusBadRequest).
+		JSON().Decode(&response)
+
+	assert.Equal(t, []dto.ViolationResponse{
+		{In: openapi.InBody, Field: "/messages/0/content", Message: "must be a string"},
+		{In: openapi.InBody, Field: "/messages/1/content", Message: "is required"},
+		{In: openapi.InBody, Field: "/mode", Message: "must be one of atomic, best_effort"},
+	}, response.Violations)
+	serviceMock.AssertNotCalled(t, "SaveBatch", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestContractMiddleware_ShouldRejectBatchesOutsideSizeLimits(t *testing.T) {
+	server := httptest.NewServer(setupHandler(nil))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.DELETE("/messages:batch").
+		WithJSON(dto.BatchDeleteMessagesRequest{IDs: []string{}}).
+		Expect().Status(http.StatusBadRequest).
+		JSON().Path("$.violations[0].message").IsEqual("must have at least 1 items")
+	e.DELETE("/messages:batch").
+		WithJSON(dto.BatchDeleteMessagesRequest{IDs: make([]string, 1001)}).
+		Expect().Status(http.StatusBadRequest).
+		JSON().Path("$.violations[0].message").IsEqual("must have at most 1000 items")
+}
+
+func TestContractMiddleware_ShouldRejectMissingBody(t *testing.T) {
+	server := httptest.NewServer(setupHandler(nil))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/message").
+		Expect().Status(http.StatusBadRequest).
+		JSON().Path("$.violations[0]").IsEqual(map[string]any{"in": openapi.InBody, "message": "is required"})
+}
+
+func TestContractMiddleware_ShouldPassValidRequestToHandler(t *testing.T) {
+	message := domain.NewMessage(uuid.NewString(), "message content")
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, message.Content).Return(message, nil)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/message").
+		WithJSON(dto.CreateMessageRequest{Content: message.Content}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object().IsEqual(dto.CreateMessageResponse{ID: message.ID})
+}
+
+func TestContractMiddleware_ShouldRejectResponseNotMatchingSchema(t *testing.T) {
+	server := httptest.NewServer(setupContractHandler(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": 1})
+	}))
+	defer server.Close()
+
+	var response dto.ErrorResponse
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/things").
+		Expect().Status(http.StatusInternalServerError).
+		JSON().Decode(&response)
+
+	assert.True(t, strings.HasPrefix(response.Error, apperrors.InternalServerError.Error()))
+	assert.Equal(t, []dto.ViolationResponse{{In: openapi.InBody, Field: "/id", Message: "must be a string"}}, response.Violations)
+}
+
+func TestContractMiddleware_ShouldRejectUndocumentedResponseStatus(t *testing.T) {
+	server := httptest.NewServer(setupContractHandler(func(c *gin.Context) {
+		c.Status(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/things").
+		Expect().Status(http.StatusInternalServerError).
+		JSON().Path("$.violations[0].message").IsEqual("status 418 is not documented")
+}
+
+func TestContractMiddleware_ShouldWriteValidResponse(t *testing.T) {
+	server := httptest.NewServer(setupContractHandler(func(c *gin.Context) {
+		c.Header("X-Thing", "yes")
+		c.JSON(http.StatusOK, dto.CreateMessageResponse{ID: "thing"})
+	}))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	response := e.GET("/things").Expect().Status(http.StatusOK)
+	response.Header("X-Thing").IsEqual("yes")
+	response.JSON().Object().IsEqual(dto.CreateMessageResponse{ID: "thing"})
+}
+
+func setupContractHandler(handler gin.HandlerFunc) *gin.Engine {
+	docs := &openAPIHandler{document: openapi.Generate(apiInfo, []openapi.Route{{
+		Method: http.MethodGet,
+		Path:   "/things",
+		Operation: openapi.Operation{
+			ID:        "getThing",
+			Responses: []openapi.Response{{Status: http.StatusOK, Body: dto.CreateMessageResponse{}}},
+		},
+	}})}
+
+	router := gin.New()
+	router.GET("/things", NewContractMiddleware(docs, true).handle, handler)
+	return router
+}
@@ -0,0 +1,9 @@
+package graphql
+
+// MessageConnection is a page of messages. The total count is resolved only
+// when it is asked for, since it visits every message matching filter.
+type MessageConnection struct {
+	Edges    []*MessageEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
+	filter   *MessageFilter
+}
@@ -0,0 +1,83 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	queryCacheSize          = 1000
+	persistedQueryCacheSize = 100
+	keepAlivePingInterval   = 10 * time.Second
+)
+
+// NewHandler serves queries and mutations over GET and POST and subscriptions
+// over WebSocket. Operations above complexityLimit are rejected before they
+// run, and clients may send only the hash of a query already seen.
+func NewHandler(service ports.MessageUseCase, feed *changefeed.Feed, complexityLimit int) http.Handler {
+	config := Config{Resolvers: resolver{service: service, feed: feed}}
+	config.Complexity.Query.Messages = func(childComplexity int, first *int, _ *string, _ *MessageFilter) int {
+		return 1 + childComplexity*max(pageSize(first), 1)
+	}
+
+	server := handler.New(NewExecutableSchema(config))
+	server.AddTransport(transport.Websocket{KeepAlivePingInterval: keepAlivePingInterval})
+	server.AddTransport(transport.Options{})
+	server.AddTransport(transport.GET{})
+	server.AddTransport(transport.POST{})
+	server.SetQueryCache(lru.New(queryCacheSize))
+	server.SetErrorPresenter(presentError)
+	server.Use(extension.Introspection{})
+	server.Use(extension.AutomaticPersistedQuery{Cache: lru.New(persistedQueryCacheSize)})
+	server.Use(extension.FixedComplexityLimit(complexityLimit))
+	return server
+}
+
+func NewPlaygroundHandler(endpoint string) http.Handler {
+	return playground.Handler("hexapi", endpoint)
+}
+
+// presentError adds a code extension so clients can tell application errors
+// apart without parsing messages.
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+
+	var code string
+	switch {
+	case errors.Is(err, apperrors.NotFound):
+		code = "NOT_FOUND"
+	case errors.Is(err, apperrors.Forbidden):
+		code = "FORBIDDEN"
+	case errors.Is(err, apperrors.InvalidInput):
+		code = "BAD_USER_INPUT"
+	case errors.Is(err, apperrors.UnprocessableEntity):
+		code = "UNPROCESSABLE_ENTITY"
+	case errors.Is(err, apperrors.Aborted):
+		code = "ABORTED"
+	case errors.Is(err, apperrors.TooManyRequests):
+		code = "TOO_MANY_REQUESTS"
+	case errors.Is(err, changefeed.ErrClosed):
+		code = "UNAVAILABLE"
+	default:
+		return presented
+	}
+
+	if presented.Extensions == nil {
+		presented.Extensions = map[string]any{}
+	}
+	presented.Extensions["code"] = code
+	return presented
+}
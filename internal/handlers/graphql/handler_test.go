@@ -0,0 +1,223 @@
+package graphql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/gavv/httpexpect/v2"
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMessage_ShouldReturnRequestedFields(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.Anything, "message-id").Return(domain.NewMessage("message-id", "message content"), nil)
+
+	e := setupServer(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 100)
+	data := postQuery(e, `{ message(id: "message-id") { content } }`, nil).Object().Value("data").Object()
+
+	data.Value("message").Object().IsEqual(map[string]any{"content": "message content"})
+}
+
+func TestMessage_ShouldReturnNullWhenMessageDoesNotExist(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.Anything, "message-id").Return(domain.Message{}, errors.Join(apperrors.NotFound, errors.New("message id not found")))
+
+	e := setupServer(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 100)
+	response := postQuery(e, `{ message(id: "message-id") { id } }`, nil).Object()
+
+	response.NotContainsKey("errors")
+	response.Value("data").Object().Value("message").IsNull()
+}
+
+func TestMessages_ShouldPaginateFilteredMessagesByID(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("ForEach", mock.Anything).Return([]domain.Message{
+		domain.NewMessage("id1", "hello one"),
+		domain.NewMessage("id2", "hello two"),
+		domain.NewMessage("id3", "hello three"),
+		domain.NewMessage("id4", "goodbye four"),
+	}, nil)
+
+	e := setupServer(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 100)
+	query := `query($after: String) {
+		messages(first: 2, after: $after, filter: {contentContains: "hello"}) {
+			edges { node { id } }
+			pageInfo { hasNextPage endCursor }
+			totalCount
+		}
+	}`
+
+	first := postQuery(e, query, nil).Object().Value("data").Object().Value("messages").Object()
+	first.Value("edges").Array().IsEqual([]any{
+		map[string]any{"node": map[string]any{"id": "id1"}},
+		map[string]any{"node": map[string]any{"id": "id2"}},
+	})
+	first.Value("pageInfo").Object().Value("hasNextPage").Boolean().IsTrue()
+	first.Value("totalCount").Number().IsEqual(3)
+
+	cursor := first.Value("pageInfo").Object().Value("endCursor").String().Raw()
+	second := postQuery(e, query, map[string]any{"after": cursor}).Object().Value("data").Object().Value("messages").Object()
+	second.Value("edges").Array().IsEqual([]any{
+		map[string]any{"node": map[string]any{"id": "id3"}},
+	})
+	second.Value("pageInfo").Object().Value("hasNextPage").Boolean().IsFalse()
+}
+
+func TestMessages_ShouldOnlyCountMessagesWhenAsked(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("ForEach", mock.Anything).Return([]domain.Message{
+		domain.NewMessage("id1", "hello one"),
+		domain.NewMessage("id2", "hello two"),
+	}, nil)
+
+	e := setupServer(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 100)
+	postQuery(e, `{ messages(first: 1) { edges { node { id } } } }`, nil).Object().
+		Value("data").Object().Value("messages").Object().Value("edges").Array().Length().IsEqual(1)
+	serviceMock.AssertNumberOfCalls(t, "ForEach", 1)
+
+	postQuery(e, `{ messages(first: 1) { totalCount } }`, nil).Object().
+		Value("data").Object().Value("messages").Object().Value("totalCount").Number().IsEqual(2)
+	serviceMock.AssertNumberOfCalls(t, "ForEach", 3)
+}
+
+func TestMessages_ShouldReturnBadUserInputWhenFirstIsTooLarge(t *testing.T) {
+	e := setupServer(t, new(mocks.MessageUseCaseMock), changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 10000)
+	response := postQuery(e, `{ messages(first: 1000) { totalCount } }`, nil).Object()
+
+	response.Value("errors").Array().Value(0).Object().
+		Value("extensions").Object().Value("code").IsEqual("BAD_USER_INPUT")
+}
+
+func TestMessages_ShouldRejectQueryAboveComplexityLimit(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+
+	e := setupServer(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 50)
+	response := postQuery(e, `{ messages(first: 100) { edges { node { id content } } } }`, nil).Object()
+
+	response.Value("errors").Array().Value(0).Object().
+		Value("extensions").Object().Value("code").IsEqual("COMPLEXITY_LIMIT_EXCEEDED")
+	serviceMock.AssertNotCalled(t, "ForEach", mock.Anything)
+}
+
+func TestCreateMessage_ShouldReturnCreatedMessage(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, "message content").Return(domain.NewMessage("message-id", "message content"), nil)
+
+	e := setupServer(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 100)
+	data := postQuery(e, `mutation { createMessage(content: "message content") { id } }`, nil).Object().Value("data").Object()
+
+	data.Value("createMessage").Object().Value("id").IsEqual("message-id")
+}
+
+func TestCreateMessage_ShouldReturnBadUserInputWhenContentIsInvalid(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, "").Return(domain.Message{}, apperrors.InvalidInput)
+
+	e := setupServer(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 100)
+	response := postQuery(e, `mutation { createMessage(content: "") { id } }`, nil).Object()
+
+	response.Value("errors").Array().Value(0).Object().
+		Value("extensions").Object().Value("code").IsEqual("BAD_USER_INPUT")
+}
+
+func TestDeleteMessage_ShouldReturnFalseWhenMessageDoesNotExist(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("DeleteByID", mock.Anything, "message-id").Return(errors.Join(apperrors.NotFound, errors.New("message id not found")))
+
+	e := setupServer(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 100)
+	data := postQuery(e, `mutation { deleteMessage(id: "message-id") }`, nil).Object().Value("data").Object()
+
+	data.Value("deleteMessage").Boolean().IsFalse()
+}
+
+func TestPersistedQuery_ShouldRunQueryByHashOnceRegistered(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("ForEach", mock.Anything).Return([]domain.Message{}, nil)
+
+	query := `{ messages { totalCount } }`
+	hash := sha256.Sum256([]byte(query))
+	extensions := map[string]any{
+		"persistedQuery": map[string]any{"version": 1, "sha256Hash": hex.EncodeToString(hash[:])},
+	}
+
+	e := setupServer(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 100)
+	e.POST("/graphql").WithJSON(map[string]any{"extensions": extensions}).
+		Expect().JSON().Object().Value("errors").Array().Value(0).Object().
+		Value("extensions").Object().Value("code").IsEqual("PERSISTED_QUERY_NOT_FOUND")
+
+	e.POST("/graphql").WithJSON(map[string]any{"query": query, "extensions": extensions}).
+		Expect().Status(http.StatusOK)
+
+	e.POST("/graphql").WithJSON(map[string]any{"extensions": extensions}).
+		Expect().JSON().Object().Value("data").Object().Value("messages").Object().
+		Value("totalCount").Number().IsEqual(0)
+}
+
+func TestMessageChanged_ShouldStreamMatchingChanges(t *testing.T) {
+	feed := changefeed.NewFeed(100, 100, policy.NewRolePolicy())
+	c := client.New(NewHandler(new(mocks.MessageUseCaseMock), feed, 100))
+
+	subscription := c.Websocket(`subscription { messageChanged(events: ["message.created"]) { event aggregateId message { content } } }`)
+	t.Cleanup(func() { subscription.Close() })
+
+	// The subscription is registered asynchronously, so publish until it is seen.
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go func() {
+		for ctx.Err() == nil {
+			_ = feed.Handle(ctx, domain.NewMessageDeleted("id1", time.Now()))
+			_ = feed.Handle(ctx, domain.NewMessageCreated(domain.NewMessage("id2", "message content"), time.Now()))
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	var response struct {
+		MessageChanged struct {
+			Event       string
+			AggregateID string
+			Message     struct{ Content string }
+		}
+	}
+	assert.NoError(t, subscription.Next(&response))
+	assert.Equal(t, domain.EventMessageCreated, response.MessageChanged.Event)
+	assert.Equal(t, "id2", response.MessageChanged.AggregateID)
+	assert.Equal(t, "message content", response.MessageChanged.Message.Content)
+}
+
+func TestMessageChanged_ShouldReturnErrorWhenEventIsUnknown(t *testing.T) {
+	c := client.New(NewHandler(new(mocks.MessageUseCaseMock), changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 100))
+
+	subscription := c.Websocket(`subscription { messageChanged(events: ["message.archived"]) { event } }`)
+	t.Cleanup(func() { subscription.Close() })
+
+	var response struct{}
+	assert.ErrorContains(t, subscription.Next(&response), "unknown event")
+}
+
+func setupServer(t *testing.T, service *mocks.MessageUseCaseMock, feed *changefeed.Feed, complexityLimit int) *httpexpect.Expect {
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", NewHandler(service, feed, complexityLimit))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return httpexpect.Default(t, server.URL)
+}
+
+func postQuery(e *httpexpect.Expect, query string, variables map[string]any) *httpexpect.Value {
+	return e.POST("/graphql").
+		WithJSON(map[string]any{"query": query, "variables": variables}).
+		Expect().Status(http.StatusOK).JSON()
+}
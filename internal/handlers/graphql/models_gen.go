@@ -0,0 +1,32 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package graphql
+
+import (
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+// A change to a message. The message is set for created and updated messages.
+type MessageChange struct {
+	ID          string          `json:"id"`
+	Event       string          `json:"event"`
+	AggregateID string          `json:"aggregateId"`
+	OccurredAt  time.Time       `json:"occurredAt"`
+	Message     *domain.Message `json:"message,omitempty"`
+}
+
+type MessageEdge struct {
+	Cursor string          `json:"cursor"`
+	Node   *domain.Message `json:"node"`
+}
+
+type MessageFilter struct {
+	ContentContains *string `json:"contentContains,omitempty"`
+}
+
+type PageInfo struct {
+	HasNextPage bool    `json:"hasNextPage"`
+	EndCursor   *string `json:"endCursor,omitempty"`
+}
@@ -0,0 +1,37 @@
+package graphql
+
+//go:generate go run github.com/99designs/gqlgen generate
+
+import (
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+)
+
+type resolver struct {
+	service ports.MessageUseCase
+	feed    *changefeed.Feed
+}
+
+func (r resolver) MessageConnection() MessageConnectionResolver {
+	return messageConnectionResolver{r}
+}
+
+func (r resolver) Mutation() MutationResolver {
+	return mutationResolver{r}
+}
+
+func (r resolver) Query() QueryResolver {
+	return queryResolver{r}
+}
+
+func (r resolver) Subscription() SubscriptionResolver {
+	return subscriptionResolver{r}
+}
+
+type messageConnectionResolver struct{ resolver }
+
+type mutationResolver struct{ resolver }
+
+type queryResolver struct{ resolver }
+
+type subscriptionResolver struct{ resolver }
@@ -0,0 +1,193 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+var (
+	errInvalidPageSize = fmt.Errorf("first must be between 0 and %d", maxPageSize)
+	errInvalidCursor   = errors.New("invalid cursor")
+)
+
+func (r messageConnectionResolver) TotalCount(ctx context.Context, obj *MessageConnection) (int, error) {
+	count := 0
+	err := r.service.ForEach(ctx, "", func(message domain.Message) error {
+		if matchesFilter(message, obj.filter) {
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r mutationResolver) CreateMessage(ctx context.Context, content string) (*domain.Message, error) {
+	message, err := r.service.Save(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r mutationResolver) DeleteMessage(ctx context.Context, id string) (bool, error) {
+	err := r.service.DeleteByID(ctx, id)
+	if errors.Is(err, apperrors.NotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r queryResolver) Message(ctx context.Context, id string) (*domain.Message, error) {
+	message, err := r.service.GetByID(ctx, id)
+	if errors.Is(err, apperrors.NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (r queryResolver) Messages(ctx context.Context, first *int, after *string, filter *MessageFilter) (*MessageConnection, error) {
+	limit := pageSize(first)
+	if limit < 0 || limit > maxPageSize {
+		return nil, errors.Join(apperrors.InvalidInput, errInvalidPageSize)
+	}
+
+	afterID := ""
+	if after != nil {
+		var err error
+		if afterID, err = decodeCursor(*after); err != nil {
+			return nil, err
+		}
+	}
+
+	// One more message than asked for tells whether there is a next page.
+	messages := make([]domain.Message, 0, limit+1)
+	err := r.service.ForEach(ctx, afterID, func(message domain.Message) error {
+		if !matchesFilter(message, filter) {
+			return nil
+		}
+		messages = append(messages, message)
+		if len(messages) > limit {
+			return ports.ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	connection := &MessageConnection{
+		Edges:    make([]*MessageEdge, 0, limit),
+		PageInfo: &PageInfo{HasNextPage: len(messages) > limit},
+		filter:   filter,
+	}
+	for i := range messages[:min(limit, len(messages))] {
+		cursor := encodeCursor(messages[i].ID)
+		connection.Edges = append(connection.Edges, &MessageEdge{Cursor: cursor, Node: &messages[i]})
+		connection.PageInfo.EndCursor = &cursor
+	}
+	return connection, nil
+}
+
+// MessageChanged forwards changes until the client unsubscribes or the
+// subscription ends, either because the client lagged or the feed closed.
+func (r subscriptionResolver) MessageChanged(ctx context.Context, events []string) (<-chan *MessageChange, error) {
+	for _, event := range events {
+		if !domain.IsKnownEvent(event) {
+			return nil, errors.Join(apperrors.InvalidInput, fmt.Errorf("unknown event %q", event))
+		}
+	}
+
+	subscription, err := r.feed.Subscribe(ctx, func(event domain.Event) bool {
+		return len(events) == 0 || slices.Contains(events, event.EventName())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(chan *MessageChange)
+	go func() {
+		defer close(changes)
+		defer subscription.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case change, ok := <-subscription.Changes():
+				if !ok {
+					return
+				}
+				select {
+				case changes <- toMessageChange(change):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return changes, nil
+}
+
+func pageSize(first *int) int {
+	if first == nil {
+		return defaultPageSize
+	}
+	return *first
+}
+
+func matchesFilter(message domain.Message, filter *MessageFilter) bool {
+	return filter == nil || filter.ContentContains == nil || strings.Contains(message.Content, *filter.ContentContains)
+}
+
+func encodeCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	id, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", errors.Join(apperrors.InvalidInput, errInvalidCursor)
+	}
+	return string(id), nil
+}
+
+func toMessageChange(change changefeed.Change) *MessageChange {
+	messageChange := &MessageChange{
+		ID:          strconv.FormatUint(change.ID, 10),
+		Event:       change.Event.EventName(),
+		AggregateID: change.Event.AggregateID(),
+		OccurredAt:  change.Event.OccurredAt(),
+	}
+
+	switch event := change.Event.(type) {
+	case domain.MessageCreated:
+		messageChange.Message = &event.Message
+	case domain.MessageUpdated:
+		messageChange.Message = &event.Message
+	}
+	return messageChange
+}
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/auth"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/internal/tenancy"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authenticator checks the bearer token of the authorization metadata, the
+// gRPC counterpart of the HTTP Authorization header, or the API key of the
+// x-api-key metadata when apiKeys is set. Without either, every call is let
+// through. The tenant of the call is then resolved like the one of an HTTP
+// request, from the x-tenant-id metadata and the :authority.
+type authenticator struct {
+	verifier auth.Verifier
+	apiKeys  ports.APIKeyUseCase
+	tenants  tenancy.Resolver
+}
+
+func (a authenticator) unary(ctx context.Context, request any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = a.resolveTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, request)
+}
+
+func (a authenticator) stream(server any, stream grpclib.ServerStream, info *grpclib.StreamServerInfo, handler grpclib.StreamHandler) error {
+	ctx, err := a.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+	ctx, err = a.resolveTenant(ctx)
+	if err != nil {
+		return err
+	}
+	return handler(server, authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+func (a authenticator) authenticate(ctx context.Context) (context.Context, error) {
+	if !a.verifier.Enabled() && a.apiKeys == nil {
+		return ctx, nil
+	}
+
+	if keys := metadata.ValueFromIncomingContext(ctx, "x-api-key"); len(keys) > 0 && a.apiKeys != nil {
+		principal, err := a.apiKeys.Authenticate(ctx, keys[0])
+		if errors.Is(err, apperrors.Unauthorized) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		return domain.ContextWithPrincipal(ctx, principal), nil
+	}
+
+	values := metadata.ValueFromIncomingContext(ctx, "authorization")
+	if len(values) == 0 || !a.verifier.Enabled() {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return nil, status.Error(codes.Unauthenticated, "missing credentials")
+	}
+
+	principal, err := a.verifier.Verify(strings.TrimSpace(token))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return domain.ContextWithPrincipal(ctx, principal), nil
+}
+
+func (a authenticator) resolveTenant(ctx context.Context) (context.Context, error) {
+	tenant, err := a.tenants.Resolve(ctx, firstValue(ctx, ":authority"), firstValue(ctx, strings.ToLower(tenancy.Header)))
+	if errors.Is(err, apperrors.Forbidden) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return domain.ContextWithTenant(ctx, tenant), nil
+}
+
+func firstValue(ctx context.Context, key string) string {
+	if values := metadata.ValueFromIncomingContext(ctx, key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+type authenticatedStream struct {
+	grpclib.ServerStream
+	ctx context.Context
+}
+
+func (s authenticatedStream) Context() context.Context {
+	return s.ctx
+}
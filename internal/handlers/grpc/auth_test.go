@@ -0,0 +1,121 @@
+package grpc
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	messagev1 "github.com/hiago-balbino/hex-architecture-template/api/proto/message/v1"
+	"github.com/hiago-balbino/hex-architecture-template/internal/auth"
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/internal/tenancy"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func TestAuthentication_ShouldRejectCallsWithoutToken(t *testing.T) {
+	client := setupAuthenticatedClient(t, new(mocks.MessageUseCaseMock), nil)
+
+	_, err := client.Get(context.Background(), &messagev1.GetRequest{Id: "message-id"})
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthentication_ShouldRejectStreamsWithInvalidToken(t *testing.T) {
+	client := setupAuthenticatedClient(t, new(mocks.MessageUseCaseMock), nil)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid")
+
+	stream, err := client.Watch(ctx, &messagev1.WatchRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthentication_ShouldPassPrincipalToService(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.MatchedBy(func(ctx context.Context) bool {
+		principal, ok := domain.PrincipalFromContext(ctx)
+		return ok && principal.Subject == "user-1"
+	}), "message-id").Return(domain.NewMessage("message-id", "message content"), nil)
+	client := setupAuthenticatedClient(t, serviceMock, nil)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+signToken(t, "user-1"))
+
+	message, err := client.Get(ctx, &messagev1.GetRequest{Id: "message-id"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "message-id", message.GetId())
+}
+
+func TestAuthentication_ShouldAcceptAPIKey(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.MatchedBy(func(ctx context.Context) bool {
+		principal, ok := domain.PrincipalFromContext(ctx)
+		return ok && principal.Subject == "apikey:key-id"
+	}), "message-id").Return(domain.NewMessage("message-id", "message content"), nil)
+	apiKeysMock := new(mocks.APIKeyUseCaseMock)
+	apiKeysMock.On("Authenticate", mock.Anything, "hexapi_key-id_secret").Return(domain.NewPrincipal("apikey:key-id", nil), nil)
+	apiKeysMock.On("Authenticate", mock.Anything, "hexapi_key-id_other").Return(domain.Principal{}, errors.Join(apperrors.Unauthorized, errors.New("invalid api key")))
+	client := setupAuthenticatedClient(t, serviceMock, apiKeysMock)
+
+	_, err := client.Get(metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "hexapi_key-id_other"), &messagev1.GetRequest{Id: "message-id"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	message, err := client.Get(metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "hexapi_key-id_secret"), &messagev1.GetRequest{Id: "message-id"})
+	assert.NoError(t, err)
+	assert.Equal(t, "message-id", message.GetId())
+}
+
+func TestAuthentication_ShouldResolveTenantOfCall(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.MatchedBy(func(ctx context.Context) bool {
+		return domain.TenantFromContext(ctx) == "acme"
+	}), "message-id").Return(domain.NewMessage("message-id", "message content"), nil)
+	apiKeysMock := new(mocks.APIKeyUseCaseMock)
+	apiKeysMock.On("Authenticate", mock.Anything, "hexapi_key-id_secret").Return(domain.NewPrincipal("apikey:key-id", nil).InTenant("acme"), nil)
+	client := setupAuthenticatedClient(t, serviceMock, apiKeysMock, tenancy.Tenant{ID: "acme"}, tenancy.Tenant{ID: "globex"})
+
+	_, err := client.Get(metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "hexapi_key-id_secret", "x-tenant-id", "globex"), &messagev1.GetRequest{Id: "message-id"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	message, err := client.Get(metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "hexapi_key-id_secret"), &messagev1.GetRequest{Id: "message-id"})
+	assert.NoError(t, err)
+	assert.Equal(t, "message-id", message.GetId())
+}
+
+func setupAuthenticatedClient(t *testing.T, service *mocks.MessageUseCaseMock, apiKeys ports.APIKeyUseCase, tenants ...tenancy.Tenant) messagev1.MessageServiceClient {
+	key, err := auth.NewHMACKey("", testSecret)
+	assert.NoError(t, err)
+	verifier := auth.NewVerifier([]auth.Key{key}, "", "", time.Minute, clock.NewSystemClock())
+	resolver, err := tenancy.NewResolver(tenants, "")
+	assert.NoError(t, err)
+	return messagev1.NewMessageServiceClient(setupConn(t, NewServer(service, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), verifier, apiKeys, resolver)))
+}
+
+func signToken(t *testing.T, subject string) string {
+	header, err := json.Marshal(map[string]any{"alg": auth.HS256})
+	assert.NoError(t, err)
+	claims, err := json.Marshal(map[string]any{"sub": subject, "exp": time.Now().Add(time.Hour).Unix()})
+	assert.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	mac := hmac.New(sha256.New, testSecret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"time"
+
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func loggingUnaryInterceptor(ctx context.Context, request any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+	startedAt := time.Now()
+	response, err := handler(ctx, request)
+	log.Printf("[GRPC] %s | %s | %v", info.FullMethod, status.Code(err), time.Since(startedAt))
+	return response, err
+}
+
+func loggingStreamInterceptor(server any, stream grpclib.ServerStream, info *grpclib.StreamServerInfo, handler grpclib.StreamHandler) error {
+	startedAt := time.Now()
+	err := handler(server, stream)
+	log.Printf("[GRPC] %s | %s | %v", info.FullMethod, status.Code(err), time.Since(startedAt))
+	return err
+}
+
+func recoveryUnaryInterceptor(ctx context.Context, request any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (response any, err error) {
+	defer recoverStatus(info.FullMethod, &err)
+	return handler(ctx, request)
+}
+
+func recoveryStreamInterceptor(server any, stream grpclib.ServerStream, info *grpclib.StreamServerInfo, handler grpclib.StreamHandler) (err error) {
+	defer recoverStatus(info.FullMethod, &err)
+	return handler(server, stream)
+}
+
+func recoverStatus(method string, err *error) {
+	if recovered := recover(); recovered != nil {
+		log.Printf("[GRPC] %s panicked: %v\n%s", method, recovered, debug.Stack())
+		*err = status.Error(codes.Internal, "internal server error")
+	}
+}
@@ -0,0 +1,179 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	messagev1 "github.com/hiago-balbino/hex-architecture-template/api/proto/message/v1"
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type messageServer struct {
+	messagev1.UnimplementedMessageServiceServer
+	service ports.MessageUseCase
+	feed    *changefeed.Feed
+}
+
+func NewMessageServer(service ports.MessageUseCase, feed *changefeed.Feed) messageServer {
+	return messageServer{
+		service: service,
+		feed:    feed,
+	}
+}
+
+func (s messageServer) Create(ctx context.Context, request *messagev1.CreateRequest) (*messagev1.Message, error) {
+	message, err := s.service.Save(ctx, request.GetContent())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toMessage(message), nil
+}
+
+func (s messageServer) Get(ctx context.Context, request *messagev1.GetRequest) (*messagev1.Message, error) {
+	message, err := s.service.GetByID(ctx, request.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toMessage(message), nil
+}
+
+func (s messageServer) List(ctx context.Context, request *messagev1.ListRequest) (*messagev1.ListResponse, error) {
+	messages, err := s.service.GetAll(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	response := &messagev1.ListResponse{Messages: make([]*messagev1.Message, len(messages))}
+	for i, message := range messages {
+		response.Messages[i] = toMessage(message)
+	}
+	return response, nil
+}
+
+func (s messageServer) Delete(ctx context.Context, request *messagev1.DeleteRequest) (*messagev1.DeleteResponse, error) {
+	if err := s.service.DeleteByID(ctx, request.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &messagev1.DeleteResponse{}, nil
+}
+
+func (s messageServer) Watch(request *messagev1.WatchRequest, stream messagev1.MessageService_WatchServer) error {
+	for _, event := range request.GetEvents() {
+		if !domain.IsKnownEvent(event) {
+			return status.Errorf(codes.InvalidArgument, "unknown event %q", event)
+		}
+	}
+
+	subscription, err := s.subscribe(stream.Context(), request)
+	if err != nil {
+		return err
+	}
+	defer subscription.Close()
+
+	// Headers tell the client the subscription is in place before any change.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case change, ok := <-subscription.Changes():
+			if !ok {
+				return watchEndStatus(subscription.Err())
+			}
+			if err := stream.Send(toMessageChange(change)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s messageServer) subscribe(ctx context.Context, request *messagev1.WatchRequest) (*changefeed.Subscription, error) {
+	events := request.GetEvents()
+	filter := func(event domain.Event) bool {
+		return len(events) == 0 || slices.Contains(events, event.EventName())
+	}
+
+	if request.AfterId == nil {
+		subscription, err := s.feed.Subscribe(ctx, filter)
+		if err != nil {
+			return nil, watchEndStatus(err)
+		}
+		return subscription, nil
+	}
+
+	subscription, complete, err := s.feed.SubscribeAfter(ctx, request.GetAfterId(), filter)
+	if err != nil {
+		return nil, watchEndStatus(err)
+	}
+	if !complete {
+		subscription.Close()
+		return nil, status.Error(codes.OutOfRange, "changes after after_id are no longer available")
+	}
+	return subscription, nil
+}
+
+func watchEndStatus(err error) error {
+	switch {
+	case errors.Is(err, changefeed.ErrLagged):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, changefeed.ErrClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return nil
+	}
+}
+
+// toStatus maps the application errors to the closest gRPC codes.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, apperrors.NotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, apperrors.Forbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, apperrors.InvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, apperrors.UnprocessableEntity):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, apperrors.Aborted):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, apperrors.TooManyRequests):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func toMessage(message domain.Message) *messagev1.Message {
+	return &messagev1.Message{
+		Id:      message.ID,
+		Content: message.Content,
+	}
+}
+
+func toMessageChange(change changefeed.Change) *messagev1.MessageChange {
+	messageChange := &messagev1.MessageChange{
+		Id:          change.ID,
+		Event:       change.Event.EventName(),
+		AggregateId: change.Event.AggregateID(),
+		OccurredAt:  timestamppb.New(change.Event.OccurredAt()),
+	}
+
+	switch event := change.Event.(type) {
+	case domain.MessageCreated:
+		messageChange.Message = toMessage(event.Message)
+	case domain.MessageUpdated:
+		messageChange.Message = toMessage(event.Message)
+	}
+	return messageChange
+}
@@ -0,0 +1,219 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	messagev1 "github.com/hiago-balbino/hex-architecture-template/api/proto/message/v1"
+	"github.com/hiago-balbino/hex-architecture-template/internal/auth"
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/internal/tenancy"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCreate_ShouldReturnCreatedMessage(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, "message content").Return(domain.NewMessage("message-id", "message content"), nil)
+
+	client := setupClient(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()))
+	message, err := client.Create(context.Background(), &messagev1.CreateRequest{Content: "message content"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "message-id", message.GetId())
+	assert.Equal(t, "message content", message.GetContent())
+}
+
+func TestCreate_ShouldReturnInvalidArgumentWhenContentIsInvalid(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, "").Return(domain.Message{}, apperrors.InvalidInput)
+
+	client := setupClient(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()))
+	_, err := client.Create(context.Background(), &messagev1.CreateRequest{})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGet_ShouldReturnNotFoundWhenMessageDoesNotExist(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.Anything, "message-id").Return(domain.Message{}, errors.Join(apperrors.NotFound, errors.New("message id not found")))
+
+	client := setupClient(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()))
+	_, err := client.Get(context.Background(), &messagev1.GetRequest{Id: "message-id"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestList_ShouldReturnAllMessages(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetAll", mock.Anything).Return([]domain.Message{
+		domain.NewMessage("id1", "first content"),
+		domain.NewMessage("id2", "second content"),
+	}, nil)
+
+	client := setupClient(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()))
+	response, err := client.List(context.Background(), &messagev1.ListRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, response.GetMessages(), 2)
+	assert.Equal(t, "id2", response.GetMessages()[1].GetId())
+}
+
+func TestDelete_ShouldReturnInternalWhenServiceFails(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("DeleteByID", mock.Anything, "message-id").Return(errors.New("unexpected error"))
+
+	client := setupClient(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()))
+	_, err := client.Delete(context.Background(), &messagev1.DeleteRequest{Id: "message-id"})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestCreate_ShouldReturnInternalWhenServicePanics(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, "message content").Panic("unexpected panic")
+
+	client := setupClient(t, serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()))
+	_, err := client.Create(context.Background(), &messagev1.CreateRequest{Content: "message content"})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestWatch_ShouldStreamMatchingChanges(t *testing.T) {
+	feed := changefeed.NewFeed(10, 10, policy.NewRolePolicy())
+	client := setupClient(t, new(mocks.MessageUseCaseMock), feed)
+
+	stream, err := client.Watch(context.Background(), &messagev1.WatchRequest{Events: []string{domain.EventMessageCreated}})
+	assert.NoError(t, err)
+	waitForHeader(t, stream)
+
+	occurredAt := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, feed.Handle(context.Background(), domain.NewMessageDeleted("id1", occurredAt)))
+	assert.NoError(t, feed.Handle(context.Background(), domain.NewMessageCreated(domain.NewMessage("id2", "message content"), occurredAt)))
+
+	change, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), change.GetId())
+	assert.Equal(t, domain.EventMessageCreated, change.GetEvent())
+	assert.Equal(t, "id2", change.GetAggregateId())
+	assert.Equal(t, occurredAt, change.GetOccurredAt().AsTime())
+	assert.Equal(t, "message content", change.GetMessage().GetContent())
+}
+
+func TestWatch_ShouldReplayChangesAfterID(t *testing.T) {
+	feed := changefeed.NewFeed(10, 10, policy.NewRolePolicy())
+	for _, id := range []string{"id1", "id2", "id3"} {
+		assert.NoError(t, feed.Handle(context.Background(), domain.NewMessageDeleted(id, time.Now())))
+	}
+
+	client := setupClient(t, new(mocks.MessageUseCaseMock), feed)
+	stream, err := client.Watch(context.Background(), &messagev1.WatchRequest{AfterId: proto.Uint64(1)})
+	assert.NoError(t, err)
+
+	for _, id := range []uint64{2, 3} {
+		change, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, id, change.GetId())
+		assert.Nil(t, change.GetMessage())
+	}
+}
+
+func TestWatch_ShouldReturnOutOfRangeWhenReplayIsIncomplete(t *testing.T) {
+	feed := changefeed.NewFeed(1, 10, policy.NewRolePolicy())
+	for _, id := range []string{"id1", "id2", "id3"} {
+		assert.NoError(t, feed.Handle(context.Background(), domain.NewMessageDeleted(id, time.Now())))
+	}
+
+	client := setupClient(t, new(mocks.MessageUseCaseMock), feed)
+	stream, err := client.Watch(context.Background(), &messagev1.WatchRequest{AfterId: proto.Uint64(1)})
+	assert.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+}
+
+func TestWatch_ShouldReturnInvalidArgumentWhenEventIsUnknown(t *testing.T) {
+	client := setupClient(t, new(mocks.MessageUseCaseMock), changefeed.NewFeed(10, 10, policy.NewRolePolicy()))
+	stream, err := client.Watch(context.Background(), &messagev1.WatchRequest{Events: []string{"message.archived"}})
+	assert.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestWatch_ShouldReturnUnavailableWhenFeedIsClosed(t *testing.T) {
+	feed := changefeed.NewFeed(10, 10, policy.NewRolePolicy())
+	client := setupClient(t, new(mocks.MessageUseCaseMock), feed)
+
+	stream, err := client.Watch(context.Background(), &messagev1.WatchRequest{})
+	assert.NoError(t, err)
+	waitForHeader(t, stream)
+	feed.Close()
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestServer_ShouldRegisterReflection(t *testing.T) {
+	conn := setupConn(t, NewServer(new(mocks.MessageUseCaseMock), changefeed.NewFeed(10, 10, policy.NewRolePolicy()), auth.Verifier{}, nil, tenancy.Resolver{}))
+	stream, err := grpc_reflection_v1alpha.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	assert.NoError(t, err)
+
+	err = stream.Send(&grpc_reflection_v1alpha.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1alpha.ServerReflectionRequest_ListServices{},
+	})
+	assert.NoError(t, err)
+
+	response, err := stream.Recv()
+	assert.NoError(t, err)
+
+	var services []string
+	for _, service := range response.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, messagev1.MessageService_ServiceDesc.ServiceName)
+}
+
+func setupClient(t *testing.T, service *mocks.MessageUseCaseMock, feed *changefeed.Feed) messagev1.MessageServiceClient {
+	return messagev1.NewMessageServiceClient(setupConn(t, NewServer(service, feed, auth.Verifier{}, nil, tenancy.Resolver{})))
+}
+
+func setupConn(t *testing.T, server *grpclib.Server) *grpclib.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpclib.DialContext(context.Background(), "bufnet",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// waitForHeader blocks until the server has started handling the stream, so
+// the subscription exists before changes are published.
+func waitForHeader(t *testing.T, stream messagev1.MessageService_WatchClient) {
+	if _, err := stream.Header(); err != nil {
+		t.Fatal(err)
+	}
+}
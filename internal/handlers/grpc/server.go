@@ -0,0 +1,25 @@
+package grpc
+
+import (
+	messagev1 "github.com/hiago-balbino/hex-architecture-template/api/proto/message/v1"
+	"github.com/hiago-balbino/hex-architecture-template/internal/auth"
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/internal/tenancy"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// NewServer builds the gRPC server. Recovery runs innermost so that the
+// logging interceptor sees the status a panic was turned into, and rejected
+// credentials are logged too.
+func NewServer(service ports.MessageUseCase, feed *changefeed.Feed, verifier auth.Verifier, apiKeys ports.APIKeyUseCase, tenants tenancy.Resolver) *grpclib.Server {
+	authenticator := authenticator{verifier: verifier, apiKeys: apiKeys, tenants: tenants}
+	server := grpclib.NewServer(
+		grpclib.ChainUnaryInterceptor(loggingUnaryInterceptor, authenticator.unary, recoveryUnaryInterceptor),
+		grpclib.ChainStreamInterceptor(loggingStreamInterceptor, authenticator.stream, recoveryStreamInterceptor),
+	)
+	messagev1.RegisterMessageServiceServer(server, NewMessageServer(service, feed))
+	reflection.Register(server)
+	return server
+}
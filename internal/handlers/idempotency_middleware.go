@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+var (
+	errInvalidIdempotencyKey = errors.New("idempotency key must have between 1 and 255 characters")
+	errIdempotencyKeyReused  = errors.New("idempotency key was already used with a different payload")
+)
+
+type idempotencyMiddleware struct {
+	repository ports.IdempotencyRepository
+	clock      clock.Clock
+	ttl        time.Duration
+	locks      *keyedMutex
+}
+
+func NewIdempotencyMiddleware(repository ports.IdempotencyRepository, clock clock.Clock, ttl time.Duration) idempotencyMiddleware {
+	return idempotencyMiddleware{
+		repository: repository,
+		clock:      clock,
+		ttl:        ttl,
+		locks:      newKeyedMutex(),
+	}
+}
+
+func (m idempotencyMiddleware) handle(c *gin.Context) {
+	if _, ok := c.Request.Header[idempotencyKeyHeader]; !ok {
+		c.Next()
+		return
+	}
+
+	key := c.GetHeader(idempotencyKeyHeader)
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		c.AbortWithStatusJSON(400, gin.H{"error": errors.Join(apperrors.InvalidInput, errInvalidIdempotencyKey).Error()})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.AbortWithStatusJSON(400, gin.H{"error": errors.Join(apperrors.InvalidInput, err).Error()})
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	fingerprint := fingerprintRequest(c.Request, body)
+	key = scopedIdempotencyKey(c.Request.Context(), key)
+
+	unlock := m.locks.lock(key)
+	defer unlock()
+
+	record, err := m.repository.GetByKey(c.Request.Context(), key)
+	if err == nil {
+		if record.Fingerprint != fingerprint {
+			c.AbortWithStatusJSON(422, gin.H{"error": errors.Join(apperrors.UnprocessableEntity, errIdempotencyKeyReused).Error()})
+			return
+		}
+		c.Header(idempotentReplayedHeader, "true")
+		c.Data(record.StatusCode, record.ContentType, record.Body)
+		c.Abort()
+		return
+	}
+	if !errors.Is(err, apperrors.NotFound) {
+		c.AbortWithStatusJSON(500, gin.H{"error": errors.Join(apperrors.InternalServerError, err).Error()})
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+	c.Next()
+
+	if isTransientStatus(recorder.Status()) {
+		return
+	}
+
+	record = domain.NewIdempotencyRecord(
+		key,
+		fingerprint,
+		recorder.Status(),
+		recorder.Header().Get("Content-Type"),
+		recorder.body.Bytes(),
+		m.clock.Now().Add(m.ttl),
+	)
+	if err := m.repository.Save(c.Request.Context(), record); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// isTransientStatus reports whether a response may change when the request is
+// retried, such as a rate limit, an exceeded quota or a server failure, so it
+// must not be replayed for the key.
+func isTransientStatus(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+		return true
+	}
+	return status >= http.StatusInternalServerError
+}
+
+// scopedIdempotencyKey keeps tenants, and the principals within a tenant, from
+// replaying the responses of one another. Without a principal, the keys of the
+// default tenant are left as they were stored before tenants.
+func scopedIdempotencyKey(ctx context.Context, key string) string {
+	tenant := domain.TenantFromContext(ctx)
+	if principal, ok := domain.PrincipalFromContext(ctx); ok {
+		return tenant + "/" + url.PathEscape(principal.Subject) + "/" + key
+	}
+	if tenant == domain.DefaultTenant {
+		return key
+	}
+	return tenant + "/" + key
+}
+
+func fingerprintRequest(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(r.URL.Path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(data string) (int, error) {
+	r.body.WriteString(data)
+	return r.ResponseWriter.WriteString(data)
+}
+
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedLock
+}
+
+type keyedLock struct {
+	mu      sync.Mutex
+	holders int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[string]*keyedLock)}
+}
+
+func (k *keyedMutex) lock(key string) func() {
+	k.mu.Lock()
+	lock, ok := k.locks[key]
+	if !ok {
+		lock = &keyedLock{}
+		k.locks[key] = lock
+	}
+	lock.holders++
+	k.mu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+
+		k.mu.Lock()
+		lock.holders--
+		if lock.holders == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
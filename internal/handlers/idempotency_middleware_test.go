@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hiago-balbino/hex-architecture-template/internal/auth"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	usecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/message"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/internal/repositories/memory"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIdempotency_ShouldReturnErrorWhenKeyIsInvalid(t *testing.T) {
+	handler := setupIdempotentHandler(nil, nil)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/message").
+		WithHeader(idempotencyKeyHeader, strings.Repeat("k", maxIdempotencyKeyLength+1)).
+		WithJSON(dto.CreateMessageRequest{Content: "message content"}).
+		Expect().Status(http.StatusBadRequest).
+		Body().Contains(apperrors.InvalidInput.Error())
+}
+
+func TestIdempotency_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
+	key := uuid.NewString()
+	unexpectedError := errors.New("unexpected error")
+
+	repositoryMock := new(mocks.IdempotencyRepositoryMock)
+	repositoryMock.On("GetByKey", mock.Anything, key).Return(domain.IdempotencyRecord{}, unexpectedError)
+
+	handler := setupIdempotentHandler(nil, repositoryMock)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/message").
+		WithHeader(idempotencyKeyHeader, key).
+		WithJSON(dto.CreateMessageRequest{Content: "message content"}).
+		Expect().Status(http.StatusInternalServerError).
+		Body().Contains(unexpectedError.Error())
+}
+
+func TestIdempotency_ShouldReplayFirstResponseWhenKeyIsReused(t *testing.T) {
+	key := uuid.NewString()
+	body := dto.CreateMessageRequest{Content: "message content"}
+	message := domain.NewMessage(uuid.NewString(), body.Content)
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, body.Content).Return(message, nil).Once()
+
+	handler := setupIdempotentHandler(serviceMock, memory.NewIdempotencyStorage(clock.NewSystemClock()))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	first := e.POST("/message").
+		WithHeader(idempotencyKeyHeader, key).
+		WithJSON(body).
+		Expect().Status(http.StatusCreated)
+	first.Header(idempotentReplayedHeader).IsEmpty()
+
+	replay := e.POST("/message").
+		WithHeader(idempotencyKeyHeader, key).
+		WithJSON(body).
+		Expect().Status(http.StatusCreated)
+	replay.Header(idempotentReplayedHeader).IsEqual("true")
+	replay.Body().IsEqual(first.Body().Raw())
+
+	serviceMock.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestIdempotency_ShouldReturnErrorWhenKeyIsReusedWithDifferentPayload(t *testing.T) {
+	key := uuid.NewString()
+	body := dto.CreateMessageRequest{Content: "message content"}
+	message := domain.NewMessage(uuid.NewString(), body.Content)
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, body.Content).Return(message, nil).Once()
+
+	handler := setupIdempotentHandler(serviceMock, memory.NewIdempotencyStorage(clock.NewSystemClock()))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/message").
+		WithHeader(idempotencyKeyHeader, key).
+		WithJSON(body).
+		Expect().Status(http.StatusCreated)
+
+	e.POST("/message").
+		WithHeader(idempotencyKeyHeader, key).
+		WithJSON(dto.CreateMessageRequest{Content: "another content"}).
+		Expect().Status(http.StatusUnprocessableEntity).
+		Body().Contains(apperrors.UnprocessableEntity.Error())
+
+	serviceMock.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestIdempotency_ShouldNotStoreResponseWhenServerFails(t *testing.T) {
+	key := uuid.NewString()
+	body := dto.CreateMessageRequest{Content: "message content"}
+	message := domain.NewMessage(uuid.NewString(), body.Content)
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, body.Content).Return(domain.Message{}, errors.New("unexpected error")).Once()
+	serviceMock.On("Save", mock.Anything, body.Content).Return(message, nil).Once()
+
+	handler := setupIdempotentHandler(serviceMock, memory.NewIdempotencyStorage(clock.NewSystemClock()))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/message").
+		WithHeader(idempotencyKeyHeader, key).
+		WithJSON(body).
+		Expect().Status(http.StatusInternalServerError)
+
+	response := dto.CreateMessageResponse{}
+	e.POST("/message").
+		WithHeader(idempotencyKeyHeader, key).
+		WithJSON(body).
+		Expect().Status(http.StatusCreated).
+		JSON().Decode(&response)
+
+	assert.Equal(t, message.ID, response.ID)
+	serviceMock.AssertNumberOfCalls(t, "Save", 2)
+}
+
+func TestIdempotency_ShouldNotStoreResponseWhenQuotaIsExceeded(t *testing.T) {
+	key := uuid.NewString()
+	body := dto.CreateMessageRequest{Content: "message content"}
+	message := domain.NewMessage(uuid.NewString(), body.Content)
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, body.Content).Return(domain.Message{}, apperrors.TooManyRequests).Once()
+	serviceMock.On("Save", mock.Anything, body.Content).Return(message, nil).Once()
+
+	handler := setupIdempotentHandler(serviceMock, memory.NewIdempotencyStorage(clock.NewSystemClock()))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/message").
+		WithHeader(idempotencyKeyHeader, key).
+		WithJSON(body).
+		Expect().Status(http.StatusTooManyRequests)
+
+	response := dto.CreateMessageResponse{}
+	e.POST("/message").
+		WithHeader(idempotencyKeyHeader, key).
+		WithJSON(body).
+		Expect().Status(http.StatusCreated).
+		JSON().Decode(&response)
+	e.POST("/message").
+		WithHeader(idempotencyKeyHeader, key).
+		WithJSON(body).
+		Expect().Status(http.StatusCreated).
+		Header(idempotentReplayedHeader).IsEqual("true")
+
+	assert.Equal(t, message.ID, response.ID)
+	serviceMock.AssertNumberOfCalls(t, "Save", 2)
+}
+
+func TestIdempotency_ShouldNotReplayResponseOfOtherPrincipalOfTenant(t *testing.T) {
+	key, err := auth.NewHMACKey("", testSecret)
+	assert.NoError(t, err)
+	messages := memory.NewMessageStorage()
+	service := usecases.NewMessageService(identifier.NewUUIDGenerator(), clock.NewSystemClock(), messages, memory.NewUnitOfWork(messages, memory.NewOutboxStorage(), memory.NewAuditStorage()), policy.NewRolePolicy())
+	handler := Server{
+		messagehdl:        NewMessageHandler(service),
+		authentication:    NewAuthMiddleware(auth.NewVerifier([]auth.Key{key}, "", "", time.Minute, clock.NewSystemClock()), nil),
+		idempotency:       NewIdempotencyMiddleware(memory.NewIdempotencyStorage(clock.NewSystemClock()), clock.NewSystemClock(), time.Hour),
+		validateResponses: true,
+	}.setupRoutes()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+	first := "Bearer " + signToken(t, map[string]any{"sub": "user-1", "scope": "writer", "exp": time.Now().Add(time.Hour).Unix()})
+	second := "Bearer " + signToken(t, map[string]any{"sub": "user-2", "scope": "writer", "exp": time.Now().Add(time.Hour).Unix()})
+	body := dto.CreateMessageRequest{Content: "message content"}
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/v2/messages").WithHeader("Authorization", first).WithHeader(idempotencyKeyHeader, "create-1").
+		WithJSON(body).
+		Expect().Status(http.StatusCreated).
+		JSON().Object().Value("owner").IsEqual("user-1")
+	created := e.POST("/v2/messages").WithHeader("Authorization", second).WithHeader(idempotencyKeyHeader, "create-1").
+		WithJSON(body).
+		Expect().Status(http.StatusCreated)
+
+	created.Header(idempotentReplayedHeader).IsEmpty()
+	created.JSON().Object().Value("owner").IsEqual("user-2")
+	e.POST("/v2/messages").WithHeader("Authorization", first).WithHeader(idempotencyKeyHeader, "create-1").
+		WithJSON(body).
+		Expect().Status(http.StatusCreated).
+		Header(idempotentReplayedHeader).IsEqual("true")
+}
+
+func setupIdempotentHandler(service ports.MessageUseCase, repository ports.IdempotencyRepository) *gin.Engine {
+	server := Server{
+		messagehdl:        NewMessageHandler(service),
+		idempotency:       NewIdempotencyMiddleware(repository, clock.NewSystemClock(), time.Hour),
+		validateResponses: true,
+	}
+	return server.setupRoutes()
+}
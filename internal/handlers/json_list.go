@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+const jsonContentType = "application/json; charset=utf-8"
+
+// jsonList writes a list response as its messages arrive, with the same bytes
+// c.JSON would write for the whole list. Nothing is sent before the first
+// message, so a failure up to then still gets a proper error response.
+type jsonList struct {
+	c       *gin.Context
+	mapper  messageMapper
+	written int
+}
+
+func newJSONList(c *gin.Context, mapper messageMapper) *jsonList {
+	return &jsonList{
+		c:      c,
+		mapper: mapper,
+	}
+}
+
+func (l *jsonList) write(message domain.Message) error {
+	data, err := json.Marshal(l.mapper.message(message))
+	if err != nil {
+		return err
+	}
+
+	separator := ","
+	if l.written == 0 {
+		separator, _ = l.mapper.listEnvelope()
+		l.start()
+	}
+	l.written++
+	if _, err := l.c.Writer.WriteString(separator); err != nil {
+		return err
+	}
+	_, err = l.c.Writer.Write(data)
+	return err
+}
+
+func (l *jsonList) close() {
+	prefix, suffix := l.mapper.listEnvelope()
+	if l.written == 0 {
+		l.start()
+		_, _ = l.c.Writer.WriteString(prefix)
+	}
+	_, _ = l.c.Writer.WriteString(suffix)
+}
+
+// fail can only report the error when nothing was written yet. Otherwise the
+// body is left as invalid JSON, which clients cannot mistake for a list.
+func (l *jsonList) fail(err error) {
+	if l.written == 0 {
+		status := 500
+		if errors.Is(err, apperrors.Forbidden) {
+			status = 403
+		}
+		l.c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	_ = l.c.Error(err)
+}
+
+func (l *jsonList) start() {
+	l.c.Header("Content-Type", jsonContentType)
+	l.c.Status(200)
+}
@@ -5,36 +5,55 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
 	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
 	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/codec"
 	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
 )
 
 type messageHandler struct {
 	service ports.MessageUseCase
+	mapper  messageMapper
 }
 
+// NewMessageHandler returns a handler for the v1 API; withMapper derives the
+// handlers of the other versions.
 func NewMessageHandler(service ports.MessageUseCase) messageHandler {
 	return messageHandler{
 		service: service,
+		mapper:  v1Mapper{},
 	}
 }
 
+func (h messageHandler) withMapper(mapper messageMapper) messageHandler {
+	h.mapper = mapper
+	return h
+}
+
 func (h messageHandler) createMessage(c *gin.Context) {
 	var messageReqDto dto.CreateMessageRequest
 	err := c.BindJSON(&messageReqDto)
 	if err != nil {
-		c.JSON(400, gin.H{"error": errors.Join(apperrors.InvalidInput, err).Error()})
+		respond(c, 400, gin.H{"error": errors.Join(apperrors.InvalidInput, err).Error()})
 		return
 	}
 
 	message, err := h.service.Save(c.Request.Context(), messageReqDto.Content)
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		if errors.Is(err, apperrors.Forbidden) {
+			respond(c, 403, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, apperrors.TooManyRequests) {
+			respond(c, 429, gin.H{"error": err.Error()})
+			return
+		}
+		respond(c, 500, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(201, dto.BuildResponseCreateMessage(message.ID))
+	respond(c, 201, h.mapper.createdMessage(message))
 }
 
 func (h messageHandler) getMessage(c *gin.Context) {
@@ -43,34 +62,123 @@ func (h messageHandler) getMessage(c *gin.Context) {
 	message, err := h.service.GetByID(c.Request.Context(), messageID)
 	if err != nil {
 		if errors.Is(err, apperrors.NotFound) {
-			c.JSON(404, gin.H{"error": err.Error()})
+			respond(c, 404, gin.H{"error": err.Error()})
 			return
 		}
-		c.JSON(500, gin.H{"error": err.Error()})
+		if errors.Is(err, apperrors.Forbidden) {
+			respond(c, 403, gin.H{"error": err.Error()})
+			return
+		}
+		respond(c, 500, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(200, dto.BuildResponseGetMessage(message))
+	respond(c, 200, h.mapper.message(message))
 }
 
+// getMessages streams JSON lists. The other media types need the whole list
+// before encoding it.
 func (h messageHandler) getMessages(c *gin.Context) {
+	if negotiatedCodec(c).MediaType() == codec.MediaTypeJSON {
+		list := newJSONList(c, h.mapper)
+		if err := h.service.ForEach(c.Request.Context(), "", list.write); err != nil {
+			list.fail(err)
+			return
+		}
+		list.close()
+		return
+	}
+
 	messages, err := h.service.GetAll(c.Request.Context())
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		if errors.Is(err, apperrors.Forbidden) {
+			respond(c, 403, gin.H{"error": err.Error()})
+			return
+		}
+		respond(c, 500, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(200, dto.BuildResponseGetMessages(messages))
+	respond(c, 200, h.mapper.messages(messages))
 }
 
 func (h messageHandler) deleteMessage(c *gin.Context) {
 	messageID := c.Param("id")
 
 	err := h.service.DeleteByID(c.Request.Context(), messageID)
+	if errors.Is(err, apperrors.NotFound) && h.mapper.deleteNotFound() {
+		respond(c, 404, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, apperrors.Forbidden) {
+		respond(c, 403, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil && !errors.Is(err, apperrors.NotFound) {
-		c.JSON(500, gin.H{"error": err.Error()})
+		respond(c, 500, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+func (h messageHandler) createMessages(c *gin.Context) {
+	var batchReqDto dto.BatchCreateMessagesRequest
+	err := c.BindJSON(&batchReqDto)
+	if err != nil {
+		respond(c, 400, gin.H{"error": errors.Join(apperrors.InvalidInput, err).Error()})
+		return
+	}
+
+	contents := make([]string, len(batchReqDto.Messages))
+	for i, messageReqDto := range batchReqDto.Messages {
+		contents[i] = messageReqDto.Content
+	}
+
+	mode := batchMode(batchReqDto.Mode)
+	results, err := h.service.SaveBatch(c.Request.Context(), contents, mode)
+	h.respondBatch(c, mode, results, err, http.StatusCreated, dto.BatchItemStatusCreated)
+}
+
+func (h messageHandler) deleteMessages(c *gin.Context) {
+	var batchReqDto dto.BatchDeleteMessagesRequest
+	err := c.BindJSON(&batchReqDto)
+	if err != nil {
+		respond(c, 400, gin.H{"error": errors.Join(apperrors.InvalidInput, err).Error()})
+		return
+	}
+
+	mode := batchMode(batchReqDto.Mode)
+	results, err := h.service.DeleteBatch(c.Request.Context(), batchReqDto.IDs, mode)
+	h.respondBatch(c, mode, results, err, http.StatusOK, dto.BatchItemStatusDeleted)
+}
+
+func (h messageHandler) respondBatch(c *gin.Context, mode domain.BatchMode, results []domain.BatchResult, err error, succeededCode int, succeededStatus string) {
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.UnprocessableEntity):
+			respond(c, 422, dto.BuildResponseBatchMessages(mode, results, succeededStatus))
+		case errors.Is(err, apperrors.InvalidInput):
+			respond(c, 400, gin.H{"error": err.Error()})
+		case errors.Is(err, apperrors.Forbidden):
+			respond(c, 403, gin.H{"error": err.Error()})
+		case errors.Is(err, apperrors.TooManyRequests):
+			respond(c, 429, gin.H{"error": err.Error()})
+		default:
+			respond(c, 500, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	if domain.HasBatchFailures(results) {
+		succeededCode = http.StatusMultiStatus
+	}
+	respond(c, succeededCode, dto.BuildResponseBatchMessages(mode, results, succeededStatus))
+}
+
+func batchMode(mode string) domain.BatchMode {
+	if mode == "" {
+		return domain.BatchModeAtomic
+	}
+	return domain.BatchMode(mode)
+}
@@ -86,6 +86,23 @@ func TestGetMessage_ShouldReturnErrorWhenMessageNotFound(t *testing.T) {
 		Body().Contains(apperrors.NotFound.Error())
 }
 
+func TestGetMessage_ShouldReturnForbiddenWhenPolicyDenies(t *testing.T) {
+	messageID := uuid.NewString()
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.Anything, messageID).Return(domain.Message{}, apperrors.Forbidden)
+
+	handler := setupHandler(serviceMock)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/v2/messages/{id}").
+		WithPath("id", messageID).
+		Expect().Status(http.StatusForbidden).
+		Body().Contains(apperrors.Forbidden.Error())
+}
+
 func TestGetMessage_ShouldReturnErrorWhenFailsToGetMessage(t *testing.T) {
 	messageID := uuid.NewString()
 	unexpectedError := errors.New("unexpected error")
@@ -129,7 +146,7 @@ func TestGetMessages_ShouldReturnErrorWhenFailsToGetMessages(t *testing.T) {
 	unexpectedError := errors.New("unexpected error")
 
 	serviceMock := new(mocks.MessageUseCaseMock)
-	serviceMock.On("GetAll", mock.Anything).Return([]domain.Message{}, unexpectedError)
+	serviceMock.On("ForEach", mock.Anything).Return([]domain.Message{}, unexpectedError)
 
 	handler := setupHandler(serviceMock)
 	server := httptest.NewServer(handler)
@@ -141,6 +158,21 @@ func TestGetMessages_ShouldReturnErrorWhenFailsToGetMessages(t *testing.T) {
 		Body().Contains(unexpectedError.Error())
 }
 
+func TestGetMessages_ShouldLeaveListUnterminatedWhenFailingMidway(t *testing.T) {
+	message := domain.NewMessage(uuid.NewString(), "message content")
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("ForEach", mock.Anything).Return([]domain.Message{message}, apperrors.InternalServerError)
+
+	server := httptest.NewServer(Server{messagehdl: NewMessageHandler(serviceMock)}.setupRoutes())
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/messages").
+		Expect().Status(http.StatusOK).
+		Body().IsEqual(`[{"id":"` + message.ID + `","content":"message content"}`)
+}
+
 func TestGetMessages_ShouldReturnMessagesWithSuccess(t *testing.T) {
 	messages := []domain.Message{
 		domain.NewMessage(uuid.NewString(), "message content 1"),
@@ -148,7 +180,7 @@ func TestGetMessages_ShouldReturnMessagesWithSuccess(t *testing.T) {
 	}
 
 	serviceMock := new(mocks.MessageUseCaseMock)
-	serviceMock.On("GetAll", mock.Anything).Return(messages, nil)
+	serviceMock.On("ForEach", mock.Anything).Return(messages, nil)
 
 	handler := setupHandler(serviceMock)
 	server := httptest.NewServer(handler)
@@ -220,7 +252,328 @@ func TestDeleteMessage_ShouldReturnNoContentWhenDeleteMessageWithSuccess(t *test
 
 func setupHandler(service ports.MessageUseCase) *gin.Engine {
 	handler := NewMessageHandler(service)
-	server := Server{messagehdl: handler}
+	server := Server{messagehdl: handler, validateResponses: true}
 	router := server.setupRoutes()
 	return router
 }
+
+func TestCreateMessages_ShouldReturnNotFoundWhenCustomMethodIsUnknown(t *testing.T) {
+	handler := setupHandler(nil)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/messages:unknown").
+		WithJSON(dto.BatchCreateMessagesRequest{}).
+		Expect().Status(http.StatusNotFound).
+		Body().Contains(apperrors.NotFound.Error())
+}
+
+func TestCreateMessages_ShouldReturnErrorWhenBindingRequestParams(t *testing.T) {
+	handler := setupHandler(nil)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/messages:batch").
+		WithJSON(`{`).
+		Expect().Status(http.StatusBadRequest).
+		Body().Contains(apperrors.InvalidInput.Error())
+}
+
+func TestCreateMessages_ShouldReturnErrorWhenBatchIsInvalid(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("SaveBatch", mock.Anything, []string{}, domain.BatchModeAtomic).
+		Return([]domain.BatchResult(nil), apperrors.InvalidInput)
+
+	handler := setupHandler(serviceMock)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/messages:batch").
+		WithJSON(dto.BatchCreateMessagesRequest{Messages: []dto.CreateMessageRequest{}}).
+		Expect().Status(http.StatusBadRequest).
+		Body().Contains(apperrors.InvalidInput.Error())
+}
+
+func TestCreateMessages_ShouldReturnErrorWhenFailsToSaveMessages(t *testing.T) {
+	unexpectedError := errors.New("unexpected error")
+	body := dto.BatchCreateMessagesRequest{Messages: []dto.CreateMessageRequest{{Content: "message content"}}}
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("SaveBatch", mock.Anything, []string{"message content"}, domain.BatchModeAtomic).
+		Return([]domain.BatchResult(nil), unexpectedError)
+
+	handler := setupHandler(serviceMock)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/messages:batch").
+		WithJSON(body).
+		Expect().Status(http.StatusInternalServerError).
+		Body().Contains(unexpectedError.Error())
+}
+
+func TestCreateMessages_ShouldReturnUnprocessableEntityWhenAtomicBatchIsAborted(t *testing.T) {
+	unexpectedError := errors.New("unexpected error")
+	body := dto.BatchCreateMessagesRequest{
+		Mode:     string(domain.BatchModeAtomic),
+		Messages: []dto.CreateMessageRequest{{Content: "message content 1"}, {Content: "message content 2"}},
+	}
+	results := []domain.BatchResult{
+		domain.NewBatchResult(uuid.NewString(), apperrors.Aborted),
+		domain.NewBatchResult(uuid.NewString(), unexpectedError),
+	}
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("SaveBatch", mock.Anything, []string{"message content 1", "message content 2"}, domain.BatchModeAtomic).
+		Return(results, apperrors.UnprocessableEntity)
+
+	handler := setupHandler(serviceMock)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	response := dto.BatchMessagesResponse{}
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/messages:batch").
+		WithJSON(body).
+		Expect().Status(http.StatusUnprocessableEntity).
+		JSON().Decode(&response)
+
+	assert.Equal(t, string(domain.BatchModeAtomic), response.Mode)
+	assert.Equal(t, dto.BatchItemStatusAborted, response.Results[0].Status)
+	assert.Equal(t, dto.BatchItemStatusFailed, response.Results[1].Status)
+	assert.Equal(t, unexpectedError.Error(), response.Results[1].Error)
+}
+
+func TestCreateMessages_ShouldReturnMultiStatusWhenBestEffortBatchHasFailures(t *testing.T) {
+	body := dto.BatchCreateMessagesRequest{
+		Mode:     string(domain.BatchModeBestEffort),
+		Messages: []dto.CreateMessageRequest{{Content: "message content 1"}, {Content: "message content 2"}},
+	}
+	results := []domain.BatchResult{
+		domain.NewBatchResult(uuid.NewString(), nil),
+		domain.NewBatchResult(uuid.NewString(), errors.New("unexpected error")),
+	}
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("SaveBatch", mock.Anything, []string{"message content 1", "message content 2"}, domain.BatchModeBestEffort).
+		Return(results, nil)
+
+	handler := setupHandler(serviceMock)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	response := dto.BatchMessagesResponse{}
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/messages:batch").
+		WithJSON(body).
+		Expect().Status(http.StatusMultiStatus).
+		JSON().Decode(&response)
+
+	assert.Equal(t, dto.BatchItemStatusCreated, response.Results[0].Status)
+	assert.Equal(t, results[0].ID, response.Results[0].ID)
+	assert.Equal(t, dto.BatchItemStatusFailed, response.Results[1].Status)
+}
+
+func TestCreateMessages_ShouldSaveMessagesWithSuccess(t *testing.T) {
+	body := dto.BatchCreateMessagesRequest{Messages: []dto.CreateMessageRequest{{Content: "message content 1"}, {Content: "message content 2"}}}
+	results := []domain.BatchResult{
+		domain.NewBatchResult(uuid.NewString(), nil),
+		domain.NewBatchResult(uuid.NewString(), nil),
+	}
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("SaveBatch", mock.Anything, []string{"message content 1", "message content 2"}, domain.BatchModeAtomic).
+		Return(results, nil)
+
+	handler := setupHandler(serviceMock)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	response := dto.BatchMessagesResponse{}
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/messages:batch").
+		WithJSON(body).
+		Expect().Status(http.StatusCreated).
+		JSON().Decode(&response)
+
+	assert.Equal(t, string(domain.BatchModeAtomic), response.Mode)
+	assert.Len(t, response.Results, 2)
+	assert.Equal(t, results[1].ID, response.Results[1].ID)
+	assert.Equal(t, 1, response.Results[1].Index)
+}
+
+func TestDeleteMessages_ShouldReturnErrorWhenBindingRequestParams(t *testing.T) {
+	handler := setupHandler(nil)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.DELETE("/messages:batch").
+		WithJSON(`{`).
+		Expect().Status(http.StatusBadRequest).
+		Body().Contains(apperrors.InvalidInput.Error())
+}
+
+func TestDeleteMessages_ShouldReturnMultiStatusWhenBestEffortBatchHasFailures(t *testing.T) {
+	ids := []string{uuid.NewString(), uuid.NewString()}
+	results := []domain.BatchResult{
+		domain.NewBatchResult(ids[0], nil),
+		domain.NewBatchResult(ids[1], apperrors.NotFound),
+	}
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("DeleteBatch", mock.Anything, ids, domain.BatchModeBestEffort).Return(results, nil)
+
+	handler := setupHandler(serviceMock)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	response := dto.BatchMessagesResponse{}
+	e := httpexpect.Default(t, server.URL)
+	e.DELETE("/messages:batch").
+		WithJSON(dto.BatchDeleteMessagesRequest{Mode: string(domain.BatchModeBestEffort), IDs: ids}).
+		Expect().Status(http.StatusMultiStatus).
+		JSON().Decode(&response)
+
+	assert.Equal(t, dto.BatchItemStatusDeleted, response.Results[0].Status)
+	assert.Equal(t, dto.BatchItemStatusFailed, response.Results[1].Status)
+	assert.Equal(t, apperrors.NotFound.Error(), response.Results[1].Error)
+}
+
+func TestDeleteMessages_ShouldDeleteMessagesWithSuccess(t *testing.T) {
+	ids := []string{uuid.NewString(), uuid.NewString()}
+	results := []domain.BatchResult{
+		domain.NewBatchResult(ids[0], nil),
+		domain.NewBatchResult(ids[1], nil),
+	}
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("DeleteBatch", mock.Anything, ids, domain.BatchModeAtomic).Return(results, nil)
+
+	handler := setupHandler(serviceMock)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	response := dto.BatchMessagesResponse{}
+	e := httpexpect.Default(t, server.URL)
+	e.DELETE("/messages:batch").
+		WithJSON(dto.BatchDeleteMessagesRequest{IDs: ids}).
+		Expect().Status(http.StatusOK).
+		JSON().Decode(&response)
+
+	assert.Equal(t, dto.BatchItemStatusDeleted, response.Results[0].Status)
+	assert.Equal(t, dto.BatchItemStatusDeleted, response.Results[1].Status)
+}
+
+func TestCreateMessageV2_ShouldReturnCreatedMessage(t *testing.T) {
+	message := domain.NewMessage(uuid.NewString(), "message content")
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, message.Content).Return(message, nil)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/v2/messages").
+		WithJSON(dto.CreateMessageRequest{Content: message.Content}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object().IsEqual(dto.MessageResponseV2{ID: message.ID, Content: message.Content})
+}
+
+func TestGetMessagesV2_ShouldWrapMessages(t *testing.T) {
+	messages := []domain.Message{domain.NewMessage(uuid.NewString(), "first"), domain.NewMessage(uuid.NewString(), "second")}
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("ForEach", mock.Anything).Return(messages, nil)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/v2/messages").
+		Expect().Status(http.StatusOK).
+		JSON().Object().IsEqual(dto.BuildResponseListMessagesV2(messages))
+}
+
+func TestGetMessagesV2_ShouldReturnEmptyList(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("ForEach", mock.Anything).Return([]domain.Message{}, nil)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/v2/messages").
+		Expect().Status(http.StatusOK).
+		Body().IsEqual(`{"messages":[]}`)
+}
+
+func TestGetMessageV2_ShouldReturnMessage(t *testing.T) {
+	message := domain.NewMessage(uuid.NewString(), "message content")
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/v2/messages/{id}").
+		WithPath("id", message.ID).
+		Expect().Status(http.StatusOK).
+		JSON().Object().IsEqual(dto.MessageResponseV2{ID: message.ID, Content: message.Content})
+}
+
+func TestDeleteMessageV2_ShouldDeleteMessage(t *testing.T) {
+	messageID := uuid.NewString()
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("DeleteByID", mock.Anything, messageID).Return(nil)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.DELETE("/v2/messages/{id}").
+		WithPath("id", messageID).
+		Expect().Status(http.StatusNoContent)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestDeleteMessageV2_ShouldReturnNotFoundWhenMessageNotFound(t *testing.T) {
+	messageID := uuid.NewString()
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("DeleteByID", mock.Anything, messageID).Return(apperrors.NotFound)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.DELETE("/v2/messages/{id}").
+		WithPath("id", messageID).
+		Expect().Status(http.StatusNotFound).
+		JSON().Object().Value("error").String().Contains(apperrors.NotFound.Error())
+}
+
+func TestCreateMessagesV2_ShouldCreateBatch(t *testing.T) {
+	message := domain.NewMessage(uuid.NewString(), "message content")
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("SaveBatch", mock.Anything, []string{message.Content}, domain.BatchModeAtomic).
+		Return([]domain.BatchResult{domain.NewBatchResult(message.ID, nil)}, nil)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/v2/messages:batch").
+		WithJSON(dto.BatchCreateMessagesRequest{Messages: []dto.CreateMessageRequest{{Content: message.Content}}}).
+		Expect().Status(http.StatusCreated).
+		JSON().Path("$.results[0].id").IsEqual(message.ID)
+}
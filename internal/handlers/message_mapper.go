@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+)
+
+// messageMapper builds the response bodies of one API version, so that every
+// version is served by the same handler over the same use case.
+type messageMapper interface {
+	createdMessage(message domain.Message) any
+	message(message domain.Message) any
+	messages(messages []domain.Message) any
+	// listEnvelope is the JSON around the message bodies of a streamed list.
+	listEnvelope() (prefix string, suffix string)
+	// deleteNotFound tells whether deleting a message that does not exist, or
+	// is already in the trash, answers 404 rather than 204.
+	deleteNotFound() bool
+}
+
+// v1Mapper must keep producing the exact v1 bodies; the compatibility tests
+// in testdata/v1 fail on any change.
+type v1Mapper struct{}
+
+func (v1Mapper) createdMessage(message domain.Message) any {
+	return dto.BuildResponseCreateMessage(message.ID)
+}
+
+func (v1Mapper) message(message domain.Message) any {
+	return dto.BuildResponseGetMessage(message)
+}
+
+func (v1Mapper) messages(messages []domain.Message) any {
+	return dto.BuildResponseGetMessages(messages)
+}
+
+func (v1Mapper) listEnvelope() (string, string) {
+	return "[", "]"
+}
+
+func (v1Mapper) deleteNotFound() bool {
+	return false
+}
+
+type v2Mapper struct{}
+
+func (v2Mapper) createdMessage(message domain.Message) any {
+	return dto.BuildResponseMessageV2(message)
+}
+
+func (v2Mapper) message(message domain.Message) any {
+	return dto.BuildResponseMessageV2(message)
+}
+
+func (v2Mapper) messages(messages []domain.Message) any {
+	return dto.BuildResponseListMessagesV2(messages)
+}
+
+func (v2Mapper) listEnvelope() (string, string) {
+	return `{"messages":[`, "]}"
+}
+
+func (v2Mapper) deleteNotFound() bool {
+	return true
+}
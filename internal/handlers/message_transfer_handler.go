@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+const (
+	mediaTypeNDJSON    = "application/x-ndjson"
+	exportCountTrailer = "X-Export-Count"
+	maxImportLineSize  = 1 << 20
+)
+
+// messageTransferHandler streams NDJSON in both directions, so neither an
+// export nor an import is ever held in memory as a whole.
+type messageTransferHandler struct {
+	service ports.MessageTransferUseCase
+}
+
+func NewMessageTransferHandler(service ports.MessageTransferUseCase) messageTransferHandler {
+	return messageTransferHandler{
+		service: service,
+	}
+}
+
+// exportMessages sends the X-Export-Count trailer only when every message was
+// written, since the status is already sent when an export fails midway.
+func (h messageTransferHandler) exportMessages(c *gin.Context) {
+	exported := 0
+	encoder := json.NewEncoder(c.Writer)
+	err := h.service.Export(c.Request.Context(), func(message domain.Message) error {
+		if exported == 0 {
+			startExport(c)
+		}
+		exported++
+		return encoder.Encode(dto.BuildResponseGetMessage(message))
+	})
+	if err != nil {
+		if exported == 0 {
+			status := 500
+			if errors.Is(err, apperrors.Forbidden) {
+				status = 403
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	if exported == 0 {
+		startExport(c)
+	}
+	c.Writer.Header().Set(exportCountTrailer, strconv.Itoa(exported))
+}
+
+func startExport(c *gin.Context) {
+	c.Header("Content-Type", mediaTypeNDJSON)
+	c.Header("Trailer", exportCountTrailer)
+	c.Status(http.StatusOK)
+	c.Writer.WriteHeaderNow()
+}
+
+func (h messageTransferHandler) importMessages(c *gin.Context) {
+	options := domain.NewImportOptions(
+		domain.ImportIDMode(c.DefaultQuery("ids", string(domain.ImportIDsPreserve))),
+		domain.ImportConflictPolicy(c.DefaultQuery("conflict", string(domain.ImportConflictFail))),
+	)
+
+	report, err := h.service.Import(c.Request.Context(), importRecords(c.Request.Body), options)
+	response := dto.BuildResponseImportReport(report, err)
+	switch {
+	case errors.Is(err, apperrors.InvalidInput):
+		c.JSON(400, response)
+	case errors.Is(err, apperrors.Forbidden):
+		c.JSON(403, gin.H{"error": err.Error()})
+	case errors.Is(err, apperrors.Conflict):
+		c.JSON(409, response)
+	case errors.Is(err, apperrors.TooManyRequests):
+		c.JSON(429, response)
+	case err != nil:
+		c.JSON(500, response)
+	case len(report.Failures) > 0:
+		c.JSON(207, response)
+	default:
+		c.JSON(200, response)
+	}
+}
+
+// importRecords reads one message per line, skipping blank lines. Lines that
+// are not a message become records with an error, so the import goes on.
+func importRecords(body io.Reader) func() (domain.ImportRecord, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+	line := 0
+
+	return func() (domain.ImportRecord, error) {
+		for scanner.Scan() {
+			line++
+			text := bytes.TrimSpace(scanner.Bytes())
+			if len(text) == 0 {
+				continue
+			}
+
+			var request dto.ImportMessageRequest
+			err := json.Unmarshal(text, &request)
+			return domain.NewImportRecord(line, domain.NewMessage(request.ID, request.Content), err), nil
+		}
+		if err := scanner.Err(); err != nil {
+			return domain.ImportRecord{}, fmt.Errorf("line %d: %w", line+1, err)
+		}
+		return domain.ImportRecord{}, io.EOF
+	}
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExportMessages_ShouldStreamNDJSON(t *testing.T) {
+	messages := []domain.Message{domain.NewMessage("1", "first"), domain.NewMessage("2", "second")}
+
+	serviceMock := new(mocks.MessageTransferUseCaseMock)
+	serviceMock.On("Export", mock.Anything).Return(messages, nil)
+
+	server := httptest.NewServer(setupTransferHandler(serviceMock))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/messages/export")
+	assert.NoError(t, err)
+	defer response.Body.Close()
+
+	body := new(strings.Builder)
+	_, err = io.Copy(body, response.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, mediaTypeNDJSON, response.Header.Get("Content-Type"))
+	assert.Equal(t, "{\"id\":\"1\",\"content\":\"first\"}\n{\"id\":\"2\",\"content\":\"second\"}\n", body.String())
+	assert.Equal(t, "2", response.Trailer.Get(exportCountTrailer))
+}
+
+func TestExportMessages_ShouldReturnErrorWhenNothingWasWritten(t *testing.T) {
+	unexpectedError := errors.New("unexpected error")
+
+	serviceMock := new(mocks.MessageTransferUseCaseMock)
+	serviceMock.On("Export", mock.Anything).Return([]domain.Message{}, errors.Join(apperrors.InternalServerError, unexpectedError))
+
+	server := httptest.NewServer(setupTransferHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/messages/export").
+		Expect().Status(http.StatusInternalServerError).
+		Body().Contains(unexpectedError.Error())
+}
+
+func TestExportMessages_ShouldOmitTrailerWhenExportFailsMidway(t *testing.T) {
+	serviceMock := new(mocks.MessageTransferUseCaseMock)
+	serviceMock.On("Export", mock.Anything).Return([]domain.Message{domain.NewMessage("1", "first")}, apperrors.InternalServerError)
+
+	server := httptest.NewServer(setupTransferHandler(serviceMock))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/messages/export")
+	assert.NoError(t, err)
+	defer response.Body.Close()
+
+	_, err = io.Copy(io.Discard, response.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Empty(t, response.Trailer.Get(exportCountTrailer))
+}
+
+func TestImportMessages_ShouldReadLinesAndReturnReport(t *testing.T) {
+	records := []domain.ImportRecord{
+		domain.NewImportRecord(1, domain.NewMessage("1", "first"), nil),
+		domain.NewImportRecord(3, domain.NewMessage("", "second"), nil),
+	}
+	options := domain.NewImportOptions(domain.ImportIDsRegenerate, domain.ImportConflictSkip)
+
+	serviceMock := new(mocks.MessageTransferUseCaseMock)
+	serviceMock.On("Import", mock.Anything, records, options).Return(domain.ImportReport{Created: 2}, nil)
+
+	server := httptest.NewServer(setupTransferHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	report := e.POST("/messages/import").
+		WithQuery("ids", "regenerate").
+		WithQuery("conflict", "skip").
+		WithHeader("Content-Type", mediaTypeNDJSON).
+		WithText("{\"id\":\"1\",\"content\":\"first\"}\n\n{\"content\":\"second\"}\n").
+		Expect().Status(http.StatusOK).
+		JSON().Object()
+	report.Value("created").Number().IsEqual(2)
+	report.Value("failures").Array().IsEmpty()
+}
+
+func TestImportMessages_ShouldReportMalformedLines(t *testing.T) {
+	serviceMock := new(mocks.MessageTransferUseCaseMock)
+	serviceMock.On("Import", mock.Anything, mock.MatchedBy(func(records []domain.ImportRecord) bool {
+		return len(records) == 1 && records[0].Line == 1 && records[0].Err != nil
+	}), domain.NewImportOptions(domain.ImportIDsPreserve, domain.ImportConflictFail)).
+		Return(domain.ImportReport{Failures: []domain.ImportFailure{{Line: 1, Err: apperrors.InvalidInput}}}, nil)
+
+	server := httptest.NewServer(setupTransferHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	report := e.POST("/messages/import").
+		WithHeader("Content-Type", mediaTypeNDJSON).
+		WithText("{\n").
+		Expect().Status(http.StatusMultiStatus).
+		JSON().Object()
+	report.Value("failed").Number().IsEqual(1)
+	report.Value("failures").Array().Value(0).Object().Value("line").Number().IsEqual(1)
+}
+
+func TestImportMessages_ShouldReturnConflictWithReport(t *testing.T) {
+	conflict := errors.Join(apperrors.Conflict, errors.New("line 2: message already exists"))
+
+	serviceMock := new(mocks.MessageTransferUseCaseMock)
+	serviceMock.On("Import", mock.Anything, mock.Anything, mock.Anything).
+		Return(domain.ImportReport{Created: 1, Failures: []domain.ImportFailure{{Line: 2, ID: "1", Err: conflict}}}, conflict)
+
+	server := httptest.NewServer(setupTransferHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	report := e.POST("/messages/import").
+		WithHeader("Content-Type", mediaTypeNDJSON).
+		WithText("{\"id\":\"2\",\"content\":\"new\"}\n{\"id\":\"1\",\"content\":\"stored\"}\n").
+		Expect().Status(http.StatusConflict).
+		JSON().Object()
+	report.Value("created").Number().IsEqual(1)
+	report.Value("error").String().Contains(apperrors.Conflict.Error())
+}
+
+func TestImportMessages_ShouldRejectUnknownPolicy(t *testing.T) {
+	server := httptest.NewServer(setupTransferHandler(nil))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/messages/import").
+		WithQuery("conflict", "merge").
+		WithHeader("Content-Type", mediaTypeNDJSON).
+		WithText("{\"id\":\"1\",\"content\":\"first\"}\n").
+		Expect().Status(http.StatusBadRequest).
+		Body().Contains(apperrors.InvalidInput.Error())
+}
+
+func setupTransferHandler(service ports.MessageTransferUseCase) *gin.Engine {
+	server := Server{transferhdl: NewMessageTransferHandler(service), validateResponses: true}
+	return server.setupRoutes()
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+type messageTrashHandler struct {
+	service ports.MessageTrashUseCase
+}
+
+func NewMessageTrashHandler(service ports.MessageTrashUseCase) messageTrashHandler {
+	return messageTrashHandler{
+		service: service,
+	}
+}
+
+func (h messageTrashHandler) getTrashedMessages(c *gin.Context) {
+	var messages []domain.Message
+	err := h.service.ForEachTrashed(c.Request.Context(), func(message domain.Message) error {
+		messages = append(messages, message)
+		return nil
+	})
+	if err != nil {
+		respondTrashError(c, err)
+		return
+	}
+
+	c.JSON(200, dto.BuildResponseListTrashedMessages(messages))
+}
+
+func (h messageTrashHandler) restoreMessage(c *gin.Context) {
+	message, err := h.service.Restore(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondTrashError(c, err)
+		return
+	}
+
+	c.JSON(200, dto.BuildResponseMessageV2(message))
+}
+
+func respondTrashError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, apperrors.Forbidden):
+		c.JSON(403, gin.H{"error": err.Error()})
+	case errors.Is(err, apperrors.NotFound):
+		c.JSON(404, gin.H{"error": err.Error()})
+	default:
+		c.JSON(500, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/hiago-balbino/hex-architecture-template/internal/auth"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	usecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/message"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/internal/repositories/memory"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTrash_ShouldKeepDeletedMessageUntilRestored(t *testing.T) {
+	server := httptest.NewServer(setupTrashHandler(t))
+	t.Cleanup(server.Close)
+	writer := "Bearer " + signToken(t, map[string]any{"sub": "user-1", "scope": "writer", "exp": time.Now().Add(time.Hour).Unix()})
+
+	e := httpexpect.Default(t, server.URL)
+	id := e.POST("/v2/messages").WithHeader("Authorization", writer).
+		WithJSON(map[string]string{"content": "message content"}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object().Value("id").String().Raw()
+	e.DELETE("/v2/messages/"+id).WithHeader("Authorization", writer).
+		Expect().Status(http.StatusNoContent)
+
+	e.GET("/v2/messages/"+id).WithHeader("Authorization", writer).
+		Expect().Status(http.StatusNotFound)
+	e.GET("/v2/messages").WithHeader("Authorization", writer).
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("messages").Array().IsEmpty()
+	trashed := e.GET("/messages/trash").WithHeader("Authorization", writer).
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("messages").Array()
+	trashed.Length().IsEqual(1)
+	trashed.Value(0).Object().Value("id").IsEqual(id)
+	trashed.Value(0).Object().Value("deleted_at").String().NotEmpty()
+
+	e.POST("/message/"+id+"/restore").WithHeader("Authorization", writer).
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("content").IsEqual("message content")
+	e.GET("/v2/messages/"+id).WithHeader("Authorization", writer).
+		Expect().Status(http.StatusOK)
+	e.GET("/messages/trash").WithHeader("Authorization", writer).
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("messages").Array().IsEmpty()
+	e.POST("/message/"+id+"/restore").WithHeader("Authorization", writer).
+		Expect().Status(http.StatusNotFound)
+}
+
+func TestTrash_ShouldOnlyLetOwnerRestoreMessage(t *testing.T) {
+	server := httptest.NewServer(setupTrashHandler(t))
+	t.Cleanup(server.Close)
+	owner := "Bearer " + signToken(t, map[string]any{"sub": "user-1", "scope": "writer", "exp": time.Now().Add(time.Hour).Unix()})
+	other := "Bearer " + signToken(t, map[string]any{"sub": "user-2", "scope": "writer", "exp": time.Now().Add(time.Hour).Unix()})
+
+	e := httpexpect.Default(t, server.URL)
+	id := e.POST("/v2/messages").WithHeader("Authorization", owner).
+		WithJSON(map[string]string{"content": "message content"}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object().Value("id").String().Raw()
+	e.DELETE("/v2/messages/"+id).WithHeader("Authorization", owner).
+		Expect().Status(http.StatusNoContent)
+
+	e.GET("/messages/trash").WithHeader("Authorization", other).
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("messages").Array().Length().IsEqual(1)
+	e.POST("/message/"+id+"/restore").WithHeader("Authorization", other).
+		Expect().Status(http.StatusForbidden)
+	e.POST("/message/"+id+"/restore").WithHeader("Authorization", owner).
+		Expect().Status(http.StatusOK)
+}
+
+func TestTrash_ShouldNotDeleteTrashedMessageAgain(t *testing.T) {
+	server := httptest.NewServer(setupTrashHandler(t))
+	t.Cleanup(server.Close)
+	writer := "Bearer " + signToken(t, map[string]any{"sub": "user-1", "scope": "writer", "exp": time.Now().Add(time.Hour).Unix()})
+
+	e := httpexpect.Default(t, server.URL)
+	id := e.POST("/v2/messages").WithHeader("Authorization", writer).
+		WithJSON(map[string]string{"content": "message content"}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object().Value("id").String().Raw()
+	e.DELETE("/v2/messages/"+id).WithHeader("Authorization", writer).
+		Expect().Status(http.StatusNoContent)
+
+	e.DELETE("/v2/messages/"+id).WithHeader("Authorization", writer).
+		Expect().Status(http.StatusNotFound)
+	e.DELETE("/v2/messages/"+uuid.NewString()).WithHeader("Authorization", writer).
+		Expect().Status(http.StatusNotFound)
+	e.DELETE("/v1/message/"+id).WithHeader("Authorization", writer).
+		Expect().Status(http.StatusNoContent)
+}
+
+func TestRestoreMessage_ShouldReturnErrorWhenServiceFails(t *testing.T) {
+	serviceMock := new(mocks.MessageTrashUseCaseMock)
+	serviceMock.On("Restore", mock.Anything, "message-id").Return(domain.Message{}, assert.AnError)
+	server := httptest.NewServer(Server{trashhdl: NewMessageTrashHandler(serviceMock)}.setupRoutes())
+	t.Cleanup(server.Close)
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/message/message-id/restore").
+		Expect().Status(http.StatusInternalServerError)
+}
+
+func setupTrashHandler(t *testing.T) *gin.Engine {
+	key, err := auth.NewHMACKey("", testSecret)
+	assert.NoError(t, err)
+	messages := memory.NewMessageStorage()
+	service := usecases.NewMessageService(identifier.NewUUIDGenerator(), clock.NewSystemClock(), messages, memory.NewUnitOfWork(messages, memory.NewOutboxStorage(), memory.NewAuditStorage()), policy.NewRolePolicy())
+	server := Server{
+		messagehdl:        NewMessageHandler(service),
+		trashhdl:          NewMessageTrashHandler(service),
+		authentication:    NewAuthMiddleware(auth.NewVerifier([]auth.Key{key}, "", "", time.Minute, clock.NewSystemClock()), nil),
+		validateResponses: true,
+	}
+	return server.setupRoutes()
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/codec"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/openapi"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+const negotiatedCodecKey = "negotiatedCodec"
+
+var defaultCodecs = codec.NewRegistry(codec.JSON{}, codec.MsgPack{}, codec.Protobuf{}, codec.CSV{})
+
+// negotiationMiddleware selects the response codec from the Accept header
+// among the media types an operation produces, and turns request bodies of
+// the media types it consumes into JSON, so that the contract middleware and
+// the handlers only deal with JSON bodies.
+type negotiationMiddleware struct {
+	codecs codec.Registry
+}
+
+func NewNegotiationMiddleware(codecs codec.Registry) negotiationMiddleware {
+	return negotiationMiddleware{
+		codecs: codecs,
+	}
+}
+
+func (m negotiationMiddleware) handle(operation openapi.Operation) gin.HandlerFunc {
+	produces := append([]string{codec.MediaTypeJSON}, operation.Produces...)
+	consumes := append([]string{codec.MediaTypeJSON}, operation.Consumes...)
+
+	return func(c *gin.Context) {
+		if len(operation.Produces) > 0 {
+			c.Header("Vary", "Accept")
+			selected, ok := m.codecs.Negotiate(c.GetHeader("Accept"), produces)
+			if !ok {
+				err := fmt.Errorf("the supported media types are %s", strings.Join(produces, ", "))
+				c.AbortWithStatusJSON(406, dto.ErrorResponse{Error: errors.Join(apperrors.NotAcceptable, err).Error()})
+				return
+			}
+			c.Set(negotiatedCodecKey, selected)
+		}
+
+		if len(operation.Consumes) > 0 && operation.RequestBody != nil {
+			if err := m.decodeRequest(c, operation.RequestBody, consumes); err != nil {
+				status := 400
+				if errors.Is(err, apperrors.UnsupportedMediaType) {
+					status = 415
+				}
+				c.AbortWithStatusJSON(status, dto.ErrorResponse{Error: err.Error()})
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+func (m negotiationMiddleware) decodeRequest(c *gin.Context, requestBody any, consumes []string) error {
+	contentType := c.GetHeader("Content-Type")
+	if contentType == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return errors.Join(apperrors.UnsupportedMediaType, err)
+	}
+	if mediaType == codec.MediaTypeJSON {
+		return nil
+	}
+
+	bodyCodec, ok := m.codecs.Lookup(mediaType)
+	if !ok || !contains(consumes, mediaType) {
+		return errors.Join(apperrors.UnsupportedMediaType, fmt.Errorf("the supported media types are %s", strings.Join(consumes, ", ")))
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return errors.Join(apperrors.InvalidInput, err)
+	}
+	value := reflect.New(reflect.TypeOf(requestBody))
+	if err := bodyCodec.Decode(body, value.Interface()); err != nil {
+		return errors.Join(apperrors.InvalidInput, err)
+	}
+	body, err = json.Marshal(value.Elem().Interface())
+	if err != nil {
+		return errors.Join(apperrors.InvalidInput, err)
+	}
+
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	c.Request.ContentLength = int64(len(body))
+	c.Request.Header.Set("Content-Type", codec.MediaTypeJSON)
+	return nil
+}
+
+// negotiatedCodec returns JSON for the routes that do not negotiate.
+func negotiatedCodec(c *gin.Context) codec.Codec {
+	if selected, ok := c.Get(negotiatedCodecKey); ok {
+		return selected.(codec.Codec)
+	}
+	return codec.JSON{}
+}
+
+// respond writes body with the codec negotiated for the request. JSON goes
+// through c.JSON so that its bytes never change, and bodies the codec cannot
+// represent, such as errors in CSV, fall back to JSON.
+func respond(c *gin.Context, code int, body any) {
+	if bodyCodec := negotiatedCodec(c); bodyCodec.MediaType() != codec.MediaTypeJSON {
+		var buffer bytes.Buffer
+		err := bodyCodec.Encode(&buffer, body)
+		if err == nil {
+			c.Data(code, bodyCodec.ContentType(), buffer.Bytes())
+			return
+		}
+		if !errors.Is(err, codec.ErrUnsupportedValue) {
+			c.JSON(500, gin.H{"error": errors.Join(apperrors.InternalServerError, err).Error()})
+			return
+		}
+	}
+	c.JSON(code, body)
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/google/uuid"
+	messagev1 "github.com/hiago-balbino/hex-architecture-template/api/proto/message/v1"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/codec"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNegotiation_ShouldEncodeMessageAsMsgPack(t *testing.T) {
+	message := domain.NewMessage(uuid.NewString(), "message content")
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.Anything, message.ID).Return(message, nil)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	response := e.GET("/v2/messages/{id}", message.ID).
+		WithHeader("Accept", codec.MediaTypeMsgPack).
+		Expect().Status(http.StatusOK)
+	response.Header("Content-Type").IsEqual(codec.MediaTypeMsgPack)
+	response.Header("Vary").IsEqual("Accept")
+
+	var fields map[string]any
+	assert.NoError(t, codec.MsgPack{}.Decode([]byte(response.Body().Raw()), &fields))
+	assert.Equal(t, map[string]any{"id": message.ID, "content": message.Content}, fields)
+}
+
+func TestNegotiation_ShouldEncodeMessagesAsCSV(t *testing.T) {
+	messages := []domain.Message{domain.NewMessage("1", "first"), domain.NewMessage("2", "second")}
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetAll", mock.Anything).Return(messages, nil)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	response := e.GET("/messages").
+		WithHeader("Accept", "text/csv, application/json;q=0.5").
+		Expect().Status(http.StatusOK)
+	response.Header("Content-Type").IsEqual("text/csv; charset=utf-8")
+	response.Body().IsEqual("id,content\n1,first\n2,second\n")
+}
+
+func TestNegotiation_ShouldKeepJSONByDefault(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("ForEach", mock.Anything).Return([]domain.Message{}, nil)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/v1/messages").
+		WithHeader("Accept", "*/*").
+		Expect().Status(http.StatusOK).
+		ContentType(codec.MediaTypeJSON)
+}
+
+func TestNegotiation_ShouldFallBackToJSONForErrors(t *testing.T) {
+	messageID := uuid.NewString()
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.Anything, messageID).Return(domain.Message{}, apperrors.NotFound)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/message/{id}", messageID).
+		WithHeader("Accept", codec.MediaTypeProtobuf).
+		Expect().Status(http.StatusNotFound).
+		JSON().Object().Value("error").String().Contains(apperrors.NotFound.Error())
+}
+
+func TestNegotiation_ShouldReturnNotAcceptable(t *testing.T) {
+	server := httptest.NewServer(setupHandler(nil))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/messages").
+		WithHeader("Accept", "application/xml").
+		Expect().Status(http.StatusNotAcceptable).
+		Body().Contains(apperrors.NotAcceptable.Error())
+}
+
+func TestNegotiation_ShouldReturnUnsupportedMediaType(t *testing.T) {
+	server := httptest.NewServer(setupHandler(nil))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/message").
+		WithHeader("Content-Type", "application/xml").
+		WithText("<content>message content</content>").
+		Expect().Status(http.StatusUnsupportedMediaType).
+		Body().Contains(apperrors.UnsupportedMediaType.Error())
+}
+
+func TestNegotiation_ShouldDecodeProtobufRequest(t *testing.T) {
+	message := domain.NewMessage(uuid.NewString(), "message content")
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, message.Content).Return(message, nil)
+
+	server := httptest.NewServer(setupHandler(serviceMock))
+	defer server.Close()
+
+	body, err := proto.Marshal(&messagev1.CreateRequest{Content: message.Content})
+	assert.NoError(t, err)
+
+	e := httpexpect.Default(t, server.URL)
+	response := e.POST("/v2/messages").
+		WithHeader("Content-Type", codec.MediaTypeProtobuf).
+		WithHeader("Accept", codec.MediaTypeProtobuf).
+		WithBytes(body).
+		Expect().Status(http.StatusCreated)
+
+	var created messagev1.Message
+	assert.NoError(t, proto.Unmarshal([]byte(response.Body().Raw()), &created))
+	assert.Equal(t, message.ID, created.GetId())
+	assert.Equal(t, message.Content, created.GetContent())
+}
+
+func TestNegotiation_ShouldRejectMalformedBody(t *testing.T) {
+	server := httptest.NewServer(setupHandler(nil))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/message").
+		WithHeader("Content-Type", codec.MediaTypeMsgPack).
+		WithBytes([]byte{0xc1}).
+		Expect().Status(http.StatusBadRequest).
+		Body().Contains(apperrors.InvalidInput.Error())
+}
@@ -0,0 +1,243 @@
+// Package openapi generates an OpenAPI 3.1 document from route descriptions,
+// reflecting the request and response bodies from their Go types.
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	Version = "3.1.0"
+
+	ContentTypeJSON = "application/json"
+
+	InPath   = "path"
+	InQuery  = "query"
+	InHeader = "header"
+)
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps a lower case HTTP method to its operation.
+type PathItem map[string]*OperationSpec
+
+type OperationSpec struct {
+	OperationID string                  `json:"operationId"`
+	Summary     string                  `json:"summary,omitempty"`
+	Tags        []string                `json:"tags,omitempty"`
+	Deprecated  bool                    `json:"deprecated,omitempty"`
+	Parameters  []Parameter             `json:"parameters,omitempty"`
+	RequestBody *RequestBodySpec        `json:"requestBody,omitempty"`
+	Responses   map[string]ResponseSpec `json:"responses"`
+	Security    []map[string][]string   `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBodySpec struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type ResponseSpec struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is a way of authenticating requests. Name identifies it in
+// the components and is not part of the scheme itself; ParameterName and In
+// locate the credentials of apiKey schemes.
+type SecurityScheme struct {
+	Name          string `json:"-"`
+	Type          string `json:"type"`
+	Description   string `json:"description,omitempty"`
+	Scheme        string `json:"scheme,omitempty"`
+	BearerFormat  string `json:"bearerFormat,omitempty"`
+	ParameterName string `json:"name,omitempty"`
+	In            string `json:"in,omitempty"`
+}
+
+// Route describes an operation with Go values: RequestBody and the response
+// bodies are zero values of the types sent on the wire.
+type Route struct {
+	Method    string
+	Path      string
+	Operation Operation
+}
+
+// Operation bodies are JSON. Consumes and Produces list the other media types
+// of the request body and of the successful response bodies. A request body
+// with a RequestContentType, such as NDJSON whose lines are RequestBody
+// values, is documented but not validated. Any of the Security schemes
+// authenticates a request; none means the operation is public.
+type Operation struct {
+	ID                 string
+	Summary            string
+	Tags               []string
+	Deprecated         bool
+	Parameters         []Parameter
+	RequestBody        any
+	RequestContentType string
+	Consumes           []string
+	Responses          []Response
+	Produces           []string
+	Security           []SecurityScheme
+}
+
+// Response has a JSON body unless ContentType says otherwise. A nil Body
+// means there is no content.
+type Response struct {
+	Status      int
+	Description string
+	Body        any
+	ContentType string
+}
+
+// Generate builds the document. Routes use gin paths: ":name" segments become
+// path parameters, which are documented as strings unless listed.
+func Generate(info Info, routes []Route) Document {
+	schemas := newSchemaGenerator()
+	document := Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]PathItem{},
+	}
+
+	for _, route := range routes {
+		path, pathParameters := convertPath(route.Path)
+		operation := route.Operation
+
+		spec := &OperationSpec{
+			OperationID: operation.ID,
+			Summary:     operation.Summary,
+			Tags:        operation.Tags,
+			Deprecated:  operation.Deprecated,
+			Parameters:  mergeParameters(pathParameters, operation.Parameters),
+			Responses:   map[string]ResponseSpec{},
+		}
+		if operation.RequestBody != nil {
+			spec.RequestBody = &RequestBodySpec{
+				Required: true,
+				Content:  mediaTypes(schemas.schemaOf(operation.RequestBody), operation.Consumes),
+			}
+		}
+		if operation.RequestContentType != "" {
+			spec.RequestBody.Content = map[string]MediaType{operation.RequestContentType: {Schema: schemas.schemaOf(operation.RequestBody)}}
+		}
+		for _, response := range operation.Responses {
+			spec.Responses[strconv.Itoa(response.Status)] = responseSpec(schemas, response, operation.Produces)
+		}
+		for _, scheme := range operation.Security {
+			if document.Components.SecuritySchemes == nil {
+				document.Components.SecuritySchemes = map[string]SecurityScheme{}
+			}
+			document.Components.SecuritySchemes[scheme.Name] = scheme
+			spec.Security = append(spec.Security, map[string][]string{scheme.Name: {}})
+		}
+
+		if document.Paths[path] == nil {
+			document.Paths[path] = PathItem{}
+		}
+		document.Paths[path][strings.ToLower(route.Method)] = spec
+	}
+
+	document.Components.Schemas = schemas.components
+	return document
+}
+
+// Operation returns the operation registered for method and the gin path.
+func (d Document) Operation(method string, ginPath string) (*OperationSpec, bool) {
+	path, _ := convertPath(ginPath)
+	operation, ok := d.Paths[path][strings.ToLower(method)]
+	return operation, ok
+}
+
+func responseSpec(schemas *schemaGenerator, response Response, produces []string) ResponseSpec {
+	spec := ResponseSpec{Description: response.Description}
+	if spec.Description == "" {
+		spec.Description = http.StatusText(response.Status)
+	}
+
+	contentType := response.ContentType
+	if contentType == "" {
+		contentType = ContentTypeJSON
+	}
+	if response.Body != nil && contentType == ContentTypeJSON && response.Status < http.StatusBadRequest {
+		spec.Content = mediaTypes(schemas.schemaOf(response.Body), produces)
+	} else if response.Body != nil {
+		spec.Content = map[string]MediaType{contentType: {Schema: schemas.schemaOf(response.Body)}}
+	} else if response.ContentType != "" {
+		spec.Content = map[string]MediaType{contentType: {Schema: &Schema{Type: TypeString}}}
+	}
+	return spec
+}
+
+func mediaTypes(schema *Schema, others []string) map[string]MediaType {
+	content := map[string]MediaType{ContentTypeJSON: {Schema: schema}}
+	for _, mediaType := range others {
+		content[mediaType] = MediaType{Schema: schema}
+	}
+	return content
+}
+
+// convertPath turns "/message/:id" into "/message/{id}". Only whole segments
+// are parameters, so custom methods such as "/messages:batch" stay literal.
+func convertPath(ginPath string) (string, []Parameter) {
+	segments := strings.Split(ginPath, "/")
+	var parameters []Parameter
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			name := segment[1:]
+			segments[i] = "{" + name + "}"
+			parameters = append(parameters, Parameter{Name: name, In: InPath, Required: true, Schema: &Schema{Type: TypeString}})
+		}
+	}
+	return strings.Join(segments, "/"), parameters
+}
+
+func mergeParameters(pathParameters []Parameter, declared []Parameter) []Parameter {
+	var parameters []Parameter
+	for _, pathParameter := range pathParameters {
+		if !hasPathParameter(declared, pathParameter.Name) {
+			parameters = append(parameters, pathParameter)
+		}
+	}
+	return append(parameters, declared...)
+}
+
+func hasPathParameter(parameters []Parameter, name string) bool {
+	for _, parameter := range parameters {
+		if parameter.In == InPath && parameter.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	ID string `json:"id"`
+}
+
+func TestGenerate_ShouldConvertPathParameters(t *testing.T) {
+	document := Generate(Info{Title: "test", Version: "1"}, []Route{
+		{Method: http.MethodGet, Path: "/items/:id/parts/:partID", Operation: Operation{ID: "getPart"}},
+	})
+
+	operation, ok := document.Paths["/items/{id}/parts/{partID}"]["get"]
+	assert.True(t, ok)
+	assert.Equal(t, []Parameter{
+		{Name: "id", In: InPath, Required: true, Schema: &Schema{Type: TypeString}},
+		{Name: "partID", In: InPath, Required: true, Schema: &Schema{Type: TypeString}},
+	}, operation.Parameters)
+}
+
+func TestGenerate_ShouldKeepCustomMethodsLiteral(t *testing.T) {
+	document := Generate(Info{}, []Route{
+		{Method: http.MethodPost, Path: "/items:batch", Operation: Operation{ID: "batch"}},
+	})
+
+	operation, ok := document.Operation(http.MethodPost, "/items:batch")
+	assert.True(t, ok)
+	assert.Empty(t, operation.Parameters)
+}
+
+func TestGenerate_ShouldPreferDeclaredPathParameters(t *testing.T) {
+	declared := Parameter{Name: "id", In: InPath, Description: "The item id", Required: true, Schema: &Schema{Type: TypeString}}
+	document := Generate(Info{}, []Route{
+		{Method: http.MethodGet, Path: "/items/:id", Operation: Operation{ID: "getItem", Parameters: []Parameter{declared}}},
+	})
+
+	operation, _ := document.Operation(http.MethodGet, "/items/:id")
+	assert.Equal(t, []Parameter{declared}, operation.Parameters)
+}
+
+func TestGenerate_ShouldDescribeBodiesAndResponses(t *testing.T) {
+	document := Generate(Info{Version: Version}, []Route{
+		{Method: http.MethodPost, Path: "/items", Operation: Operation{
+			ID:          "createItem",
+			RequestBody: item{},
+			Responses: []Response{
+				{Status: http.StatusCreated, Body: item{}},
+				{Status: http.StatusNoContent, Description: "Nothing to say"},
+				{Status: http.StatusOK, ContentType: "text/event-stream"},
+			},
+		}},
+	})
+
+	operation, _ := document.Operation(http.MethodPost, "/items")
+	ref := &Schema{Ref: "#/components/schemas/item"}
+	assert.Equal(t, &RequestBodySpec{Required: true, Content: map[string]MediaType{ContentTypeJSON: {Schema: ref}}}, operation.RequestBody)
+	assert.Equal(t, map[string]ResponseSpec{
+		"201": {Description: "Created", Content: map[string]MediaType{ContentTypeJSON: {Schema: ref}}},
+		"204": {Description: "Nothing to say"},
+		"200": {Description: "OK", Content: map[string]MediaType{"text/event-stream": {Schema: &Schema{Type: TypeString}}}},
+	}, operation.Responses)
+	assert.Contains(t, document.Components.Schemas, "item")
+}
+
+func TestOperation_ShouldReturnFalseWhenRouteIsNotDocumented(t *testing.T) {
+	document := Generate(Info{}, []Route{
+		{Method: http.MethodGet, Path: "/items", Operation: Operation{ID: "getItems"}},
+	})
+
+	_, ok := document.Operation(http.MethodDelete, "/items")
+	assert.False(t, ok)
+	_, ok = document.Operation(http.MethodGet, "/parts")
+	assert.False(t, ok)
+}
+
+func TestGenerate_ShouldListOtherMediaTypes(t *testing.T) {
+	document := Generate(Info{}, []Route{
+		{Method: http.MethodPost, Path: "/items", Operation: Operation{
+			ID:          "createItem",
+			RequestBody: item{},
+			Consumes:    []string{"application/msgpack"},
+			Produces:    []string{"text/csv"},
+			Responses: []Response{
+				{Status: http.StatusCreated, Body: item{}},
+				{Status: http.StatusBadRequest, Body: item{}},
+			},
+		}},
+	})
+
+	operation, ok := document.Operation(http.MethodPost, "/items")
+	assert.True(t, ok)
+	assert.Contains(t, operation.RequestBody.Content, "application/msgpack")
+	assert.Contains(t, operation.Responses["201"].Content, "text/csv")
+	assert.NotContains(t, operation.Responses["400"].Content, "text/csv")
+}
+
+func TestGenerate_ShouldListSecuritySchemes(t *testing.T) {
+	bearer := SecurityScheme{Name: "bearerAuth", Type: "http", Scheme: "bearer"}
+	document := Generate(Info{}, []Route{
+		{Method: http.MethodGet, Path: "/items", Operation: Operation{ID: "getItems", Security: []SecurityScheme{bearer}}},
+		{Method: http.MethodGet, Path: "/health", Operation: Operation{ID: "getHealth"}},
+	})
+
+	protected, _ := document.Operation(http.MethodGet, "/items")
+	public, _ := document.Operation(http.MethodGet, "/health")
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, protected.Security)
+	assert.Empty(t, public.Security)
+	assert.Equal(t, map[string]SecurityScheme{"bearerAuth": bearer}, document.Components.SecuritySchemes)
+}
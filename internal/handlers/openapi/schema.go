@@ -0,0 +1,192 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+
+	componentsPrefix = "#/components/schemas/"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// Schema is the subset of JSON Schema the generator produces. An empty schema
+// accepts any value.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// schemaGenerator registers named struct types as components, following the
+// encoding/json rules for field names, omitempty and embedded structs. The
+// openapi tag adds constraints to a field, e.g. `openapi:"enum=a|b,maxItems=10"`.
+type schemaGenerator struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		components: map[string]*Schema{},
+		names:      map[reflect.Type]string{},
+	}
+}
+
+func (g *schemaGenerator) schemaOf(value any) *Schema {
+	return g.schema(reflect.TypeOf(value))
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: TypeString, Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: TypeString}
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: TypeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: TypeNumber}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: TypeString, Format: "byte"}
+		}
+		return &Schema{Type: TypeArray, Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: TypeObject, AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return &Schema{Ref: componentsPrefix + g.component(t)}
+	default:
+		return &Schema{}
+	}
+}
+
+func (g *schemaGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := g.components[name]; taken {
+		name = packageName(t) + name
+	}
+	g.names[t] = name
+	// Registered before the fields so that recursive types end in a $ref.
+	g.components[name] = &Schema{}
+	*g.components[name] = *g.object(t)
+	return name
+}
+
+func (g *schemaGenerator) object(t reflect.Type) *Schema {
+	object := &Schema{Type: TypeObject, Properties: map[string]*Schema{}}
+	g.addFields(object, t)
+	return object
+}
+
+func (g *schemaGenerator) addFields(object *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			g.addFields(object, fieldType)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		schema := g.schema(field.Type)
+		if constraints, ok := field.Tag.Lookup("openapi"); ok {
+			if err := constrain(schema, constraints); err != nil {
+				panic(fmt.Sprintf("openapi: field %s of %s: %v", field.Name, t, err))
+			}
+		}
+		object.Properties[name] = schema
+		if !strings.Contains(options, "omitempty") {
+			object.Required = append(object.Required, name)
+		}
+	}
+}
+
+func constrain(schema *Schema, constraints string) error {
+	for _, constraint := range strings.Split(constraints, ",") {
+		key, value, _ := strings.Cut(constraint, "=")
+		switch key {
+		case "format":
+			schema.Format = value
+		case "enum":
+			schema.Enum = strings.Split(value, "|")
+		case "minItems", "maxItems":
+			limit, err := strconv.Atoi(value)
+			if err != nil {
+				return err
+			}
+			if key == "minItems" {
+				schema.MinItems = &limit
+			} else {
+				schema.MaxItems = &limit
+			}
+		default:
+			return fmt.Errorf("unknown constraint %q", key)
+		}
+	}
+	return nil
+}
+
+func packageName(t reflect.Type) string {
+	path := t.PkgPath()
+	name := path[strings.LastIndex(path, "/")+1:]
+	if name == "" {
+		return ""
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
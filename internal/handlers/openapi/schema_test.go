@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type base struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type node struct {
+	base
+	Name     string          `json:"name,omitempty"`
+	Payload  json.RawMessage `json:"payload"`
+	Data     []byte          `json:"data"`
+	Children []node          `json:"children"`
+	Labels   map[string]int  `json:"labels"`
+	Parent   *node           `json:"parent,omitempty"`
+	Ignored  string          `json:"-"`
+	internal string
+}
+
+func TestSchemaOf_ShouldFollowEncodingJSONRules(t *testing.T) {
+	generator := newSchemaGenerator()
+
+	schema := generator.schemaOf(node{})
+
+	ref := &Schema{Ref: "#/components/schemas/node"}
+	assert.Equal(t, ref, schema)
+	assert.Equal(t, &Schema{
+		Type: TypeObject,
+		Properties: map[string]*Schema{
+			"id":         {Type: TypeString},
+			"created_at": {Type: TypeString, Format: "date-time"},
+			"name":       {Type: TypeString},
+			"payload":    {},
+			"data":       {Type: TypeString, Format: "byte"},
+			"children":   {Type: TypeArray, Items: ref},
+			"labels":     {Type: TypeObject, AdditionalProperties: &Schema{Type: TypeInteger}},
+			"parent":     ref,
+		},
+		Required: []string{"id", "created_at", "payload", "data", "children", "labels"},
+	}, generator.components["node"])
+}
+
+func TestSchemaOf_ShouldDescribeUnnamedTypesInline(t *testing.T) {
+	generator := newSchemaGenerator()
+
+	assert.Equal(t, &Schema{Type: TypeArray, Items: &Schema{Type: TypeNumber}}, generator.schemaOf([]float64{}))
+	assert.Equal(t, &Schema{Type: TypeObject, AdditionalProperties: &Schema{}}, generator.schemaOf(map[string]any{}))
+	assert.Equal(t, &Schema{Type: TypeObject, Properties: map[string]*Schema{"ok": {Type: TypeBoolean}}, Required: []string{"ok"}},
+		generator.schemaOf(struct {
+			OK bool `json:"ok"`
+		}{}))
+	assert.Empty(t, generator.components)
+}
+
+func TestSchemaOf_ShouldPrefixCollidingNamesWithPackage(t *testing.T) {
+	generator := newSchemaGenerator()
+	generator.components["Time"] = &Schema{}
+
+	type Time struct {
+		At string `json:"at"`
+	}
+
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/OpenapiTime"}, generator.schemaOf(Time{}))
+}
+
+func TestSchemaOf_ShouldApplyFieldConstraints(t *testing.T) {
+	generator := newSchemaGenerator()
+	one, ten := 1, 10
+
+	schema := generator.schemaOf(struct {
+		ID    string   `json:"id" openapi:"format=uuid"`
+		Mode  string   `json:"mode" openapi:"enum=a|b"`
+		Items []string `json:"items" openapi:"minItems=1,maxItems=10"`
+	}{})
+
+	assert.Equal(t, &Schema{Type: TypeString, Format: "uuid"}, schema.Properties["id"])
+	assert.Equal(t, &Schema{Type: TypeString, Enum: []string{"a", "b"}}, schema.Properties["mode"])
+	assert.Equal(t, &Schema{Type: TypeArray, Items: &Schema{Type: TypeString}, MinItems: &one, MaxItems: &ten}, schema.Properties["items"])
+}
+
+func TestSchemaOf_ShouldPanicWhenConstraintIsUnknown(t *testing.T) {
+	assert.Panics(t, func() {
+		newSchemaGenerator().schemaOf(struct {
+			Name string `json:"name" openapi:"pattern=.*"`
+		}{})
+	})
+}
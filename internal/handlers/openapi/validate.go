@@ -0,0 +1,264 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const InBody = "body"
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Violation is a part of a request or response that does not match the
+// document. Field is the parameter name, or a JSON pointer into the body.
+type Violation struct {
+	In      string
+	Field   string
+	Message string
+}
+
+// ValidateRequest checks the parameters and the JSON body of a request to
+// operation. pathParameters holds the values matched by the router.
+// Request bodies of other media types are left to the handler.
+func (d Document) ValidateRequest(operation *OperationSpec, r *http.Request, pathParameters map[string]string, body []byte) []Violation {
+	var violations []Violation
+	for _, parameter := range operation.Parameters {
+		value, ok := parameterValue(parameter, r, pathParameters)
+		if !ok {
+			if parameter.Required {
+				violations = append(violations, Violation{In: parameter.In, Field: parameter.Name, Message: "is required"})
+			}
+			continue
+		}
+		for _, violation := range d.validateParameter(parameter.Schema, value) {
+			violations = append(violations, Violation{In: parameter.In, Field: parameter.Name, Message: violation.Message})
+		}
+	}
+
+	if operation.HasJSONBody() {
+		schema := operation.RequestBody.Content[ContentTypeJSON].Schema
+		violations = append(violations, d.validateBody(schema, body, operation.RequestBody.Required)...)
+	}
+	return violations
+}
+
+func (o *OperationSpec) HasJSONBody() bool {
+	if o.RequestBody == nil {
+		return false
+	}
+	_, ok := o.RequestBody.Content[ContentTypeJSON]
+	return ok
+}
+
+// ValidateResponse checks that status is documented for operation and that
+// body matches its JSON schema, or is empty when it has no content.
+func (d Document) ValidateResponse(operation *OperationSpec, status int, body []byte) []Violation {
+	response, ok := operation.Responses[strconv.Itoa(status)]
+	if !ok {
+		return []Violation{{In: InBody, Message: fmt.Sprintf("status %d is not documented", status)}}
+	}
+
+	media, ok := response.Content[ContentTypeJSON]
+	if !ok {
+		if len(response.Content) == 0 && len(body) > 0 {
+			return []Violation{{In: InBody, Message: "must be empty"}}
+		}
+		return nil
+	}
+	return d.validateBody(media.Schema, body, true)
+}
+
+func parameterValue(parameter Parameter, r *http.Request, pathParameters map[string]string) (string, bool) {
+	switch parameter.In {
+	case InPath:
+		value, ok := pathParameters[parameter.Name]
+		return value, ok
+	case InQuery:
+		values, ok := r.URL.Query()[parameter.Name]
+		if !ok {
+			return "", false
+		}
+		return values[0], true
+	case InHeader:
+		values := r.Header.Values(parameter.Name)
+		if len(values) == 0 {
+			return "", false
+		}
+		return values[0], true
+	default:
+		return "", false
+	}
+}
+
+func (d Document) validateParameter(schema *Schema, raw string) []Violation {
+	schema = d.resolve(schema)
+	var value any = raw
+	switch schema.Type {
+	case TypeInteger, TypeNumber:
+		value = json.Number(raw)
+	case TypeBoolean:
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			return []Violation{{Message: "must be a boolean"}}
+		}
+		value = enabled
+	}
+	return d.validate(schema, value, "")
+}
+
+func (d Document) validateBody(schema *Schema, body []byte, required bool) []Violation {
+	if len(bytes.TrimSpace(body)) == 0 {
+		if required {
+			return []Violation{{In: InBody, Message: "is required"}}
+		}
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return []Violation{{In: InBody, Message: "must be valid JSON: " + err.Error()}}
+	}
+
+	violations := d.validate(schema, value, "")
+	for i := range violations {
+		violations[i].In = InBody
+	}
+	return violations
+}
+
+// validate checks a value decoded with json.Number against the subset of
+// JSON Schema the generator produces.
+func (d Document) validate(schema *Schema, value any, pointer string) []Violation {
+	schema = d.resolve(schema)
+	invalid := func(format string, args ...any) []Violation {
+		return []Violation{{Field: pointer, Message: fmt.Sprintf(format, args...)}}
+	}
+
+	switch schema.Type {
+	case TypeObject:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return invalid("must be an object")
+		}
+		return d.validateObject(schema, object, pointer)
+	case TypeArray:
+		items, ok := value.([]any)
+		if !ok {
+			return invalid("must be an array")
+		}
+		if schema.MinItems != nil && len(items) < *schema.MinItems {
+			return invalid("must have at least %d items", *schema.MinItems)
+		}
+		if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+			return invalid("must have at most %d items", *schema.MaxItems)
+		}
+		var violations []Violation
+		for i, item := range items {
+			violations = append(violations, d.validate(schema.Items, item, pointer+"/"+strconv.Itoa(i))...)
+		}
+		return violations
+	case TypeString:
+		text, ok := value.(string)
+		if !ok {
+			return invalid("must be a string")
+		}
+		if message := checkString(schema, text); message != "" {
+			return invalid("%s", message)
+		}
+	case TypeInteger:
+		number, ok := value.(json.Number)
+		if _, err := number.Int64(); !ok || err != nil {
+			return invalid("must be an integer")
+		}
+	case TypeNumber:
+		number, ok := value.(json.Number)
+		if _, err := number.Float64(); !ok || err != nil {
+			return invalid("must be a number")
+		}
+	case TypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return invalid("must be a boolean")
+		}
+	}
+	return nil
+}
+
+func (d Document) validateObject(schema *Schema, object map[string]any, pointer string) []Violation {
+	var violations []Violation
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			violations = append(violations, Violation{Field: pointer + "/" + escapePointer(name), Message: "is required"})
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property, ok := schema.Properties[name]
+		if !ok {
+			property = schema.AdditionalProperties
+		}
+		if property != nil {
+			violations = append(violations, d.validate(property, object[name], pointer+"/"+escapePointer(name))...)
+		}
+	}
+	return violations
+}
+
+func checkString(schema *Schema, text string) string {
+	if len(schema.Enum) > 0 && !contains(schema.Enum, text) {
+		return "must be one of " + strings.Join(schema.Enum, ", ")
+	}
+
+	switch schema.Format {
+	case "uuid":
+		if !uuidPattern.MatchString(text) {
+			return "must be a UUID"
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, text); err != nil {
+			return "must be an RFC 3339 date-time"
+		}
+	case "byte":
+		if _, err := base64.StdEncoding.DecodeString(text); err != nil {
+			return "must be base64 encoded"
+		}
+	}
+	return ""
+}
+
+func (d Document) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = d.Components.Schemas[strings.TrimPrefix(schema.Ref, componentsPrefix)]
+	}
+	if schema == nil {
+		return &Schema{}
+	}
+	return schema
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
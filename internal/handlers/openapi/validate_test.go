@@ -0,0 +1,132 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type order struct {
+	ID       string     `json:"id" openapi:"format=uuid"`
+	Status   string     `json:"status" openapi:"enum=open|closed"`
+	Lines    []line     `json:"lines" openapi:"minItems=1"`
+	Notes    string     `json:"notes,omitempty"`
+	PlacedAt *time.Time `json:"placed_at,omitempty"`
+}
+
+type line struct {
+	Quantity int     `json:"quantity"`
+	Price    float64 `json:"price"`
+	Gift     bool    `json:"gift"`
+}
+
+func orderDocument() (Document, *OperationSpec) {
+	document := Generate(Info{}, []Route{{
+		Method: http.MethodPost,
+		Path:   "/orders/:id",
+		Operation: Operation{
+			ID: "updateOrder",
+			Parameters: []Parameter{
+				{Name: "dry_run", In: InQuery, Schema: &Schema{Type: TypeBoolean}},
+				{Name: "limit", In: InQuery, Schema: &Schema{Type: TypeInteger}},
+				{Name: "X-Request-ID", In: InHeader, Required: true, Schema: &Schema{Type: TypeString}},
+			},
+			RequestBody: order{},
+			Responses: []Response{
+				{Status: http.StatusOK, Body: order{}},
+				{Status: http.StatusNoContent},
+			},
+		},
+	}})
+	operation, _ := document.Operation(http.MethodPost, "/orders/:id")
+	return document, operation
+}
+
+const validOrder = `{"id":"0b0b5c4c-7d1e-4a53-9a2b-8c0fbb7d7a10","status":"open","lines":[{"quantity":2,"price":1.5,"gift":false}],"placed_at":"2024-01-02T03:04:05Z"}`
+
+func TestValidateRequest_ShouldAcceptValidRequest(t *testing.T) {
+	document, operation := orderDocument()
+	request := httptest.NewRequest(http.MethodPost, "/orders/1?dry_run=true&limit=10", nil)
+	request.Header.Set("X-Request-ID", "abc")
+
+	violations := document.ValidateRequest(operation, request, map[string]string{"id": "1"}, []byte(validOrder))
+
+	assert.Empty(t, violations)
+}
+
+func TestValidateRequest_ShouldReportParameterViolations(t *testing.T) {
+	document, operation := orderDocument()
+	request := httptest.NewRequest(http.MethodPost, "/orders/1?dry_run=maybe&limit=ten", nil)
+
+	violations := document.ValidateRequest(operation, request, map[string]string{"id": "1"}, []byte(validOrder))
+
+	assert.Equal(t, []Violation{
+		{In: InQuery, Field: "dry_run", Message: "must be a boolean"},
+		{In: InQuery, Field: "limit", Message: "must be an integer"},
+		{In: InHeader, Field: "X-Request-ID", Message: "is required"},
+	}, violations)
+}
+
+func TestValidateRequest_ShouldReportBodyViolations(t *testing.T) {
+	document, operation := orderDocument()
+	request := httptest.NewRequest(http.MethodPost, "/orders/1", nil)
+	request.Header.Set("X-Request-ID", "abc")
+	body := `{"id":"1","status":"lost","lines":[{"quantity":1.5,"price":"free"}],"placed_at":"yesterday"}`
+
+	violations := document.ValidateRequest(operation, request, map[string]string{"id": "1"}, []byte(body))
+
+	assert.Equal(t, []Violation{
+		{In: InBody, Field: "/id", Message: "must be a UUID"},
+		{In: InBody, Field: "/lines/0/gift", Message: "is required"},
+		{In: InBody, Field: "/lines/0/price", Message: "must be a number"},
+		{In: InBody, Field: "/lines/0/quantity", Message: "must be an integer"},
+		{In: InBody, Field: "/placed_at", Message: "must be an RFC 3339 date-time"},
+		{In: InBody, Field: "/status", Message: "must be one of open, closed"},
+	}, violations)
+}
+
+func TestValidateRequest_ShouldReportMalformedBody(t *testing.T) {
+	document, operation := orderDocument()
+	request := httptest.NewRequest(http.MethodPost, "/orders/1", nil)
+	request.Header.Set("X-Request-ID", "abc")
+	pathParameters := map[string]string{"id": "1"}
+
+	assert.Equal(t, []Violation{{In: InBody, Message: "is required"}},
+		document.ValidateRequest(operation, request, pathParameters, nil))
+	assert.Equal(t, []Violation{{In: InBody, Message: "must be an object"}},
+		document.ValidateRequest(operation, request, pathParameters, []byte(`[]`)))
+	violations := document.ValidateRequest(operation, request, pathParameters, []byte(`{`))
+	if assert.Len(t, violations, 1) {
+		assert.Contains(t, violations[0].Message, "must be valid JSON")
+	}
+}
+
+func TestValidateRequest_ShouldIgnoreBodiesOfOtherMediaTypes(t *testing.T) {
+	document := Generate(Info{}, []Route{{
+		Method:    http.MethodPost,
+		Path:      "/lines",
+		Operation: Operation{ID: "importLines", RequestBody: line{}, RequestContentType: "application/x-ndjson"},
+	}})
+	operation, _ := document.Operation(http.MethodPost, "/lines")
+	request := httptest.NewRequest(http.MethodPost, "/lines", nil)
+
+	assert.Contains(t, operation.RequestBody.Content, "application/x-ndjson")
+	assert.NotContains(t, operation.RequestBody.Content, ContentTypeJSON)
+	assert.Empty(t, document.ValidateRequest(operation, request, nil, []byte("{}\n{}\n")))
+}
+
+func TestValidateResponse_ShouldCheckStatusAndBody(t *testing.T) {
+	document, operation := orderDocument()
+
+	assert.Empty(t, document.ValidateResponse(operation, http.StatusOK, []byte(validOrder)))
+	assert.Empty(t, document.ValidateResponse(operation, http.StatusNoContent, nil))
+	assert.Equal(t, []Violation{{In: InBody, Message: "must be empty"}},
+		document.ValidateResponse(operation, http.StatusNoContent, []byte(validOrder)))
+	assert.Equal(t, []Violation{{In: InBody, Message: "status 404 is not documented"}},
+		document.ValidateResponse(operation, http.StatusNotFound, nil))
+	assert.Equal(t, []Violation{{In: InBody, Field: "/lines", Message: "must have at least 1 items"}},
+		document.ValidateResponse(operation, http.StatusOK, []byte(`{"id":"0b0b5c4c-7d1e-4a53-9a2b-8c0fbb7d7a10","status":"open","lines":[]}`)))
+}
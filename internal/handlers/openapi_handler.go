@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/openapi"
+)
+
+const docsPage = `<!DOCTYPE html>
+<html>
+  <head>
+    <title>hexapi</title>
+    <meta charset="utf-8"/>
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+  </head>
+  <body>
+    <redoc spec-url="/openapi.json"></redoc>
+    <script src="https://cdn.redoc.ly/redoc/latest/bundles/redoc.standalone.js"></script>
+  </body>
+</html>
+`
+
+// openAPIHandler serves the document generated from the route table once
+// every route has been registered.
+type openAPIHandler struct {
+	document openapi.Document
+}
+
+func (h *openAPIHandler) getDocument(c *gin.Context) {
+	c.JSON(200, h.document)
+}
+
+func (h *openAPIHandler) getDocs(c *gin.Context) {
+	c.Data(200, "text/html; charset=utf-8", []byte(docsPage))
+}
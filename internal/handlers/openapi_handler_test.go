@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+const openAPIDocumentPath = "../../api/openapi.json"
+
+var updateOpenAPI = flag.Bool("update", false, "rewrite api/openapi.json from the route table")
+
+// Pages for people are registered outside the route table on purpose.
+var undocumentedRoutes = map[string]bool{
+	"/docs":               true,
+	"/graphql/playground": true,
+}
+
+func TestOpenAPI_ShouldDocumentEveryRegisteredRoute(t *testing.T) {
+	router := Server{playground: true}.setupRoutes()
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	var document openapi.Document
+	body := httpexpect.Default(t, server.URL).GET("/openapi.json").
+		Expect().Status(http.StatusOK).
+		Body().Raw()
+	assert.NoError(t, json.Unmarshal([]byte(body), &document))
+
+	documented := 0
+	for _, route := range router.Routes() {
+		if undocumentedRoutes[route.Path] {
+			continue
+		}
+		operation, ok := document.Operation(route.Method, route.Path)
+		if assert.True(t, ok, "%s %s is not documented", route.Method, route.Path) {
+			assert.NotEmpty(t, operation.Responses, "%s %s has no responses", route.Method, route.Path)
+		}
+		documented++
+	}
+
+	operations := 0
+	for _, pathItem := range document.Paths {
+		operations += len(pathItem)
+	}
+	assert.Equal(t, documented, operations, "the document has operations that are not routes")
+}
+
+func TestOpenAPI_ShouldMatchCommittedDocument(t *testing.T) {
+	docs := &openAPIHandler{}
+	document := openapi.Generate(apiInfo, apiRoutes(Server{}.routes(docs)))
+	generated, err := json.MarshalIndent(document, "", "  ")
+	assert.NoError(t, err)
+	generated = append(generated, '\n')
+
+	if *updateOpenAPI {
+		assert.NoError(t, os.WriteFile(openAPIDocumentPath, generated, 0o644))
+		return
+	}
+
+	committed, err := os.ReadFile(openAPIDocumentPath)
+	assert.NoError(t, err)
+	assert.Equal(t, string(committed), string(generated), "api/openapi.json is stale, run make openapi")
+}
+
+func TestOpenAPI_ShouldServeDocsPage(t *testing.T) {
+	server := httptest.NewServer(Server{}.setupRoutes())
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	response := e.GET("/docs").Expect().Status(http.StatusOK)
+	response.Header("Content-Type").Contains("text/html")
+	response.Body().Contains(`spec-url="/openapi.json"`)
+}
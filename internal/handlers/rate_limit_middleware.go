@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/config"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+const (
+	rateLimitLimitHeader     = "RateLimit-Limit"
+	rateLimitRemainingHeader = "RateLimit-Remaining"
+	rateLimitResetHeader     = "RateLimit-Reset"
+	rateLimitPolicyHeader    = "RateLimit-Policy"
+	retryAfterHeader         = "Retry-After"
+)
+
+var errRateLimited = errors.New("rate limit exceeded")
+
+// rateLimitMiddleware limits the requests of each client to a route. Routes
+// are limited to the fallback unless listed, by "METHOD /path" as registered.
+// The zero value, without a repository, limits nothing. Limits are only as
+// shared as the repository, which is kept in memory, so each instance of the
+// API enforces them on its own.
+type rateLimitMiddleware struct {
+	repository ports.RateLimitRepository
+	fallback   domain.RateLimit
+	routes     map[string]domain.RateLimit
+	keyBy      string
+}
+
+func NewRateLimitMiddleware(repository ports.RateLimitRepository, fallback domain.RateLimit, routes map[string]domain.RateLimit, keyBy string) rateLimitMiddleware {
+	return rateLimitMiddleware{
+		repository: repository,
+		fallback:   fallback,
+		routes:     routes,
+		keyBy:      keyBy,
+	}
+}
+
+func (m rateLimitMiddleware) handle(method string, path string) gin.HandlerFunc {
+	route := method + " " + path
+	limit, ok := m.routes[route]
+	if !ok {
+		limit = m.fallback
+	}
+	return m.limit(route, limit)
+}
+
+// handleAll counts the requests to every route together against the
+// fallback.
+func (m rateLimitMiddleware) handleAll() gin.HandlerFunc {
+	return m.limit("*", m.fallback)
+}
+
+func (m rateLimitMiddleware) limit(route string, limit domain.RateLimit) gin.HandlerFunc {
+	if m.repository == nil || limit.IsUnlimited() {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	return func(c *gin.Context) {
+		decision, err := m.repository.Take(c.Request.Context(), m.clientKey(c)+" "+route, limit)
+		if err != nil {
+			// An unavailable store must not take the API down with it.
+			c.Error(err)
+			c.Next()
+			return
+		}
+
+		c.Header(rateLimitLimitHeader, strconv.Itoa(decision.Limit))
+		c.Header(rateLimitRemainingHeader, strconv.Itoa(decision.Remaining))
+		c.Header(rateLimitResetHeader, strconv.Itoa(ceilSeconds(decision.ResetAfter)))
+		c.Header(rateLimitPolicyHeader, fmt.Sprintf("%d;w=%d", limit.Requests, ceilSeconds(limit.Period)))
+		if !decision.Allowed {
+			c.Header(retryAfterHeader, strconv.Itoa(ceilSeconds(decision.RetryAfter)))
+			c.AbortWithStatusJSON(429, dto.ErrorResponse{Error: errors.Join(apperrors.TooManyRequests, errRateLimited).Error()})
+			return
+		}
+		c.Next()
+	}
+}
+
+// clientKey identifies who the request counts against. Clients are told apart
+// by their principal, falling back to their address when not authenticated.
+func (m rateLimitMiddleware) clientKey(c *gin.Context) string {
+	ctx := c.Request.Context()
+	switch m.keyBy {
+	case config.RateLimitKeyIP:
+		return "ip:" + c.ClientIP()
+	case config.RateLimitKeyTenant:
+		return "tenant:" + domain.TenantFromContext(ctx)
+	}
+
+	if principal, ok := domain.PrincipalFromContext(ctx); ok && principal.Subject != "" {
+		return "principal:" + domain.TenantFromContext(ctx) + "/" + principal.Subject
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(duration time.Duration) int {
+	return int(math.Ceil(duration.Seconds()))
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/google/uuid"
+	"github.com/hiago-balbino/hex-architecture-template/internal/auth"
+	"github.com/hiago-balbino/hex-architecture-template/internal/config"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	usecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/message"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/internal/repositories/memory"
+	"github.com/hiago-balbino/hex-architecture-template/internal/tenancy"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRateLimit_ShouldRejectRequestsOverLimitOfClient(t *testing.T) {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC))
+	server := Server{
+		rateLimit: NewRateLimitMiddleware(memory.NewRateLimitStorage(clockMock), domain.NewRateLimit(2, time.Minute), nil, config.RateLimitKeyClient),
+	}
+	httpServer := httptest.NewServer(server.setupRoutes())
+	t.Cleanup(httpServer.Close)
+
+	e := httpexpect.Default(t, httpServer.URL)
+	response := e.GET("/openapi.json").Expect().Status(http.StatusOK)
+	response.Header(rateLimitLimitHeader).IsEqual("2")
+	response.Header(rateLimitRemainingHeader).IsEqual("1")
+	response.Header(rateLimitResetHeader).IsEqual("30")
+	response.Header(rateLimitPolicyHeader).IsEqual("2;w=60")
+	e.GET("/openapi.json").Expect().Status(http.StatusOK).
+		Header(rateLimitRemainingHeader).IsEqual("0")
+
+	response = e.GET("/openapi.json").Expect().Status(http.StatusTooManyRequests)
+	response.Header(retryAfterHeader).IsEqual("30")
+	response.Header(rateLimitResetHeader).IsEqual("60")
+	response.Body().Contains(apperrors.TooManyRequests.Error())
+}
+
+func TestRateLimit_ShouldApplyLimitOfRoute(t *testing.T) {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC))
+	routes := map[string]domain.RateLimit{"GET /openapi.json": {}}
+	server := Server{
+		rateLimit: NewRateLimitMiddleware(memory.NewRateLimitStorage(clockMock), domain.NewRateLimit(1, time.Minute), routes, config.RateLimitKeyClient),
+	}
+	httpServer := httptest.NewServer(server.setupRoutes())
+	t.Cleanup(httpServer.Close)
+
+	e := httpexpect.Default(t, httpServer.URL)
+	for i := 0; i < 3; i++ {
+		e.GET("/openapi.json").Expect().Status(http.StatusOK).
+			Header(rateLimitLimitHeader).IsEmpty()
+	}
+}
+
+func TestRateLimit_ShouldCountRequestsByTenant(t *testing.T) {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC))
+	resolver, err := tenancy.NewResolver([]tenancy.Tenant{{ID: "acme"}, {ID: "globex"}}, "")
+	assert.NoError(t, err)
+	messages := memory.NewMessageStorage()
+	service := usecases.NewMessageService(identifier.NewUUIDGenerator(), clockMock, messages, memory.NewUnitOfWork(messages, memory.NewOutboxStorage(), memory.NewAuditStorage()), policy.NewRolePolicy())
+	server := Server{
+		messagehdl: NewMessageHandler(service),
+		tenancy:    NewTenantMiddleware(resolver),
+		rateLimit:  NewRateLimitMiddleware(memory.NewRateLimitStorage(clockMock), domain.NewRateLimit(1, time.Minute), nil, config.RateLimitKeyTenant),
+	}
+	httpServer := httptest.NewServer(server.setupRoutes())
+	t.Cleanup(httpServer.Close)
+
+	e := httpexpect.Default(t, httpServer.URL)
+	e.GET("/v2/messages").WithHeader(tenancy.Header, "acme").
+		Expect().Status(http.StatusOK)
+	e.GET("/v2/messages").WithHeader(tenancy.Header, "acme").
+		Expect().Status(http.StatusTooManyRequests)
+	e.GET("/v2/messages").WithHeader(tenancy.Header, "globex").
+		Expect().Status(http.StatusOK)
+}
+
+func TestRateLimit_ShouldAllowRequestsWhenStoreFails(t *testing.T) {
+	repositoryMock := new(mocks.RateLimitRepositoryMock)
+	repositoryMock.On("Take", mock.Anything, "ip:127.0.0.1 GET /openapi.json", domain.NewRateLimit(1, time.Minute)).
+		Return(domain.RateLimitDecision{}, errors.New("database is closed"))
+	server := Server{
+		rateLimit: NewRateLimitMiddleware(repositoryMock, domain.NewRateLimit(1, time.Minute), nil, config.RateLimitKeyClient),
+	}
+	httpServer := httptest.NewServer(server.setupRoutes())
+	t.Cleanup(httpServer.Close)
+
+	e := httpexpect.Default(t, httpServer.URL)
+	e.GET("/openapi.json").Expect().Status(http.StatusOK).
+		Header(rateLimitLimitHeader).IsEmpty()
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestRateLimit_ShouldNotTrustForwardedAddressFromUntrustedProxy(t *testing.T) {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC))
+	server := Server{
+		ipRateLimit: NewRateLimitMiddleware(memory.NewRateLimitStorage(clockMock), domain.NewRateLimit(1, time.Minute), nil, config.RateLimitKeyIP),
+	}
+	httpServer := httptest.NewServer(server.setupRoutes())
+	t.Cleanup(httpServer.Close)
+
+	e := httpexpect.Default(t, httpServer.URL)
+	e.GET("/openapi.json").WithHeader("X-Forwarded-For", "203.0.113.1").
+		Expect().Status(http.StatusOK)
+	e.GET("/openapi.json").WithHeader("X-Forwarded-For", "203.0.113.2").
+		Expect().Status(http.StatusTooManyRequests)
+	e.GET("/openapi.json").WithHeader("X-Real-IP", "203.0.113.3").
+		Expect().Status(http.StatusTooManyRequests)
+}
+
+func TestRateLimit_ShouldCountForwardedAddressFromTrustedProxy(t *testing.T) {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC))
+	server := Server{
+		ipRateLimit:    NewRateLimitMiddleware(memory.NewRateLimitStorage(clockMock), domain.NewRateLimit(1, time.Minute), nil, config.RateLimitKeyIP),
+		trustedProxies: []string{"127.0.0.1"},
+	}
+	httpServer := httptest.NewServer(server.setupRoutes())
+	t.Cleanup(httpServer.Close)
+
+	e := httpexpect.Default(t, httpServer.URL)
+	e.GET("/openapi.json").WithHeader("X-Forwarded-For", "203.0.113.1").
+		Expect().Status(http.StatusOK)
+	e.GET("/openapi.json").WithHeader("X-Forwarded-For", "203.0.113.2").
+		Expect().Status(http.StatusOK)
+	e.GET("/openapi.json").WithHeader("X-Forwarded-For", "203.0.113.1").
+		Expect().Status(http.StatusTooManyRequests)
+}
+
+func TestRateLimit_ShouldLimitAddressBeforeAuthentication(t *testing.T) {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC))
+	key, err := auth.NewHMACKey("", testSecret)
+	assert.NoError(t, err)
+	server := Server{
+		messagehdl:     NewMessageHandler(new(mocks.MessageUseCaseMock)),
+		authentication: NewAuthMiddleware(auth.NewVerifier([]auth.Key{key}, "", "", time.Minute, clockMock), nil),
+		ipRateLimit:    NewRateLimitMiddleware(memory.NewRateLimitStorage(clockMock), domain.NewRateLimit(2, time.Minute), nil, config.RateLimitKeyIP),
+	}
+	httpServer := httptest.NewServer(server.setupRoutes())
+	t.Cleanup(httpServer.Close)
+
+	e := httpexpect.Default(t, httpServer.URL)
+	e.GET("/v2/messages").WithHeader("Authorization", "Bearer guessed").
+		Expect().Status(http.StatusUnauthorized)
+	e.GET("/v2/messages/"+uuid.NewString()).WithHeader("Authorization", "Bearer guessed").
+		Expect().Status(http.StatusUnauthorized)
+	e.GET("/v2/messages").WithHeader("Authorization", "Bearer guessed").
+		Expect().Status(http.StatusTooManyRequests)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+)
+
+const (
+	requestIDHeader       = "X-Request-ID"
+	maxRequestIDLength    = 128
+	minRequestIDCharacter = '!'
+	maxRequestIDCharacter = '~'
+)
+
+// requestIDMiddleware keeps the X-Request-ID of the request, or generates one
+// when it is missing or not printable ASCII, and echoes it in the response.
+// The zero value, without a generator, only keeps the ids it is sent.
+type requestIDMiddleware struct {
+	uuidGenerator identifier.UUIDGenerator
+}
+
+func NewRequestIDMiddleware(uuidGenerator identifier.UUIDGenerator) requestIDMiddleware {
+	return requestIDMiddleware{uuidGenerator: uuidGenerator}
+}
+
+func (m requestIDMiddleware) handle(c *gin.Context) {
+	requestID := c.GetHeader(requestIDHeader)
+	if !validRequestID(requestID) {
+		requestID = ""
+		if m.uuidGenerator != nil {
+			requestID = m.uuidGenerator.New()
+		}
+	}
+	if requestID == "" {
+		c.Next()
+		return
+	}
+
+	c.Header(requestIDHeader, requestID)
+	c.Request = c.Request.WithContext(domain.ContextWithRequestID(c.Request.Context(), requestID))
+	c.Next()
+}
+
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < minRequestIDCharacter || requestID[i] > maxRequestIDCharacter {
+			return false
+		}
+	}
+	return true
+}
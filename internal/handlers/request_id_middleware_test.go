@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+)
+
+func TestRequestID_ShouldEchoValidRequestID(t *testing.T) {
+	server := Server{requestID: NewRequestIDMiddleware(new(mocks.UUIDGeneratorMock))}
+	httpServer := httptest.NewServer(server.setupRoutes())
+	t.Cleanup(httpServer.Close)
+
+	e := httpexpect.Default(t, httpServer.URL)
+	e.GET("/openapi.json").WithHeader(requestIDHeader, "request-1").
+		Expect().Status(http.StatusOK).
+		Header(requestIDHeader).IsEqual("request-1")
+}
+
+func TestRequestID_ShouldGenerateRequestIDWhenInvalid(t *testing.T) {
+	uuidGeneratorMock := new(mocks.UUIDGeneratorMock)
+	uuidGeneratorMock.On("New").Return("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	server := Server{requestID: NewRequestIDMiddleware(uuidGeneratorMock)}
+	httpServer := httptest.NewServer(server.setupRoutes())
+	t.Cleanup(httpServer.Close)
+
+	e := httpexpect.Default(t, httpServer.URL)
+	e.GET("/openapi.json").
+		Expect().Status(http.StatusOK).
+		Header(requestIDHeader).IsEqual("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	e.GET("/openapi.json").WithHeader(requestIDHeader, strings.Repeat("a", maxRequestIDLength+1)).
+		Expect().Status(http.StatusOK).
+		Header(requestIDHeader).IsEqual("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+}
@@ -0,0 +1,765 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/codec"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/openapi"
+	"github.com/hiago-balbino/hex-architecture-template/internal/tenancy"
+)
+
+var apiInfo = openapi.Info{
+	Title:   "hexapi",
+	Version: "1.0.0",
+}
+
+// route registers handlers on the router and describes them in the OpenAPI
+// document, so that the two cannot drift apart. Routes need authentication
+// unless they are public; queryToken also accepts the token in the query.
+type route struct {
+	method     string
+	path       string
+	handlers   []gin.HandlerFunc
+	operation  openapi.Operation
+	public     bool
+	queryToken bool
+}
+
+var (
+	idempotencyKeyParameter = openapi.Parameter{
+		Name:        idempotencyKeyHeader,
+		In:          openapi.InHeader,
+		Description: "Replays the stored response when the request is repeated with the same key and payload",
+		Schema:      &openapi.Schema{Type: openapi.TypeString},
+	}
+	messageIDParameter = openapi.Parameter{
+		Name:     "id",
+		In:       openapi.InPath,
+		Required: true,
+		Schema:   &openapi.Schema{Type: openapi.TypeString, Format: "uuid"},
+	}
+	importIDsParameter = openapi.Parameter{
+		Name:        "ids",
+		In:          openapi.InQuery,
+		Description: "Keeps the ids of the lines, or generates new ones. Defaults to preserve",
+		Schema:      &openapi.Schema{Type: openapi.TypeString, Enum: []string{string(domain.ImportIDsPreserve), string(domain.ImportIDsRegenerate)}},
+	}
+	importConflictParameter = openapi.Parameter{
+		Name:        "conflict",
+		In:          openapi.InQuery,
+		Description: "What to do with a line whose id is already stored. Defaults to fail",
+		Schema:      &openapi.Schema{Type: openapi.TypeString, Enum: []string{string(domain.ImportConflictSkip), string(domain.ImportConflictOverwrite), string(domain.ImportConflictFail)}},
+	}
+	accessTokenParameter = openapi.Parameter{
+		Name:        accessTokenQuery,
+		In:          openapi.InQuery,
+		Description: "The bearer token, for clients that cannot set the Authorization header",
+		Schema:      &openapi.Schema{Type: openapi.TypeString},
+	}
+	tenantParameter = openapi.Parameter{
+		Name:        tenancy.Header,
+		In:          openapi.InHeader,
+		Description: "The tenant to act in, when neither the host nor the credentials name one",
+		Schema:      &openapi.Schema{Type: openapi.TypeString},
+	}
+	auditMessageIDParameter = openapi.Parameter{
+		Name:        "message_id",
+		In:          openapi.InQuery,
+		Description: "Only lists the entries of this message",
+		Schema:      &openapi.Schema{Type: openapi.TypeString},
+	}
+	lastEventIDParameter = openapi.Parameter{
+		Name:        "Last-Event-ID",
+		In:          openapi.InHeader,
+		Description: "Resumes the stream after this change id",
+		Schema:      &openapi.Schema{Type: openapi.TypeString},
+	}
+)
+
+// Media types the message routes offer besides JSON.
+var (
+	messageMediaTypes = []string{codec.MediaTypeMsgPack, codec.MediaTypeProtobuf}
+	listMediaTypes    = []string{codec.MediaTypeMsgPack, codec.MediaTypeProtobuf, codec.MediaTypeCSV}
+	batchMediaTypes   = []string{codec.MediaTypeMsgPack}
+)
+
+func (s Server) routes(docs *openAPIHandler) []route {
+	contract := NewContractMiddleware(docs, s.validateResponses)
+	v1 := routeGroup{handlers: []gin.HandlerFunc{s.v1Deprecation.handle}, deprecated: s.v1Deprecation.deprecated()}
+
+	// The unversioned message routes are aliases of v1 kept for the clients
+	// written before versioning.
+	routes := v1.routes(s.messageRoutesV1(contract, s.messagehdl))
+	v1.prefix = "/v1"
+	routes = append(routes, v1.routes(s.messageRoutesV1(contract, s.messagehdl))...)
+	routes = append(routes, routeGroup{prefix: "/v2"}.routes(s.messageRoutesV2(contract, s.messagehdl.withMapper(v2Mapper{})))...)
+
+	routes = append(routes, []route{
+		{
+			method:   http.MethodGet,
+			path:     "/messages/export",
+			handlers: []gin.HandlerFunc{contract.handle, s.transferhdl.exportMessages},
+			operation: openapi.Operation{
+				ID:      "exportMessages",
+				Summary: "Export every message as NDJSON",
+				Tags:    []string{"messages"},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Description: "A message per line, followed by the X-Export-Count trailer when complete", ContentType: mediaTypeNDJSON, Body: dto.GetMessageResponse{}},
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/messages/import",
+			handlers: []gin.HandlerFunc{contract.handle, s.transferhdl.importMessages},
+			operation: openapi.Operation{
+				ID:                 "importMessages",
+				Summary:            "Import messages from NDJSON",
+				Tags:               []string{"messages"},
+				Parameters:         []openapi.Parameter{importIDsParameter, importConflictParameter},
+				RequestBody:        dto.ImportMessageRequest{},
+				RequestContentType: mediaTypeNDJSON,
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: dto.ImportReportResponse{}},
+					{Status: http.StatusMultiStatus, Description: "Some lines failed and were skipped", Body: dto.ImportReportResponse{}},
+					{Status: http.StatusBadRequest, Description: "Invalid options, or a line too long to read", Body: dto.ImportReportResponse{}},
+					errorResponse(http.StatusForbidden),
+					{Status: http.StatusConflict, Description: "A line conflicted under the fail policy", Body: dto.ImportReportResponse{}},
+					{Status: http.StatusTooManyRequests, Description: "Rate limited, or the daily quota of the tenant was exceeded by a chunk", Body: dto.ImportReportResponse{}},
+					{Status: http.StatusInternalServerError, Body: dto.ImportReportResponse{}},
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/messages/trash",
+			handlers: []gin.HandlerFunc{contract.handle, s.trashhdl.getTrashedMessages},
+			operation: openapi.Operation{
+				ID:      "getTrashedMessages",
+				Summary: "List the deleted messages that were not purged yet",
+				Tags:    []string{"messages"},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: dto.ListTrashedMessagesResponse{}},
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/message/:id/restore",
+			handlers: []gin.HandlerFunc{contract.handle, s.trashhdl.restoreMessage},
+			operation: openapi.Operation{
+				ID:      "restoreMessage",
+				Summary: "Restore a deleted message from the trash",
+				Tags:    []string{"messages"},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: dto.MessageResponseV2{}},
+					errorResponse(http.StatusForbidden),
+					{Status: http.StatusNotFound, Description: "Unknown, not deleted or already purged", Body: dto.ErrorResponse{}},
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/messages/stream",
+			handlers: []gin.HandlerFunc{s.streamhdl.streamMessages},
+			operation: openapi.Operation{
+				ID:         "streamMessages",
+				Summary:    "Stream message changes as Server-Sent Events",
+				Tags:       []string{"messages"},
+				Parameters: []openapi.Parameter{lastEventIDParameter, accessTokenParameter},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Description: "Events whose data is a change", ContentType: "text/event-stream"},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusServiceUnavailable),
+				},
+			},
+			queryToken: true,
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/ws",
+			handlers: []gin.HandlerFunc{s.wshdl.serveWebSocket},
+			operation: openapi.Operation{
+				ID:         "serveWebSocket",
+				Summary:    "Upgrade to a WebSocket accepting JSON commands",
+				Tags:       []string{"messages"},
+				Parameters: []openapi.Parameter{accessTokenParameter},
+				Responses: []openapi.Response{
+					{Status: http.StatusSwitchingProtocols},
+					errorResponse(http.StatusServiceUnavailable),
+				},
+			},
+			queryToken: true,
+		},
+		{
+			method:     http.MethodGet,
+			path:       "/graphql",
+			handlers:   []gin.HandlerFunc{gin.WrapH(s.graphqlhdl)},
+			operation:  graphQLOperation("getGraphQL", "Run a GraphQL query, or subscribe over WebSocket"),
+			queryToken: true,
+		},
+		{
+			method:    http.MethodPost,
+			path:      "/graphql",
+			handlers:  []gin.HandlerFunc{gin.WrapH(s.graphqlhdl)},
+			operation: graphQLOperation("postGraphQL", "Run a GraphQL operation"),
+		},
+		{
+			method:   http.MethodOptions,
+			path:     "/graphql",
+			handlers: []gin.HandlerFunc{gin.WrapH(s.graphqlhdl)},
+			operation: openapi.Operation{
+				ID:      "optionsGraphQL",
+				Summary: "List the methods allowed on the GraphQL endpoint",
+				Tags:    []string{"graphql"},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Description: "The methods are in the Allow header"},
+				},
+			},
+			// Browsers send CORS preflight requests without credentials.
+			public: true,
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/webhooks",
+			handlers: []gin.HandlerFunc{s.webhookhdl.createWebhook},
+			operation: openapi.Operation{
+				ID:          "createWebhook",
+				Summary:     "Register a webhook",
+				Tags:        []string{"webhooks"},
+				RequestBody: dto.CreateWebhookRequest{},
+				Responses: []openapi.Response{
+					{Status: http.StatusCreated, Body: dto.CreateWebhookResponse{}},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/webhooks",
+			handlers: []gin.HandlerFunc{s.webhookhdl.getWebhooks},
+			operation: openapi.Operation{
+				ID:      "getWebhooks",
+				Summary: "List every webhook",
+				Tags:    []string{"webhooks"},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: []dto.GetWebhookResponse{}},
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/webhooks/:id",
+			handlers: []gin.HandlerFunc{s.webhookhdl.getWebhook},
+			operation: openapi.Operation{
+				ID:      "getWebhook",
+				Summary: "Get a webhook",
+				Tags:    []string{"webhooks"},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: dto.GetWebhookResponse{}},
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusNotFound),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodDelete,
+			path:     "/webhooks/:id",
+			handlers: []gin.HandlerFunc{s.webhookhdl.deleteWebhook},
+			operation: openapi.Operation{
+				ID:      "deleteWebhook",
+				Summary: "Delete a webhook and its deliveries",
+				Tags:    []string{"webhooks"},
+				Responses: []openapi.Response{
+					{Status: http.StatusNoContent, Description: "Deleted, or did not exist"},
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/webhooks/:id/deliveries",
+			handlers: []gin.HandlerFunc{s.webhookhdl.getDeliveries},
+			operation: openapi.Operation{
+				ID:      "getWebhookDeliveries",
+				Summary: "List the deliveries of a webhook",
+				Tags:    []string{"webhooks"},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: []dto.WebhookDeliveryResponse{}},
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusNotFound),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/webhooks/:id/deliveries/:deliveryID/redeliver",
+			handlers: []gin.HandlerFunc{s.webhookhdl.redeliver},
+			operation: openapi.Operation{
+				ID:      "redeliverWebhookDelivery",
+				Summary: "Send a delivery again",
+				Tags:    []string{"webhooks"},
+				Responses: []openapi.Response{
+					{Status: http.StatusAccepted, Body: dto.WebhookDeliveryResponse{}},
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusNotFound),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/admin/api-keys",
+			handlers: []gin.HandlerFunc{s.apikeyhdl.createAPIKey},
+			operation: openapi.Operation{
+				ID:          "createAPIKey",
+				Summary:     "Create an API key, whose secret is only returned here",
+				Tags:        []string{"admin"},
+				RequestBody: dto.CreateAPIKeyRequest{},
+				Responses: []openapi.Response{
+					{Status: http.StatusCreated, Body: dto.CreateAPIKeyResponse{}},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/admin/api-keys",
+			handlers: []gin.HandlerFunc{s.apikeyhdl.getAPIKeys},
+			operation: openapi.Operation{
+				ID:      "getAPIKeys",
+				Summary: "List every API key, including the revoked ones",
+				Tags:    []string{"admin"},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: []dto.GetAPIKeyResponse{}},
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodDelete,
+			path:     "/admin/api-keys/:id",
+			handlers: []gin.HandlerFunc{s.apikeyhdl.revokeAPIKey},
+			operation: openapi.Operation{
+				ID:      "revokeAPIKey",
+				Summary: "Revoke an API key",
+				Tags:    []string{"admin"},
+				Responses: []openapi.Response{
+					{Status: http.StatusNoContent, Description: "Revoked, or already revoked"},
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusNotFound),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/audit",
+			handlers: []gin.HandlerFunc{s.audithdl.getAuditEntries},
+			operation: openapi.Operation{
+				ID:         "getAuditEntries",
+				Summary:    "List the audit log of the tenant in sequence order",
+				Tags:       []string{"audit"},
+				Parameters: []openapi.Parameter{auditMessageIDParameter},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: []dto.AuditEntryResponse{}},
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/audit/export",
+			handlers: []gin.HandlerFunc{s.audithdl.exportAuditEntries},
+			operation: openapi.Operation{
+				ID:      "exportAuditEntries",
+				Summary: "Export the audit log of the tenant as NDJSON",
+				Tags:    []string{"audit"},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Description: "An entry per line, followed by the X-Export-Count trailer when complete", ContentType: mediaTypeNDJSON, Body: dto.AuditEntryResponse{}},
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/openapi.json",
+			handlers: []gin.HandlerFunc{docs.getDocument},
+			operation: openapi.Operation{
+				ID:      "getOpenAPIDocument",
+				Summary: "Get this OpenAPI document",
+				Tags:    []string{"docs"},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: map[string]any{}},
+				},
+			},
+			public: true,
+		},
+	}...)
+	return rateLimited(protect(routes))
+}
+
+func (s Server) messageRoutesV1(contract contractMiddleware, handler messageHandler) []route {
+	return []route{
+		{
+			method:   http.MethodPost,
+			path:     "/message",
+			handlers: []gin.HandlerFunc{contract.handle, s.idempotency.handle, handler.createMessage},
+			operation: openapi.Operation{
+				ID:          "createMessage",
+				Summary:     "Create a message",
+				Tags:        []string{"messages"},
+				Consumes:    messageMediaTypes,
+				Produces:    messageMediaTypes,
+				Parameters:  []openapi.Parameter{idempotencyKeyParameter},
+				RequestBody: dto.CreateMessageRequest{},
+				Responses: []openapi.Response{
+					{Status: http.StatusCreated, Body: dto.CreateMessageResponse{}},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusUnprocessableEntity),
+					errorResponse(http.StatusNotAcceptable),
+					errorResponse(http.StatusUnsupportedMediaType),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/message/:id",
+			handlers: []gin.HandlerFunc{contract.handle, handler.getMessage},
+			operation: openapi.Operation{
+				ID:         "getMessage",
+				Summary:    "Get a message",
+				Tags:       []string{"messages"},
+				Produces:   messageMediaTypes,
+				Parameters: []openapi.Parameter{messageIDParameter},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: dto.GetMessageResponse{}},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusNotFound),
+					errorResponse(http.StatusNotAcceptable),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/messages",
+			handlers: []gin.HandlerFunc{contract.handle, handler.getMessages},
+			operation: openapi.Operation{
+				ID:       "getMessages",
+				Summary:  "List every message",
+				Tags:     []string{"messages"},
+				Produces: listMediaTypes,
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: []dto.GetMessageResponse{}},
+					errorResponse(http.StatusNotAcceptable),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodDelete,
+			path:     "/message/:id",
+			handlers: []gin.HandlerFunc{contract.handle, handler.deleteMessage},
+			operation: openapi.Operation{
+				ID:         "deleteMessage",
+				Summary:    "Delete a message",
+				Tags:       []string{"messages"},
+				Parameters: []openapi.Parameter{messageIDParameter},
+				Responses: []openapi.Response{
+					{Status: http.StatusNoContent, Description: "Deleted, or did not exist"},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/messages:batch",
+			handlers: []gin.HandlerFunc{customMethod("batch"), contract.handle, s.idempotency.handle, handler.createMessages},
+			operation: openapi.Operation{
+				ID:          "createMessages",
+				Summary:     "Create messages in a batch",
+				Tags:        []string{"messages"},
+				Produces:    batchMediaTypes,
+				Parameters:  []openapi.Parameter{idempotencyKeyParameter},
+				RequestBody: dto.BatchCreateMessagesRequest{},
+				Responses: []openapi.Response{
+					{Status: http.StatusCreated, Body: dto.BatchMessagesResponse{}},
+					{Status: http.StatusMultiStatus, Description: "Some messages failed in best_effort mode", Body: dto.BatchMessagesResponse{}},
+					{Status: http.StatusUnprocessableEntity, Description: "An atomic batch was aborted", Body: dto.BatchMessagesResponse{}},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusNotAcceptable),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodDelete,
+			path:     "/messages:batch",
+			handlers: []gin.HandlerFunc{customMethod("batch"), contract.handle, handler.deleteMessages},
+			operation: openapi.Operation{
+				ID:          "deleteMessages",
+				Summary:     "Delete messages in a batch",
+				Tags:        []string{"messages"},
+				Produces:    batchMediaTypes,
+				RequestBody: dto.BatchDeleteMessagesRequest{},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: dto.BatchMessagesResponse{}},
+					{Status: http.StatusMultiStatus, Description: "Some messages failed in best_effort mode", Body: dto.BatchMessagesResponse{}},
+					{Status: http.StatusUnprocessableEntity, Description: "An atomic batch was aborted", Body: dto.BatchMessagesResponse{}},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusNotAcceptable),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+	}
+}
+
+func (s Server) messageRoutesV2(contract contractMiddleware, handler messageHandler) []route {
+	return []route{
+		{
+			method:   http.MethodPost,
+			path:     "/messages",
+			handlers: []gin.HandlerFunc{contract.handle, s.idempotency.handle, handler.createMessage},
+			operation: openapi.Operation{
+				ID:          "createMessage",
+				Summary:     "Create a message",
+				Tags:        []string{"messages"},
+				Consumes:    messageMediaTypes,
+				Produces:    messageMediaTypes,
+				Parameters:  []openapi.Parameter{idempotencyKeyParameter},
+				RequestBody: dto.CreateMessageRequest{},
+				Responses: []openapi.Response{
+					{Status: http.StatusCreated, Body: dto.MessageResponseV2{}},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusUnprocessableEntity),
+					errorResponse(http.StatusNotAcceptable),
+					errorResponse(http.StatusUnsupportedMediaType),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/messages",
+			handlers: []gin.HandlerFunc{contract.handle, handler.getMessages},
+			operation: openapi.Operation{
+				ID:       "getMessages",
+				Summary:  "List every message",
+				Tags:     []string{"messages"},
+				Produces: listMediaTypes,
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: dto.ListMessagesResponseV2{}},
+					errorResponse(http.StatusNotAcceptable),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodGet,
+			path:     "/messages/:id",
+			handlers: []gin.HandlerFunc{contract.handle, handler.getMessage},
+			operation: openapi.Operation{
+				ID:         "getMessage",
+				Summary:    "Get a message",
+				Tags:       []string{"messages"},
+				Produces:   messageMediaTypes,
+				Parameters: []openapi.Parameter{messageIDParameter},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: dto.MessageResponseV2{}},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusNotFound),
+					errorResponse(http.StatusNotAcceptable),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodDelete,
+			path:     "/messages/:id",
+			handlers: []gin.HandlerFunc{contract.handle, handler.deleteMessage},
+			operation: openapi.Operation{
+				ID:         "deleteMessage",
+				Summary:    "Delete a message",
+				Tags:       []string{"messages"},
+				Parameters: []openapi.Parameter{messageIDParameter},
+				Responses: []openapi.Response{
+					{Status: http.StatusNoContent, Description: "Deleted"},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusNotFound),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodPost,
+			path:     "/messages:batch",
+			handlers: []gin.HandlerFunc{customMethod("batch"), contract.handle, s.idempotency.handle, handler.createMessages},
+			operation: openapi.Operation{
+				ID:          "createMessages",
+				Summary:     "Create messages in a batch",
+				Tags:        []string{"messages"},
+				Produces:    batchMediaTypes,
+				Parameters:  []openapi.Parameter{idempotencyKeyParameter},
+				RequestBody: dto.BatchCreateMessagesRequest{},
+				Responses: []openapi.Response{
+					{Status: http.StatusCreated, Body: dto.BatchMessagesResponse{}},
+					{Status: http.StatusMultiStatus, Description: "Some messages failed in best_effort mode", Body: dto.BatchMessagesResponse{}},
+					{Status: http.StatusUnprocessableEntity, Description: "An atomic batch was aborted", Body: dto.BatchMessagesResponse{}},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusNotAcceptable),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+		{
+			method:   http.MethodDelete,
+			path:     "/messages:batch",
+			handlers: []gin.HandlerFunc{customMethod("batch"), contract.handle, handler.deleteMessages},
+			operation: openapi.Operation{
+				ID:          "deleteMessages",
+				Summary:     "Delete messages in a batch",
+				Tags:        []string{"messages"},
+				Produces:    batchMediaTypes,
+				RequestBody: dto.BatchDeleteMessagesRequest{},
+				Responses: []openapi.Response{
+					{Status: http.StatusOK, Body: dto.BatchMessagesResponse{}},
+					{Status: http.StatusMultiStatus, Description: "Some messages failed in best_effort mode", Body: dto.BatchMessagesResponse{}},
+					{Status: http.StatusUnprocessableEntity, Description: "An atomic batch was aborted", Body: dto.BatchMessagesResponse{}},
+					errorResponse(http.StatusBadRequest),
+					errorResponse(http.StatusNotAcceptable),
+					errorResponse(http.StatusForbidden),
+					errorResponse(http.StatusInternalServerError),
+				},
+			},
+		},
+	}
+}
+
+// routeGroup mounts routes under a version prefix, which also prefixes their
+// operation ids, and runs its handlers before theirs.
+type routeGroup struct {
+	prefix     string
+	handlers   []gin.HandlerFunc
+	deprecated bool
+}
+
+func (g routeGroup) routes(routes []route) []route {
+	version := strings.TrimPrefix(g.prefix, "/")
+	for i, route := range routes {
+		routes[i].path = g.prefix + route.path
+		routes[i].handlers = append(append([]gin.HandlerFunc{}, g.handlers...), route.handlers...)
+		routes[i].operation.Deprecated = g.deprecated
+		if version != "" {
+			routes[i].operation.ID = version + strings.ToUpper(route.operation.ID[:1]) + route.operation.ID[1:]
+			routes[i].operation.Tags = append(append([]string{}, route.operation.Tags...), version)
+		}
+	}
+	return routes
+}
+
+// protect documents the authentication of the routes that are not public.
+// Like the rest of the contract, it does not depend on the configuration, so
+// the 401 responses are documented even when authentication is disabled.
+func protect(routes []route) []route {
+	for i, route := range routes {
+		if route.public {
+			continue
+		}
+		routes[i].operation.Security = []openapi.SecurityScheme{bearerAuth, apiKeyAuth}
+		routes[i].operation.Parameters = append(route.operation.Parameters, tenantParameter)
+		responses := append(route.operation.Responses, errorResponse(http.StatusUnauthorized))
+		// The tenant middleware answers these too, but routes may already
+		// describe them with another body.
+		for _, status := range []int{http.StatusBadRequest, http.StatusForbidden} {
+			if !hasResponse(responses, status) {
+				responses = append(responses, errorResponse(status))
+			}
+		}
+		routes[i].operation.Responses = responses
+	}
+	return routes
+}
+
+// rateLimited documents the answer of the rate limit middleware, which runs on
+// every route, unless the route already describes it.
+func rateLimited(routes []route) []route {
+	for i, route := range routes {
+		if hasResponse(route.operation.Responses, http.StatusTooManyRequests) {
+			continue
+		}
+		routes[i].operation.Responses = append(route.operation.Responses, openapi.Response{
+			Status:      http.StatusTooManyRequests,
+			Description: "Rate limited, to retry after the Retry-After header, or over the daily quota of the tenant",
+			Body:        dto.ErrorResponse{},
+		})
+	}
+	return routes
+}
+
+func hasResponse(responses []openapi.Response, status int) bool {
+	for _, response := range responses {
+		if response.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+func graphQLOperation(id string, summary string) openapi.Operation {
+	return openapi.Operation{
+		ID:      id,
+		Summary: summary,
+		Tags:    []string{"graphql"},
+		Responses: []openapi.Response{
+			{Status: http.StatusOK, Description: "GraphQL response, which may carry errors", Body: map[string]any{}},
+		},
+	}
+}
+
+func errorResponse(status int) openapi.Response {
+	return openapi.Response{Status: status, Body: dto.ErrorResponse{}}
+}
+
+func apiRoutes(routes []route) []openapi.Route {
+	apiRoutes := make([]openapi.Route, len(routes))
+	for i, route := range routes {
+		apiRoutes[i] = openapi.Route{Method: route.method, Path: route.path, Operation: route.operation}
+	}
+	return apiRoutes
+}
@@ -1,37 +1,404 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/auth"
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/config"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	apikeyusecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/apikey"
+	auditusecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/audit"
 	usecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/message"
+	webhookusecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/webhook"
+	"github.com/hiago-balbino/hex-architecture-template/internal/eventbus"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/graphql"
+	grpchandlers "github.com/hiago-balbino/hex-architecture-template/internal/handlers/grpc"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers/openapi"
+	"github.com/hiago-balbino/hex-architecture-template/internal/outbox"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/internal/repositories/boltdb"
 	"github.com/hiago-balbino/hex-architecture-template/internal/repositories/memory"
+	"github.com/hiago-balbino/hex-architecture-template/internal/tenancy"
+	"github.com/hiago-balbino/hex-architecture-template/internal/trash"
+	"github.com/hiago-balbino/hex-architecture-template/internal/webhook"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
 	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+	grpclib "google.golang.org/grpc"
 )
 
+const shutdownTimeout = 10 * time.Second
+
 type Server struct {
-	messagehdl messageHandler
+	address           string
+	grpcAddress       string
+	grpcServer        *grpclib.Server
+	messagehdl        messageHandler
+	transferhdl       messageTransferHandler
+	webhookhdl        webhookHandler
+	apikeyhdl         apiKeyHandler
+	audithdl          auditHandler
+	trashhdl          messageTrashHandler
+	streamhdl         streamHandler
+	wshdl             websocketHandler
+	graphqlhdl        http.Handler
+	playground        bool
+	idempotency       idempotencyMiddleware
+	requestID         requestIDMiddleware
+	authentication    authMiddleware
+	tenancy           tenantMiddleware
+	rateLimit         rateLimitMiddleware
+	ipRateLimit       rateLimitMiddleware
+	trustedProxies    []string
+	validateResponses bool
+	v1Deprecation     apiDeprecation
+	storage           storage
+	events            *eventbus.Bus
+	feed              *changefeed.Feed
+	relay             outbox.Relay
+	dispatcher        webhook.Dispatcher
+	purger            trash.Purger
 }
 
-func NewServer() Server {
+type storage struct {
+	messages    ports.MessageRepository
+	outbox      ports.OutboxRepository
+	webhooks    ports.WebhookRepository
+	apiKeys     ports.APIKeyRepository
+	unitOfWork  ports.UnitOfWork
+	idempotency ports.IdempotencyRepository
+	rateLimits  ports.RateLimitRepository
+	audit       ports.AuditRepository
+	close       func() error
+}
+
+func NewServer(cfg config.Config) (Server, error) {
 	uuidGenerator := identifier.NewUUIDGenerator()
-	messageRepository := memory.NewMessageStorage()
-	messageService := usecases.NewMessageService(uuidGenerator, messageRepository)
-	messageHandler := NewMessageHandler(messageService)
+	systemClock := clock.NewSystemClock()
+
+	keys, err := auth.LoadKeys(cfg.JWTSecret, cfg.JWTPublicKeyFile, cfg.JWTJWKSFile)
+	if err != nil {
+		return Server{}, err
+	}
+	verifier := auth.NewVerifier(keys, cfg.JWTIssuer, cfg.JWTAudience, cfg.JWTLeeway, systemClock)
+	if !verifier.Enabled() && !cfg.APIKeysEnabled {
+		log.Print("[AUTH] neither JWT keys nor API keys are configured, requests are not authenticated")
+	}
+
+	tenants, err := tenancy.LoadTenants(cfg.TenantsFile)
+	if err != nil {
+		return Server{}, err
+	}
+	resolver, err := tenancy.NewResolver(tenants, cfg.TenantDomain)
+	if err != nil {
+		return Server{}, err
+	}
+
+	storage, err := openStorage(cfg, systemClock)
+	if err != nil {
+		return Server{}, err
+	}
+
+	apiKeyService := apikeyusecases.NewAPIKeyService(uuidGenerator, systemClock, storage.apiKeys, cfg.AdminAPIKey)
+	// A nil use case, rather than a service, leaves API keys out of authentication.
+	var apiKeys ports.APIKeyUseCase
+	if cfg.APIKeysEnabled {
+		apiKeys = apiKeyService
+	}
+
+	events := eventbus.NewBus(eventbus.Sync, nil)
+	relay := outbox.NewRelay(storage.outbox, events, cfg.OutboxInterval, cfg.OutboxBatchSize, cfg.OutboxMaxAttempts)
+	messageService := usecases.NewMessageService(uuidGenerator, systemClock, storage.messages, storage.unitOfWork, policy.NewRolePolicy()).WithQuotas(resolver)
+	auditedService := auditusecases.NewAuditedMessageService(messageService, storage.audit, systemClock)
+	messageHandler := NewMessageHandler(auditedService)
+	trashService := auditusecases.NewAuditedMessageTrashService(messageService, storage.audit, systemClock)
+	transferHandler := NewMessageTransferHandler(auditusecases.NewAuditedMessageTransferService(messageService, storage.audit, systemClock))
+	idempotency := NewIdempotencyMiddleware(storage.idempotency, systemClock, cfg.IdempotencyTTL)
+
+	webhookService := webhookusecases.NewWebhookService(uuidGenerator, systemClock, storage.webhooks)
+	if cfg.WebhookAllowPrivate {
+		webhookService = webhookService.WithPrivateAddresses()
+	}
+	events.SubscribeAll(webhookService.Notify)
+	webhookHandler := NewWebhookHandler(webhookService)
+	sender := webhook.NewHTTPSender(systemClock, cfg.WebhookTimeout, cfg.WebhookAllowPrivate)
+	feed := changefeed.NewFeed(cfg.StreamReplaySize, cfg.StreamSubscriberBuffer, policy.NewRolePolicy())
+	events.SubscribeAll(feed.Handle)
+	streamHandler := NewStreamHandler(feed, cfg.StreamHeartbeat)
+	websocketHandler := NewWebSocketHandler(auditedService, feed, cfg.WebSocketPingInterval, float64(cfg.WebSocketRateLimit), cfg.WebSocketRateBurst)
+
+	dispatcher := webhook.NewDispatcher(storage.webhooks, sender, systemClock, cfg.WebhookInterval, cfg.WebhookBatchSize, cfg.WebhookMaxAttempts, cfg.WebhookBackoff)
 
 	return Server{
-		messagehdl: messageHandler,
+		address:           cfg.Address,
+		grpcAddress:       cfg.GRPCAddress,
+		grpcServer:        grpchandlers.NewServer(auditedService, feed, verifier, apiKeys, resolver),
+		messagehdl:        messageHandler,
+		transferhdl:       transferHandler,
+		webhookhdl:        webhookHandler,
+		apikeyhdl:         NewAPIKeyHandler(apiKeyService),
+		trashhdl:          NewMessageTrashHandler(trashService),
+		audithdl:          NewAuditHandler(auditusecases.NewAuditService(storage.audit, policy.NewRolePolicy())),
+		streamhdl:         streamHandler,
+		wshdl:             websocketHandler,
+		graphqlhdl:        graphql.NewHandler(auditedService, feed, cfg.GraphQLComplexityLimit),
+		playground:        cfg.GraphQLPlayground,
+		validateResponses: cfg.ValidateResponses,
+		v1Deprecation:     NewAPIDeprecation(cfg.V1DeprecatedAt, cfg.V1SunsetAt, "/v2"),
+		idempotency:       idempotency,
+		requestID:         NewRequestIDMiddleware(uuidGenerator),
+		authentication:    NewAuthMiddleware(verifier, apiKeys),
+		tenancy:           NewTenantMiddleware(resolver),
+		rateLimit:         NewRateLimitMiddleware(storage.rateLimits, domainRateLimit(cfg.RateLimit), domainRouteRateLimits(cfg.RouteRateLimits), cfg.RateLimitKey),
+		ipRateLimit:       NewRateLimitMiddleware(storage.rateLimits, domainRateLimit(cfg.IPRateLimit), nil, config.RateLimitKeyIP),
+		trustedProxies:    cfg.TrustedProxies,
+		storage:           storage,
+		events:            events,
+		feed:              feed,
+		relay:             relay,
+		dispatcher:        dispatcher,
+		purger:            trash.NewPurger(trashService, resolver.Tenants(), systemClock, cfg.TrashPurgeInterval, cfg.TrashRetention),
+	}, nil
+}
+
+// Start serves HTTP and gRPC and runs the background workers until ctx is
+// done, then shuts everything down gracefully.
+func (s Server) Start(ctx context.Context) error {
+	defer s.storage.close()
+	defer s.events.Close()
+	defer s.feed.Close()
+
+	grpcListener, err := net.Listen("tcp", s.grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	for _, run := range []func(ctx context.Context){s.relay.Run, s.dispatcher.Run, s.purger.Run} {
+		workers.Add(1)
+		go func(run func(ctx context.Context)) {
+			defer workers.Done()
+			run(workersCtx)
+		}(run)
+	}
+	defer workers.Wait()
+	defer stopWorkers()
+
+	httpServer := &http.Server{
+		Addr:    s.address,
+		Handler: s.setupRoutes(),
+	}
+	// Open streams would otherwise hold Shutdown until its timeout.
+	httpServer.RegisterOnShutdown(s.feed.Close)
+	httpServer.RegisterOnShutdown(s.wshdl.shutdown)
+
+	serveErr := make(chan error, 2)
+	go func() {
+		serveErr <- httpServer.ListenAndServe()
+	}()
+	go func() {
+		serveErr <- s.grpcServer.Serve(grpcListener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		httpServer.Close()
+		s.grpcServer.Stop()
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		s.grpcServer.Stop()
+		return err
+	}
+	stopGRPC(shutdownCtx, s.grpcServer)
+
+	for i := 0; i < cap(serveErr); i++ {
+		if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+	}
+	return nil
+}
+
+// stopGRPC waits for in-flight calls until ctx is done and then closes the
+// remaining ones. Watch streams end as soon as the feed is closed.
+func stopGRPC(ctx context.Context, server *grpclib.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+		<-stopped
 	}
 }
 
-func (s Server) Start() {
-	router := s.setupRoutes()
-	router.Run(":8080")
+// Handler returns the HTTP routes without starting the server or its workers.
+func (s Server) Handler() http.Handler {
+	return s.setupRoutes()
 }
 
 func (s Server) setupRoutes() *gin.Engine {
-	router := gin.Default()
-	router.POST("/message", s.messagehdl.createMessage)
-	router.GET("/message/:id", s.messagehdl.getMessage)
-	router.GET("/messages", s.messagehdl.getMessages)
-	router.DELETE("/message/:id", s.messagehdl.deleteMessage)
+	router := gin.New()
+	router.Use(accessLog(gin.DefaultWriter), gin.Recovery())
+	// Only the listed proxies may name the client address in X-Forwarded-For,
+	// which would otherwise let any client pick the address it is limited by.
+	// config.Load has already checked the addresses.
+	_ = router.SetTrustedProxies(s.trustedProxies)
+	router.Use(s.requestID.handle)
+	docs := &openAPIHandler{}
+	routes := s.routes(docs)
+	negotiation := NewNegotiationMiddleware(defaultCodecs)
+	for _, route := range routes {
+		handlers := route.handlers
+		// Negotiation runs first so that the other handlers only see JSON request bodies.
+		if len(route.operation.Produces) > 0 || len(route.operation.Consumes) > 0 {
+			handlers = append([]gin.HandlerFunc{negotiation.handle(route.operation)}, handlers...)
+		}
+		// Authentication and tenancy run before the rate limit, which counts
+		// requests by client. Unauthenticated requests learn nothing else
+		// about the route. Every request first counts against its address,
+		// so that credentials cannot be guessed at an unlimited rate.
+		handlers = append([]gin.HandlerFunc{s.rateLimit.handle(route.method, route.path)}, handlers...)
+		if !route.public {
+			handlers = append([]gin.HandlerFunc{s.authentication.handle(route.queryToken), s.tenancy.handle}, handlers...)
+		}
+		handlers = append([]gin.HandlerFunc{s.ipRateLimit.handleAll()}, handlers...)
+		router.Handle(route.method, route.path, handlers...)
+	}
+	docs.document = openapi.Generate(apiInfo, apiRoutes(routes))
+
+	// Pages for people rather than API clients are left out of the document.
+	router.GET("/docs", docs.getDocs)
+	if s.playground {
+		router.GET("/graphql/playground", gin.WrapH(graphql.NewPlaygroundHandler("/graphql")))
+	}
 	return router
 }
+
+// gin has no way to escape ':' in a path, so "/messages:batch" is registered as
+// "/messages" followed by a wildcard and the method name is matched here.
+func customMethod(name string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Param(name) != ":"+name {
+			c.AbortWithStatusJSON(404, gin.H{"error": apperrors.NotFound.Error()})
+			return
+		}
+		c.Next()
+	}
+}
+
+func openStorage(cfg config.Config, clock clock.Clock) (storage, error) {
+	if cfg.Storage != config.StorageBolt {
+		messageRepository := memory.NewMessageStorage()
+		outboxRepository := memory.NewOutboxStorage()
+		auditRepository := memory.NewAuditStorage()
+		return storage{
+			messages:    messageRepository,
+			outbox:      outboxRepository,
+			webhooks:    memory.NewWebhookStorage(),
+			apiKeys:     memory.NewAPIKeyStorage(),
+			unitOfWork:  memory.NewUnitOfWork(messageRepository, outboxRepository, auditRepository),
+			idempotency: memory.NewIdempotencyStorage(clock),
+			rateLimits:  memory.NewRateLimitStorage(clock),
+			audit:       auditRepository,
+			close:       func() error { return nil },
+		}, nil
+	}
+
+	db, err := boltdb.Open(cfg.BoltPath)
+	if err != nil {
+		return storage{}, err
+	}
+
+	messageRepository, err := boltdb.NewMessageStorage(db)
+	if err != nil {
+		db.Close()
+		return storage{}, err
+	}
+
+	outboxRepository, err := boltdb.NewOutboxStorage(db)
+	if err != nil {
+		db.Close()
+		return storage{}, err
+	}
+
+	webhookRepository, err := boltdb.NewWebhookStorage(db)
+	if err != nil {
+		db.Close()
+		return storage{}, err
+	}
+
+	apiKeyRepository, err := boltdb.NewAPIKeyStorage(db)
+	if err != nil {
+		db.Close()
+		return storage{}, err
+	}
+
+	idempotencyRepository, err := boltdb.NewIdempotencyStorage(db, clock)
+	if err != nil {
+		db.Close()
+		return storage{}, err
+	}
+
+	// Usage is only counted through the unit of work, which needs its bucket.
+	if _, err := boltdb.NewUsageStorage(db); err != nil {
+		db.Close()
+		return storage{}, err
+	}
+
+	rateLimitRepository, err := boltdb.NewRateLimitStorage(db, clock)
+	if err != nil {
+		db.Close()
+		return storage{}, err
+	}
+
+	auditRepository, err := boltdb.NewAuditStorage(db)
+	if err != nil {
+		db.Close()
+		return storage{}, err
+	}
+
+	return storage{
+		messages:    messageRepository,
+		outbox:      outboxRepository,
+		webhooks:    webhookRepository,
+		apiKeys:     apiKeyRepository,
+		unitOfWork:  boltdb.NewUnitOfWork(db),
+		idempotency: idempotencyRepository,
+		rateLimits:  rateLimitRepository,
+		audit:       auditRepository,
+		close:       db.Close,
+	}, nil
+}
+
+func domainRateLimit(limit config.RateLimit) domain.RateLimit {
+	return domain.NewRateLimit(limit.Requests, limit.Period)
+}
+
+func domainRouteRateLimits(limits map[string]config.RateLimit) map[string]domain.RateLimit {
+	routeLimits := make(map[string]domain.RateLimit, len(limits))
+	for route, limit := range limits {
+		routeLimits[route] = domainRateLimit(limit)
+	}
+	return routeLimits
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+const (
+	streamRetry          = 3 * time.Second
+	streamEventReset     = "reset"
+	streamEventLagged    = "lagged"
+	streamEventShutdown  = "shutdown"
+	streamHeartbeatFrame = ": heartbeat\n\n"
+)
+
+var errInvalidLastEventID = errors.New("Last-Event-ID must be a change id")
+
+type streamHandler struct {
+	feed      *changefeed.Feed
+	heartbeat time.Duration
+}
+
+func NewStreamHandler(feed *changefeed.Feed, heartbeat time.Duration) streamHandler {
+	return streamHandler{
+		feed:      feed,
+		heartbeat: heartbeat,
+	}
+}
+
+// streamMessages pushes message changes as Server-Sent Events. A client that
+// reconnects with Last-Event-ID gets the changes it missed from the replay
+// buffer, or a reset event when they are gone and it has to reload.
+func (h streamHandler) streamMessages(c *gin.Context) {
+	subscription, complete, err := h.subscribe(c.Request.Context(), c.GetHeader("Last-Event-ID"))
+	if err != nil {
+		if errors.Is(err, apperrors.InvalidInput) {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(503, gin.H{"error": err.Error()})
+		return
+	}
+	defer subscription.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry.Milliseconds())
+	if !complete {
+		writeStreamEvent(c.Writer, "", streamEventReset, "{}")
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, streamHeartbeatFrame); err != nil {
+				return
+			}
+		case change, ok := <-subscription.Changes():
+			if !ok {
+				h.writeEnd(c.Writer, subscription.Err())
+				return
+			}
+			data, err := json.Marshal(dto.BuildResponseChange(change.ID, change.Event))
+			if err != nil {
+				continue
+			}
+			if err := writeStreamEvent(c.Writer, strconv.FormatUint(change.ID, 10), change.Event.EventName(), string(data)); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func (h streamHandler) subscribe(ctx context.Context, lastEventID string) (*changefeed.Subscription, bool, error) {
+	if lastEventID == "" {
+		subscription, err := h.feed.Subscribe(ctx, nil)
+		return subscription, true, err
+	}
+
+	lastID, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil {
+		return nil, false, errors.Join(apperrors.InvalidInput, errInvalidLastEventID)
+	}
+	return h.feed.SubscribeAfter(ctx, lastID, nil)
+}
+
+// writeEnd tells the client why the stream ends before closing it. A lagged
+// client reconnects with Last-Event-ID and catches up from the replay buffer.
+func (h streamHandler) writeEnd(w gin.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, changefeed.ErrLagged):
+		writeStreamEvent(w, "", streamEventLagged, "{}")
+	case errors.Is(err, changefeed.ErrClosed):
+		writeStreamEvent(w, "", streamEventShutdown, "{}")
+	}
+	w.Flush()
+}
+
+func writeStreamEvent(w io.Writer, id string, event string, data string) error {
+	frame := ""
+	if id != "" {
+		frame += "id: " + id + "\n"
+	}
+	frame += "event: " + event + "\ndata: " + data + "\n\n"
+
+	_, err := io.WriteString(w, frame)
+	return err
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamMessages_ShouldReturnErrorWhenLastEventIDIsInvalid(t *testing.T) {
+	server := httptest.NewServer(setupStreamHandler(changefeed.NewFeed(10, 10, policy.NewRolePolicy()), time.Minute))
+	t.Cleanup(server.Close)
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/messages/stream").
+		WithHeader("Last-Event-ID", "abc").
+		Expect().Status(http.StatusBadRequest).
+		Body().Contains(apperrors.InvalidInput.Error())
+}
+
+func TestStreamMessages_ShouldPushPublishedChanges(t *testing.T) {
+	feed := changefeed.NewFeed(10, 10, policy.NewRolePolicy())
+	server := httptest.NewServer(setupStreamHandler(feed, time.Minute))
+	t.Cleanup(server.Close)
+
+	stream := openStream(t, server.URL, "")
+	assert.Equal(t, "text/event-stream", stream.contentType)
+	assert.Equal(t, []string{"retry: 3000"}, stream.next(t))
+
+	created := domain.NewMessageCreated(domain.NewMessage("message-id", "message content"), time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, feed.Handle(context.Background(), created))
+
+	frame := stream.next(t)
+	assert.Equal(t, "id: 1", frame[0])
+	assert.Equal(t, "event: message.created", frame[1])
+	assert.JSONEq(t, `{
+		"id": 1,
+		"event": "message.created",
+		"aggregate_id": "message-id",
+		"occurred_at": "2023-10-01T12:00:00Z",
+		"data": {"message": {"id": "message-id", "content": "message content"}, "occurred_at": "2023-10-01T12:00:00Z"}
+	}`, strings.TrimPrefix(frame[2], "data: "))
+}
+
+func TestStreamMessages_ShouldReplayChangesAfterLastEventID(t *testing.T) {
+	feed := changefeed.NewFeed(10, 10, policy.NewRolePolicy())
+	for _, id := range []string{"id1", "id2", "id3"} {
+		assert.NoError(t, feed.Handle(context.Background(), domain.NewMessageDeleted(id, time.Now())))
+	}
+
+	server := httptest.NewServer(setupStreamHandler(feed, time.Minute))
+	t.Cleanup(server.Close)
+
+	stream := openStream(t, server.URL, "1")
+	stream.next(t)
+
+	assert.Equal(t, "id: 2", stream.next(t)[0])
+	assert.Equal(t, "id: 3", stream.next(t)[0])
+}
+
+func TestStreamMessages_ShouldSendResetWhenReplayIsIncomplete(t *testing.T) {
+	feed := changefeed.NewFeed(1, 10, policy.NewRolePolicy())
+	for _, id := range []string{"id1", "id2", "id3"} {
+		assert.NoError(t, feed.Handle(context.Background(), domain.NewMessageDeleted(id, time.Now())))
+	}
+
+	server := httptest.NewServer(setupStreamHandler(feed, time.Minute))
+	t.Cleanup(server.Close)
+
+	stream := openStream(t, server.URL, "1")
+	stream.next(t)
+
+	assert.Equal(t, []string{"event: reset", "data: {}"}, stream.next(t))
+	assert.Equal(t, "id: 3", stream.next(t)[0])
+}
+
+func TestStreamMessages_ShouldSendHeartbeats(t *testing.T) {
+	server := httptest.NewServer(setupStreamHandler(changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 10*time.Millisecond))
+	t.Cleanup(server.Close)
+
+	stream := openStream(t, server.URL, "")
+	stream.next(t)
+
+	assert.Equal(t, []string{": heartbeat"}, stream.next(t))
+}
+
+func TestStreamMessages_ShouldEndStreamWhenFeedCloses(t *testing.T) {
+	feed := changefeed.NewFeed(10, 10, policy.NewRolePolicy())
+	server := httptest.NewServer(setupStreamHandler(feed, time.Minute))
+	t.Cleanup(server.Close)
+
+	stream := openStream(t, server.URL, "")
+	stream.next(t)
+
+	feed.Close()
+
+	assert.Equal(t, []string{"event: shutdown", "data: {}"}, stream.next(t))
+	assert.False(t, stream.scanner.Scan())
+}
+
+func setupStreamHandler(feed *changefeed.Feed, heartbeat time.Duration) *gin.Engine {
+	server := Server{streamhdl: NewStreamHandler(feed, heartbeat)}
+	return server.setupRoutes()
+}
+
+type eventStream struct {
+	contentType string
+	scanner     *bufio.Scanner
+}
+
+func openStream(t *testing.T, url string, lastEventID string) eventStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/messages/stream", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastEventID != "" {
+		request.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { response.Body.Close() })
+
+	return eventStream{
+		contentType: response.Header.Get("Content-Type"),
+		scanner:     bufio.NewScanner(response.Body),
+	}
+}
+
+// next returns the lines of the next frame.
+func (s eventStream) next(t *testing.T) []string {
+	var lines []string
+	for s.scanner.Scan() {
+		if s.scanner.Text() == "" {
+			return lines
+		}
+		lines = append(lines, s.scanner.Text())
+	}
+	t.Fatalf("stream ended: %v", s.scanner.Err())
+	return nil
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/tenancy"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+// tenantMiddleware puts the tenant of the request in its context. It runs
+// after authentication, as principals may be bound to a tenant. The zero
+// value, whose resolver has no tenants, acts in the default tenant.
+type tenantMiddleware struct {
+	resolver tenancy.Resolver
+}
+
+func NewTenantMiddleware(resolver tenancy.Resolver) tenantMiddleware {
+	return tenantMiddleware{resolver: resolver}
+}
+
+func (m tenantMiddleware) handle(c *gin.Context) {
+	tenant, err := m.resolver.Resolve(c.Request.Context(), c.Request.Host, c.GetHeader(tenancy.Header))
+	if err != nil {
+		status := 400
+		if errors.Is(err, apperrors.Forbidden) {
+			status = 403
+		}
+		c.AbortWithStatusJSON(status, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.Request = c.Request.WithContext(domain.ContextWithTenant(c.Request.Context(), tenant))
+	c.Next()
+}
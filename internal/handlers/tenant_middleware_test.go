@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	usecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/message"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/internal/repositories/memory"
+	"github.com/hiago-balbino/hex-architecture-template/internal/tenancy"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenancy_ShouldRejectRequestsWithoutValidTenant(t *testing.T) {
+	server := httptest.NewServer(setupTenantHandler(t))
+	t.Cleanup(server.Close)
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/v2/messages").
+		Expect().Status(http.StatusBadRequest).
+		Body().Contains(apperrors.InvalidInput.Error())
+	e.GET("/v2/messages").WithHeader(tenancy.Header, "umbrella").
+		Expect().Status(http.StatusForbidden).
+		Body().Contains(apperrors.Forbidden.Error())
+	e.GET("/v2/messages").WithHeader(tenancy.Header, "initech").
+		Expect().Status(http.StatusForbidden).
+		Body().Contains("tenant is disabled")
+	e.GET("/openapi.json").
+		Expect().Status(http.StatusOK)
+}
+
+func TestTenancy_ShouldIsolateMessagesOfTenants(t *testing.T) {
+	server := httptest.NewServer(setupTenantHandler(t))
+	t.Cleanup(server.Close)
+
+	e := httpexpect.Default(t, server.URL)
+	created := e.POST("/v2/messages").WithHeader(tenancy.Header, "acme").WithHeader(idempotencyKeyHeader, "create-1").
+		WithJSON(dto.CreateMessageRequest{Content: "acme content"}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object()
+	id := created.Value("id").String().Raw()
+
+	e.GET("/v2/messages/"+id).WithHeader(tenancy.Header, "globex").
+		Expect().Status(http.StatusNotFound)
+	e.GET("/v2/messages").WithHeader(tenancy.Header, "globex").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("messages").Array().IsEmpty()
+	// The other tenant is answered as if the message did not exist, while it
+	// is kept.
+	e.DELETE("/v2/messages/"+id).WithHeader(tenancy.Header, "globex").
+		Expect().Status(http.StatusNotFound)
+	e.GET("/v2/messages/"+id).WithHeader(tenancy.Header, "acme").
+		Expect().Status(http.StatusOK)
+	// The same idempotency key creates another message rather than replaying
+	// the response of the other tenant.
+	e.POST("/v2/messages").WithHeader(tenancy.Header, "globex").WithHeader(idempotencyKeyHeader, "create-1").
+		WithJSON(dto.CreateMessageRequest{Content: "acme content"}).
+		Expect().Status(http.StatusCreated).
+		Header(idempotentReplayedHeader).IsEmpty()
+
+	e.GET("/v2/messages").WithHeader(tenancy.Header, "acme").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("messages").Array().Length().IsEqual(1)
+	e.DELETE("/v2/messages/"+id).WithHeader(tenancy.Header, "acme").
+		Expect().Status(http.StatusNoContent)
+	e.GET("/v2/messages").WithHeader(tenancy.Header, "globex").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("messages").Array().Length().IsEqual(1)
+}
+
+func TestTenancy_ShouldEnforceDailyMessageQuota(t *testing.T) {
+	resolver, err := tenancy.NewResolver([]tenancy.Tenant{{ID: "acme", DailyMessageQuota: 2}, {ID: "globex"}}, "")
+	assert.NoError(t, err)
+	messages := memory.NewMessageStorage()
+	service := usecases.NewMessageService(identifier.NewUUIDGenerator(), clock.NewSystemClock(), messages, memory.NewUnitOfWork(messages, memory.NewOutboxStorage(), memory.NewAuditStorage()), policy.NewRolePolicy()).
+		WithQuotas(resolver)
+	server := Server{
+		messagehdl:        NewMessageHandler(service),
+		tenancy:           NewTenantMiddleware(resolver),
+		validateResponses: true,
+	}
+	httpServer := httptest.NewServer(server.setupRoutes())
+	t.Cleanup(httpServer.Close)
+
+	e := httpexpect.Default(t, httpServer.URL)
+	e.POST("/v2/messages").WithHeader(tenancy.Header, "acme").
+		WithJSON(dto.CreateMessageRequest{Content: "first"}).
+		Expect().Status(http.StatusCreated)
+	// The batch would take the tenant over its quota, so none of it is kept.
+	e.POST("/v2/messages:batch").WithHeader(tenancy.Header, "acme").
+		WithJSON(dto.BatchCreateMessagesRequest{Mode: string(domain.BatchModeBestEffort), Messages: []dto.CreateMessageRequest{{Content: "second"}, {Content: "third"}}}).
+		Expect().Status(http.StatusTooManyRequests)
+	e.POST("/v2/messages").WithHeader(tenancy.Header, "acme").
+		WithJSON(dto.CreateMessageRequest{Content: "second"}).
+		Expect().Status(http.StatusCreated)
+	e.POST("/v2/messages").WithHeader(tenancy.Header, "acme").
+		WithJSON(dto.CreateMessageRequest{Content: "third"}).
+		Expect().Status(http.StatusTooManyRequests).
+		Body().Contains(apperrors.TooManyRequests.Error())
+	e.POST("/v2/messages").WithHeader(tenancy.Header, "globex").
+		WithJSON(dto.CreateMessageRequest{Content: "first"}).
+		Expect().Status(http.StatusCreated)
+
+	e.GET("/v2/messages").WithHeader(tenancy.Header, "acme").
+		Expect().Status(http.StatusOK).
+		JSON().Object().Value("messages").Array().Length().IsEqual(2)
+}
+
+func setupTenantHandler(t *testing.T) *gin.Engine {
+	resolver, err := tenancy.NewResolver([]tenancy.Tenant{{ID: "acme"}, {ID: "globex"}, {ID: "initech", Disabled: true}}, "")
+	assert.NoError(t, err)
+	systemClock := clock.NewSystemClock()
+	messages := memory.NewMessageStorage()
+	service := usecases.NewMessageService(identifier.NewUUIDGenerator(), systemClock, messages, memory.NewUnitOfWork(messages, memory.NewOutboxStorage(), memory.NewAuditStorage()), policy.NewRolePolicy())
+	server := Server{
+		messagehdl:        NewMessageHandler(service),
+		idempotency:       NewIdempotencyMiddleware(memory.NewIdempotencyStorage(systemClock), systemClock, time.Hour),
+		tenancy:           NewTenantMiddleware(resolver),
+		validateResponses: true,
+	}
+	return server.setupRoutes()
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	compatibilityMessageID      = "0f8e3c4a-5b6d-4e7f-8a9b-0c1d2e3f4a5b"
+	compatibilityOtherMessageID = "7c6b5a49-3827-4165-9f8e-7d6c5b4a3928"
+)
+
+// The files in testdata/v1 pin the bytes of v1 responses. They must never be
+// rewritten: a failure here means a change would break v1 clients, and it
+// belongs in a new version instead.
+func TestV1Compatibility_ShouldKeepResponsesByteStable(t *testing.T) {
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "create_message", method: http.MethodPost, path: "/message", body: `{"content":"hello"}`},
+		{name: "get_message", method: http.MethodGet, path: "/message/" + compatibilityMessageID},
+		{name: "get_message_not_found", method: http.MethodGet, path: "/message/" + compatibilityOtherMessageID},
+		{name: "get_message_invalid_id", method: http.MethodGet, path: "/message/42"},
+		{name: "get_messages", method: http.MethodGet, path: "/messages"},
+		{name: "delete_message", method: http.MethodDelete, path: "/message/" + compatibilityMessageID},
+		{name: "create_messages", method: http.MethodPost, path: "/messages:batch", body: `{"mode":"best_effort","messages":[{"content":"hello"},{"content":"rejected"}]}`},
+		{name: "create_messages_aborted", method: http.MethodPost, path: "/messages:batch", body: `{"messages":[{"content":"hello"},{"content":"rejected"}]}`},
+		{name: "create_messages_invalid_mode", method: http.MethodPost, path: "/messages:batch", body: `{"mode":"eventually","messages":[{"content":"hello"}]}`},
+		{name: "delete_messages", method: http.MethodDelete, path: "/messages:batch", body: `{"ids":["` + compatibilityMessageID + `","` + compatibilityOtherMessageID + `"]}`},
+	}
+
+	for _, prefix := range []string{"/v1", ""} {
+		server := httptest.NewServer(setupHandler(compatibilityService()))
+		t.Cleanup(server.Close)
+
+		for _, tc := range cases {
+			t.Run(tc.name+prefix, func(t *testing.T) {
+				expected, err := os.ReadFile(filepath.Join("testdata", "v1", tc.name+".txt"))
+				assert.NoError(t, err)
+
+				request, err := http.NewRequest(tc.method, server.URL+prefix+tc.path, strings.NewReader(tc.body))
+				assert.NoError(t, err)
+				response, err := http.DefaultClient.Do(request)
+				assert.NoError(t, err)
+				defer response.Body.Close()
+
+				assert.Equal(t, string(expected), snapshotResponse(t, response))
+			})
+		}
+	}
+}
+
+func TestV1Compatibility_ShouldMarkDeprecatedVersion(t *testing.T) {
+	deprecation := NewAPIDeprecation(compatibilityTime(t, "2024-01-01T00:00:00Z"), compatibilityTime(t, "2025-01-01T00:00:00Z"), "/v2")
+	server := httptest.NewServer(Server{messagehdl: NewMessageHandler(compatibilityService()), v1Deprecation: deprecation}.setupRoutes())
+	defer server.Close()
+
+	for _, path := range []string{"/v1/messages", "/messages"} {
+		response, err := http.Get(server.URL + path)
+		assert.NoError(t, err)
+		response.Body.Close()
+
+		assert.Equal(t, "@1704067200", response.Header.Get("Deprecation"))
+		assert.Equal(t, "Wed, 01 Jan 2025 00:00:00 GMT", response.Header.Get("Sunset"))
+		assert.Equal(t, `</v2>; rel="successor-version"`, response.Header.Get("Link"))
+	}
+
+	response, err := http.Get(server.URL + "/v2/messages")
+	assert.NoError(t, err)
+	response.Body.Close()
+	assert.Empty(t, response.Header.Get("Deprecation"))
+	assert.Empty(t, response.Header.Get("Sunset"))
+}
+
+func snapshotResponse(t *testing.T, response *http.Response) string {
+	body, err := io.ReadAll(response.Body)
+	assert.NoError(t, err)
+
+	var snapshot bytes.Buffer
+	fmt.Fprintf(&snapshot, "Status: %d\n", response.StatusCode)
+	if contentType := response.Header.Get("Content-Type"); contentType != "" {
+		fmt.Fprintf(&snapshot, "Content-Type: %s\n", contentType)
+	}
+	snapshot.WriteString("\n")
+	snapshot.Write(body)
+	return snapshot.String()
+}
+
+func compatibilityService() *mocks.MessageUseCaseMock {
+	message := domain.NewMessage(compatibilityMessageID, "hello")
+	rejected := errors.Join(apperrors.InvalidInput, errors.New("content was rejected"))
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, "hello").Return(message, nil)
+	serviceMock.On("GetByID", mock.Anything, compatibilityMessageID).Return(message, nil)
+	serviceMock.On("GetByID", mock.Anything, compatibilityOtherMessageID).Return(domain.Message{}, apperrors.NotFound)
+	serviceMock.On("ForEach", mock.Anything).Return([]domain.Message{message, domain.NewMessage(compatibilityOtherMessageID, "world")}, nil)
+	serviceMock.On("DeleteByID", mock.Anything, compatibilityMessageID).Return(nil)
+	serviceMock.On("SaveBatch", mock.Anything, []string{"hello", "rejected"}, domain.BatchModeBestEffort).
+		Return([]domain.BatchResult{domain.NewBatchResult(compatibilityMessageID, nil), domain.NewBatchResult("", rejected)}, nil)
+	serviceMock.On("SaveBatch", mock.Anything, []string{"hello", "rejected"}, domain.BatchModeAtomic).
+		Return([]domain.BatchResult{domain.NewBatchResult("", apperrors.Aborted), domain.NewBatchResult("", rejected)}, apperrors.UnprocessableEntity)
+	serviceMock.On("DeleteBatch", mock.Anything, []string{compatibilityMessageID, compatibilityOtherMessageID}, domain.BatchModeAtomic).
+		Return([]domain.BatchResult{domain.NewBatchResult(compatibilityMessageID, nil), domain.NewBatchResult(compatibilityOtherMessageID, nil)}, nil)
+	return serviceMock
+}
+
+func compatibilityTime(t *testing.T, value string) time.Time {
+	timestamp, err := time.Parse(time.RFC3339, value)
+	assert.NoError(t, err)
+	return timestamp
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+type webhookHandler struct {
+	service ports.WebhookUseCase
+}
+
+func NewWebhookHandler(service ports.WebhookUseCase) webhookHandler {
+	return webhookHandler{
+		service: service,
+	}
+}
+
+func (h webhookHandler) createWebhook(c *gin.Context) {
+	var webhookReqDto dto.CreateWebhookRequest
+	err := c.BindJSON(&webhookReqDto)
+	if err != nil {
+		c.JSON(400, gin.H{"error": errors.Join(apperrors.InvalidInput, err).Error()})
+		return
+	}
+
+	webhook, err := h.service.Create(c.Request.Context(), webhookReqDto.URL, webhookReqDto.Events, webhookReqDto.Secret)
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	c.JSON(201, dto.BuildResponseCreateWebhook(webhook))
+}
+
+func (h webhookHandler) getWebhook(c *gin.Context) {
+	webhook, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	c.JSON(200, dto.BuildResponseGetWebhook(webhook))
+}
+
+func (h webhookHandler) getWebhooks(c *gin.Context) {
+	webhooks, err := h.service.GetAll(c.Request.Context())
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	c.JSON(200, dto.BuildResponseGetWebhooks(webhooks))
+}
+
+func (h webhookHandler) deleteWebhook(c *gin.Context) {
+	err := h.service.DeleteByID(c.Request.Context(), c.Param("id"))
+	if err != nil && !errors.Is(err, apperrors.NotFound) {
+		respondWebhookError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h webhookHandler) getDeliveries(c *gin.Context) {
+	deliveries, err := h.service.GetDeliveries(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	c.JSON(200, dto.BuildResponseWebhookDeliveries(deliveries))
+}
+
+func (h webhookHandler) redeliver(c *gin.Context) {
+	delivery, err := h.service.Redeliver(c.Request.Context(), c.Param("id"), c.Param("deliveryID"))
+	if err != nil {
+		respondWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.BuildResponseWebhookDelivery(delivery))
+}
+
+func respondWebhookError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, apperrors.InvalidInput):
+		c.JSON(400, gin.H{"error": err.Error()})
+	case errors.Is(err, apperrors.Forbidden):
+		c.JSON(403, gin.H{"error": err.Error()})
+	case errors.Is(err, apperrors.NotFound):
+		c.JSON(404, gin.H{"error": err.Error()})
+	default:
+		c.JSON(500, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	usecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/message"
+	webhookusecases "github.com/hiago-balbino/hex-architecture-template/internal/core/usecases/webhook"
+	"github.com/hiago-balbino/hex-architecture-template/internal/eventbus"
+	"github.com/hiago-balbino/hex-architecture-template/internal/outbox"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/internal/repositories/memory"
+	"github.com/hiago-balbino/hex-architecture-template/internal/webhook"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/identifier"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateWebhook_ShouldReturnErrorWhenBindingRequestParams(t *testing.T) {
+	server := httptest.NewServer(setupWebhookHandler(nil))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/webhooks").
+		WithJSON(`{`).
+		Expect().Status(http.StatusBadRequest).
+		Body().Contains(apperrors.InvalidInput.Error())
+}
+
+func TestCreateWebhook_ShouldReturnBadRequestWhenInputIsInvalid(t *testing.T) {
+	body := dto.CreateWebhookRequest{URL: "localhost"}
+
+	serviceMock := new(mocks.WebhookUseCaseMock)
+	serviceMock.On("Create", mock.Anything, body.URL, body.Events, body.Secret).Return(domain.Webhook{}, apperrors.InvalidInput)
+
+	server := httptest.NewServer(setupWebhookHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/webhooks").
+		WithJSON(body).
+		Expect().Status(http.StatusBadRequest).
+		Body().Contains(apperrors.InvalidInput.Error())
+}
+
+func TestCreateWebhook_ShouldReturnSecretOnCreation(t *testing.T) {
+	body := dto.CreateWebhookRequest{URL: "http://localhost/hook", Events: []string{domain.EventMessageCreated}}
+	webhook := domain.NewWebhook("webhook-id", domain.DefaultTenant, body.URL, "generated-secret", body.Events, time.Now().UTC())
+
+	serviceMock := new(mocks.WebhookUseCaseMock)
+	serviceMock.On("Create", mock.Anything, body.URL, body.Events, "").Return(webhook, nil)
+
+	server := httptest.NewServer(setupWebhookHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	response := e.POST("/webhooks").
+		WithJSON(body).
+		Expect().Status(http.StatusCreated).
+		JSON().Object()
+
+	response.Value("id").IsEqual(webhook.ID)
+	response.Value("secret").IsEqual(webhook.Secret)
+	response.Value("events").Array().IsEqual(body.Events)
+}
+
+func TestGetWebhook_ShouldReturnErrorWhenWebhookNotFound(t *testing.T) {
+	serviceMock := new(mocks.WebhookUseCaseMock)
+	serviceMock.On("GetByID", mock.Anything, "webhook-id").Return(domain.Webhook{}, apperrors.NotFound)
+
+	server := httptest.NewServer(setupWebhookHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/webhooks/webhook-id").
+		Expect().Status(http.StatusNotFound).
+		Body().Contains(apperrors.NotFound.Error())
+}
+
+func TestGetWebhook_ShouldNotExposeSecret(t *testing.T) {
+	webhook := domain.NewWebhook("webhook-id", domain.DefaultTenant, "http://localhost/hook", "secret", nil, time.Now().UTC())
+
+	serviceMock := new(mocks.WebhookUseCaseMock)
+	serviceMock.On("GetByID", mock.Anything, webhook.ID).Return(webhook, nil)
+
+	server := httptest.NewServer(setupWebhookHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	response := e.GET("/webhooks/webhook-id").
+		Expect().Status(http.StatusOK).
+		JSON().Object()
+
+	response.Value("url").IsEqual(webhook.URL)
+	response.Value("events").Array().IsEmpty()
+	response.NotContainsKey("secret")
+}
+
+func TestGetWebhooks_ShouldReturnErrorWhenServiceFails(t *testing.T) {
+	unexpectedError := errors.New("unexpected error")
+
+	serviceMock := new(mocks.WebhookUseCaseMock)
+	serviceMock.On("GetAll", mock.Anything).Return([]domain.Webhook{}, unexpectedError)
+
+	server := httptest.NewServer(setupWebhookHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/webhooks").
+		Expect().Status(http.StatusInternalServerError).
+		Body().Contains(unexpectedError.Error())
+}
+
+func TestGetWebhooks_ShouldReturnForbiddenWithoutAdminScope(t *testing.T) {
+	serviceMock := new(mocks.WebhookUseCaseMock)
+	serviceMock.On("GetAll", mock.Anything).Return([]domain.Webhook{}, apperrors.Forbidden)
+
+	server := httptest.NewServer(setupWebhookHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/webhooks").
+		Expect().Status(http.StatusForbidden).
+		Body().Contains(apperrors.Forbidden.Error())
+}
+
+func TestDeleteWebhook_ShouldReturnNoContentWhenWebhookNotFound(t *testing.T) {
+	serviceMock := new(mocks.WebhookUseCaseMock)
+	serviceMock.On("DeleteByID", mock.Anything, "webhook-id").Return(apperrors.NotFound)
+
+	server := httptest.NewServer(setupWebhookHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.DELETE("/webhooks/webhook-id").
+		Expect().Status(http.StatusNoContent)
+}
+
+func TestGetDeliveries_ShouldReturnDeliveryLog(t *testing.T) {
+	now := time.Now().UTC()
+	delivery := domain.WebhookDelivery{
+		ID:        "delivery-id",
+		WebhookID: "webhook-id",
+		EventName: domain.EventMessageDeleted,
+		Payload:   []byte(`{"event":"message.deleted"}`),
+		Status:    domain.DeliveryStatusFailed,
+		Attempts:  []domain.WebhookAttempt{{At: now, StatusCode: 500, Error: "unexpected status code 500", Duration: 3 * time.Millisecond}},
+	}
+
+	serviceMock := new(mocks.WebhookUseCaseMock)
+	serviceMock.On("GetDeliveries", mock.Anything, "webhook-id").Return([]domain.WebhookDelivery{delivery}, nil)
+
+	server := httptest.NewServer(setupWebhookHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	response := e.GET("/webhooks/webhook-id/deliveries").
+		Expect().Status(http.StatusOK).
+		JSON().Array()
+
+	response.Length().IsEqual(1)
+	item := response.Value(0).Object()
+	item.Value("status").IsEqual(domain.DeliveryStatusFailed)
+	item.Value("payload").Object().Value("event").IsEqual(domain.EventMessageDeleted)
+	item.NotContainsKey("next_attempt_at")
+	attempt := item.Value("attempts").Array().Value(0).Object()
+	attempt.Value("status_code").IsEqual(500)
+	attempt.Value("duration_ms").IsEqual(3)
+}
+
+func TestRedeliver_ShouldReturnErrorWhenDeliveryNotFound(t *testing.T) {
+	serviceMock := new(mocks.WebhookUseCaseMock)
+	serviceMock.On("Redeliver", mock.Anything, "webhook-id", "delivery-id").Return(domain.WebhookDelivery{}, apperrors.NotFound)
+
+	server := httptest.NewServer(setupWebhookHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST("/webhooks/webhook-id/deliveries/delivery-id/redeliver").
+		Expect().Status(http.StatusNotFound)
+}
+
+func TestRedeliver_ShouldAcceptRedelivery(t *testing.T) {
+	redelivery := domain.WebhookDelivery{
+		ID:           "redelivery-id",
+		WebhookID:    "webhook-id",
+		Payload:      []byte(`{}`),
+		Status:       domain.DeliveryStatusPending,
+		RedeliveryOf: "delivery-id",
+	}
+
+	serviceMock := new(mocks.WebhookUseCaseMock)
+	serviceMock.On("Redeliver", mock.Anything, "webhook-id", "delivery-id").Return(redelivery, nil)
+
+	server := httptest.NewServer(setupWebhookHandler(serviceMock))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	response := e.POST("/webhooks/webhook-id/deliveries/delivery-id/redeliver").
+		Expect().Status(http.StatusAccepted).
+		JSON().Object()
+
+	response.Value("id").IsEqual(redelivery.ID)
+	response.Value("redelivery_of").IsEqual("delivery-id")
+	response.Value("status").IsEqual(domain.DeliveryStatusPending)
+}
+
+func TestWebhooks_ShouldDeliverSignedMessageEventsToReceiver(t *testing.T) {
+	ctx := context.Background()
+	received := make(chan *http.Request, 1)
+	receivedBodies := make(chan []byte, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		receivedBodies <- body
+	}))
+	defer receiver.Close()
+
+	systemClock := clock.NewSystemClock()
+	uuidGenerator := identifier.NewUUIDGenerator()
+	messages := memory.NewMessageStorage()
+	outboxStorage := memory.NewOutboxStorage()
+	webhooks := memory.NewWebhookStorage()
+
+	events := eventbus.NewBus(eventbus.Sync, nil)
+	webhookService := webhookusecases.NewWebhookService(uuidGenerator, systemClock, webhooks).WithPrivateAddresses()
+	events.SubscribeAll(webhookService.Notify)
+
+	server := Server{
+		messagehdl: NewMessageHandler(usecases.NewMessageService(uuidGenerator, systemClock, messages, memory.NewUnitOfWork(messages, outboxStorage, memory.NewAuditStorage()), policy.NewRolePolicy())),
+		webhookhdl: NewWebhookHandler(webhookService),
+	}
+	api := httptest.NewServer(server.setupRoutes())
+	defer api.Close()
+
+	e := httpexpect.Default(t, api.URL)
+	secret := e.POST("/webhooks").
+		WithJSON(dto.CreateWebhookRequest{URL: receiver.URL, Events: []string{domain.EventMessageCreated}}).
+		Expect().Status(http.StatusCreated).
+		JSON().Object().Value("secret").String().Raw()
+	e.POST("/message").
+		WithJSON(dto.CreateMessageRequest{Content: "message content"}).
+		Expect().Status(http.StatusCreated)
+
+	relay := outbox.NewRelay(outboxStorage, events, time.Second, 10, 3)
+	dispatcher := webhook.NewDispatcher(webhooks, webhook.NewHTTPSender(systemClock, time.Second, true), systemClock, time.Second, 10, 3, time.Second)
+	assert.NoError(t, relay.DispatchPending(ctx))
+	assert.NoError(t, dispatcher.DispatchDue(ctx))
+
+	request := <-received
+	body := <-receivedBodies
+	assert.Equal(t, domain.EventMessageCreated, request.Header.Get(webhook.HeaderEvent))
+	assert.True(t, webhook.Verify(secret, request.Header.Get(webhook.HeaderTimestamp), body, request.Header.Get(webhook.HeaderSignature)))
+}
+
+func setupWebhookHandler(service ports.WebhookUseCase) *gin.Engine {
+	server := Server{webhookhdl: NewWebhookHandler(service)}
+	return server.setupRoutes()
+}
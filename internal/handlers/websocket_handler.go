@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"golang.org/x/time/rate"
+)
+
+const (
+	websocketMaxCommandSize = 64 << 10
+	websocketWriteWait      = 10 * time.Second
+	websocketSendBuffer     = 64
+)
+
+var (
+	errWebSocketRateLimited    = errors.New("rate limit exceeded")
+	errWebSocketUnknownCommand = errors.New("unknown command type")
+	errWebSocketInvalidCommand = errors.New("command must be a JSON object")
+	errWebSocketShuttingDown   = errors.New("server is shutting down")
+)
+
+type websocketHandler struct {
+	service      ports.MessageUseCase
+	feed         *changefeed.Feed
+	upgrader     websocket.Upgrader
+	pingInterval time.Duration
+	rateLimit    rate.Limit
+	rateBurst    int
+	connections  *websocketConnections
+}
+
+func NewWebSocketHandler(service ports.MessageUseCase, feed *changefeed.Feed, pingInterval time.Duration, rateLimit float64, rateBurst int) websocketHandler {
+	return websocketHandler{
+		service:      service,
+		feed:         feed,
+		pingInterval: pingInterval,
+		rateLimit:    rate.Limit(rateLimit),
+		rateBurst:    rateBurst,
+		connections:  &websocketConnections{active: make(map[*websocketConnection]struct{})},
+	}
+}
+
+func (h websocketHandler) serveWebSocket(c *gin.Context) {
+	if h.connections.isClosed() {
+		c.JSON(503, gin.H{"error": errWebSocketShuttingDown.Error()})
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.WithoutCancel(c.Request.Context()))
+	connection := &websocketConnection{
+		handler: h,
+		conn:    conn,
+		ctx:     ctx,
+		cancel:  cancel,
+		limiter: rate.NewLimiter(h.rateLimit, h.rateBurst),
+		send:    make(chan dto.WebSocketReply, websocketSendBuffer),
+		done:    make(chan struct{}),
+	}
+	if !h.connections.add(connection) {
+		connection.close(websocket.CloseGoingAway, errWebSocketShuttingDown.Error())
+		return
+	}
+	defer h.connections.remove(connection)
+
+	go connection.writeLoop()
+	connection.readLoop()
+}
+
+// shutdown closes every open connection with a going away frame, since
+// hijacked connections are not tracked by the HTTP server.
+func (h websocketHandler) shutdown() {
+	for _, connection := range h.connections.closeAll() {
+		connection.close(websocket.CloseGoingAway, errWebSocketShuttingDown.Error())
+	}
+}
+
+type websocketConnections struct {
+	mu     sync.Mutex
+	active map[*websocketConnection]struct{}
+	closed bool
+}
+
+func (w *websocketConnections) add(connection *websocketConnection) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return false
+	}
+	w.active[connection] = struct{}{}
+	return true
+}
+
+func (w *websocketConnections) isClosed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.closed
+}
+
+func (w *websocketConnections) remove(connection *websocketConnection) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.active, connection)
+}
+
+func (w *websocketConnections) closeAll() []*websocketConnection {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.closed = true
+	connections := make([]*websocketConnection, 0, len(w.active))
+	for connection := range w.active {
+		connections = append(connections, connection)
+	}
+	return connections
+}
+
+// websocketConnection reads commands on the handler goroutine while a single
+// writer goroutine owns every data frame, as the websocket package allows only
+// one concurrent writer.
+type websocketConnection struct {
+	handler   websocketHandler
+	conn      *websocket.Conn
+	ctx       context.Context
+	cancel    context.CancelFunc
+	limiter   *rate.Limiter
+	send      chan dto.WebSocketReply
+	done      chan struct{}
+	closeOnce sync.Once
+
+	subscriptionMu sync.Mutex
+	subscription   *changefeed.Subscription
+}
+
+func (w *websocketConnection) readLoop() {
+	defer w.unsubscribe()
+
+	pongWait := 2 * w.handler.pingInterval
+	w.conn.SetReadLimit(websocketMaxCommandSize)
+	_ = w.conn.SetReadDeadline(time.Now().Add(pongWait))
+	w.conn.SetPongHandler(func(string) error {
+		return w.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, frame, err := w.conn.ReadMessage()
+		if err != nil {
+			w.close(websocket.CloseNormalClosure, "")
+			return
+		}
+
+		var command dto.WebSocketCommand
+		if err := json.Unmarshal(frame, &command); err != nil {
+			w.reply(dto.BuildWebSocketError("", http.StatusBadRequest, errors.Join(apperrors.InvalidInput, errWebSocketInvalidCommand)))
+			continue
+		}
+		if !w.limiter.Allow() {
+			w.reply(dto.BuildWebSocketError(command.ID, http.StatusTooManyRequests, errWebSocketRateLimited))
+			continue
+		}
+
+		w.reply(w.dispatch(command))
+	}
+}
+
+func (w *websocketConnection) writeLoop() {
+	ping := time.NewTicker(w.handler.pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case reply := <-w.send:
+			_ = w.conn.SetWriteDeadline(time.Now().Add(websocketWriteWait))
+			if err := w.conn.WriteJSON(reply); err != nil {
+				w.close(websocket.CloseInternalServerErr, "")
+				return
+			}
+		case <-ping.C:
+			if err := w.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(websocketWriteWait)); err != nil {
+				w.close(websocket.CloseGoingAway, "")
+				return
+			}
+		}
+	}
+}
+
+func (w *websocketConnection) dispatch(command dto.WebSocketCommand) dto.WebSocketReply {
+	switch command.Type {
+	case dto.WebSocketCommandCreate:
+		message, err := w.handler.service.Save(w.ctx, command.Content)
+		if err != nil {
+			return websocketError(command.ID, err)
+		}
+		return dto.BuildWebSocketResult(command.ID, http.StatusCreated, dto.BuildResponseGetMessage(message))
+	case dto.WebSocketCommandGet:
+		message, err := w.handler.service.GetByID(w.ctx, command.MessageID)
+		if err != nil {
+			return websocketError(command.ID, err)
+		}
+		return dto.BuildWebSocketResult(command.ID, http.StatusOK, dto.BuildResponseGetMessage(message))
+	case dto.WebSocketCommandDelete:
+		err := w.handler.service.DeleteByID(w.ctx, command.MessageID)
+		if err != nil {
+			return websocketError(command.ID, err)
+		}
+		return dto.BuildWebSocketResult(command.ID, http.StatusNoContent, nil)
+	case dto.WebSocketCommandSubscribe:
+		if err := w.subscribe(command.Events); err != nil {
+			return websocketError(command.ID, err)
+		}
+		return dto.BuildWebSocketResult(command.ID, http.StatusOK, nil)
+	case dto.WebSocketCommandUnsubscribe:
+		w.unsubscribe()
+		return dto.BuildWebSocketResult(command.ID, http.StatusOK, nil)
+	default:
+		return websocketError(command.ID, errors.Join(apperrors.InvalidInput, errWebSocketUnknownCommand))
+	}
+}
+
+// subscribe replaces the current subscription, if any, with one limited to
+// events. Changes are forwarded until the subscription ends.
+func (w *websocketConnection) subscribe(events []string) error {
+	for _, event := range events {
+		if !domain.IsKnownEvent(event) {
+			return errors.Join(apperrors.InvalidInput, fmt.Errorf("unknown event %q", event))
+		}
+	}
+
+	filter := func(event domain.Event) bool {
+		return len(events) == 0 || slices.Contains(events, event.EventName())
+	}
+	subscription, err := w.handler.feed.Subscribe(w.ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	w.unsubscribe()
+	w.subscriptionMu.Lock()
+	w.subscription = subscription
+	w.subscriptionMu.Unlock()
+
+	go w.forward(subscription)
+	return nil
+}
+
+func (w *websocketConnection) unsubscribe() {
+	w.subscriptionMu.Lock()
+	defer w.subscriptionMu.Unlock()
+
+	if w.subscription != nil {
+		w.subscription.Close()
+		w.subscription = nil
+	}
+}
+
+func (w *websocketConnection) forward(subscription *changefeed.Subscription) {
+	for change := range subscription.Changes() {
+		w.reply(dto.WebSocketReply{
+			Type: dto.WebSocketReplyChange,
+			Data: dto.BuildResponseChange(change.ID, change.Event),
+		})
+	}
+
+	if err := subscription.Err(); errors.Is(err, changefeed.ErrLagged) {
+		w.reply(dto.WebSocketReply{Type: dto.WebSocketReplySubscriptionEnded, Error: err.Error()})
+	}
+}
+
+func (w *websocketConnection) reply(reply dto.WebSocketReply) {
+	select {
+	case w.send <- reply:
+	case <-w.done:
+	}
+}
+
+func (w *websocketConnection) close(code int, text string) {
+	w.closeOnce.Do(func() {
+		_ = w.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(websocketWriteWait))
+		close(w.done)
+		w.cancel()
+		w.conn.Close()
+	})
+}
+
+func websocketError(id string, err error) dto.WebSocketReply {
+	switch {
+	case errors.Is(err, apperrors.InvalidInput):
+		return dto.BuildWebSocketError(id, http.StatusBadRequest, err)
+	case errors.Is(err, apperrors.NotFound):
+		return dto.BuildWebSocketError(id, http.StatusNotFound, err)
+	case errors.Is(err, apperrors.Forbidden):
+		return dto.BuildWebSocketError(id, http.StatusForbidden, err)
+	case errors.Is(err, apperrors.TooManyRequests):
+		return dto.BuildWebSocketError(id, http.StatusTooManyRequests, err)
+	case errors.Is(err, changefeed.ErrClosed):
+		return dto.BuildWebSocketError(id, http.StatusServiceUnavailable, err)
+	default:
+		return dto.BuildWebSocketError(id, http.StatusInternalServerError, err)
+	}
+}
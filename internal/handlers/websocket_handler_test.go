@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hiago-balbino/hex-architecture-template/internal/changefeed"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/internal/policy"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebSocket_ShouldCreateMessage(t *testing.T) {
+	message := domain.NewMessage("message-id", "message content")
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("Save", mock.Anything, message.Content).Return(message, nil)
+
+	conn, _ := dialWebSocket(t, setupWebSocketHandler(serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), time.Minute, 100))
+	reply := sendCommand(t, conn, dto.WebSocketCommand{ID: "1", Type: dto.WebSocketCommandCreate, Content: message.Content})
+
+	assert.Equal(t, "1", reply.ID)
+	assert.Equal(t, dto.WebSocketReplyResult, reply.Type)
+	assert.Equal(t, http.StatusCreated, reply.Status)
+	assert.Equal(t, map[string]any{"id": message.ID, "content": message.Content}, reply.Data)
+}
+
+func TestWebSocket_ShouldReturnNotFoundWhenMessageDoesNotExist(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("GetByID", mock.Anything, "message-id").Return(domain.Message{}, apperrors.NotFound)
+
+	conn, _ := dialWebSocket(t, setupWebSocketHandler(serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), time.Minute, 100))
+	reply := sendCommand(t, conn, dto.WebSocketCommand{ID: "1", Type: dto.WebSocketCommandGet, MessageID: "message-id"})
+
+	assert.Equal(t, dto.WebSocketReplyError, reply.Type)
+	assert.Equal(t, http.StatusNotFound, reply.Status)
+	assert.Equal(t, apperrors.NotFound.Error(), reply.Error)
+}
+
+func TestWebSocket_ShouldDeleteMessage(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("DeleteByID", mock.Anything, "message-id").Return(nil)
+
+	conn, _ := dialWebSocket(t, setupWebSocketHandler(serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), time.Minute, 100))
+	reply := sendCommand(t, conn, dto.WebSocketCommand{ID: "1", Type: dto.WebSocketCommandDelete, MessageID: "message-id"})
+
+	assert.Equal(t, dto.WebSocketReplyResult, reply.Type)
+	assert.Equal(t, http.StatusNoContent, reply.Status)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestWebSocket_ShouldReturnNotFoundWhenDeletedMessageDoesNotExist(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("DeleteByID", mock.Anything, "message-id").Return(apperrors.NotFound)
+
+	conn, _ := dialWebSocket(t, setupWebSocketHandler(serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), time.Minute, 100))
+	reply := sendCommand(t, conn, dto.WebSocketCommand{ID: "1", Type: dto.WebSocketCommandDelete, MessageID: "message-id"})
+
+	assert.Equal(t, dto.WebSocketReplyError, reply.Type)
+	assert.Equal(t, http.StatusNotFound, reply.Status)
+}
+
+func TestWebSocket_ShouldReturnInternalServerErrorWhenServiceFails(t *testing.T) {
+	unexpectedError := errors.New("unexpected error")
+
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("DeleteByID", mock.Anything, "message-id").Return(unexpectedError)
+
+	conn, _ := dialWebSocket(t, setupWebSocketHandler(serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), time.Minute, 100))
+	reply := sendCommand(t, conn, dto.WebSocketCommand{ID: "1", Type: dto.WebSocketCommandDelete, MessageID: "message-id"})
+
+	assert.Equal(t, http.StatusInternalServerError, reply.Status)
+	assert.Equal(t, unexpectedError.Error(), reply.Error)
+}
+
+func TestWebSocket_ShouldRejectInvalidCommands(t *testing.T) {
+	conn, _ := dialWebSocket(t, setupWebSocketHandler(nil, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), time.Minute, 100))
+
+	reply := sendCommand(t, conn, dto.WebSocketCommand{ID: "1", Type: "update"})
+	assert.Equal(t, "1", reply.ID)
+	assert.Equal(t, http.StatusBadRequest, reply.Status)
+
+	assert.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("{")))
+	reply = readReply(t, conn)
+	assert.Equal(t, http.StatusBadRequest, reply.Status)
+	assert.Contains(t, reply.Error, apperrors.InvalidInput.Error())
+
+	reply = sendCommand(t, conn, dto.WebSocketCommand{ID: "2", Type: dto.WebSocketCommandSubscribe, Events: []string{"message.archived"}})
+	assert.Equal(t, http.StatusBadRequest, reply.Status)
+}
+
+func TestWebSocket_ShouldRateLimitCommandsPerConnection(t *testing.T) {
+	serviceMock := new(mocks.MessageUseCaseMock)
+	serviceMock.On("DeleteByID", mock.Anything, "message-id").Return(nil)
+
+	handler := setupWebSocketHandler(serviceMock, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), time.Minute, 1)
+	conn, _ := dialWebSocket(t, handler)
+	reply := sendCommand(t, conn, dto.WebSocketCommand{ID: "1", Type: dto.WebSocketCommandDelete, MessageID: "message-id"})
+	assert.Equal(t, http.StatusNoContent, reply.Status)
+
+	reply = sendCommand(t, conn, dto.WebSocketCommand{ID: "2", Type: dto.WebSocketCommandDelete, MessageID: "message-id"})
+	assert.Equal(t, "2", reply.ID)
+	assert.Equal(t, http.StatusTooManyRequests, reply.Status)
+
+	anotherConn, _ := dialWebSocket(t, handler)
+	reply = sendCommand(t, anotherConn, dto.WebSocketCommand{ID: "3", Type: dto.WebSocketCommandDelete, MessageID: "message-id"})
+	assert.Equal(t, http.StatusNoContent, reply.Status)
+}
+
+func TestWebSocket_ShouldPushSubscribedChanges(t *testing.T) {
+	feed := changefeed.NewFeed(10, 10, policy.NewRolePolicy())
+	conn, _ := dialWebSocket(t, setupWebSocketHandler(nil, feed, time.Minute, 100))
+
+	reply := sendCommand(t, conn, dto.WebSocketCommand{ID: "1", Type: dto.WebSocketCommandSubscribe, Events: []string{domain.EventMessageDeleted}})
+	assert.Equal(t, dto.WebSocketReplyResult, reply.Type)
+
+	ctx := context.Background()
+	assert.NoError(t, feed.Handle(ctx, domain.NewMessageCreated(domain.NewMessage("id1", "message content"), time.Now())))
+	assert.NoError(t, feed.Handle(ctx, domain.NewMessageDeleted("id2", time.Now())))
+
+	reply = readReply(t, conn)
+	assert.Equal(t, dto.WebSocketReplyChange, reply.Type)
+	change := reply.Data.(map[string]any)
+	assert.Equal(t, float64(2), change["id"])
+	assert.Equal(t, domain.EventMessageDeleted, change["event"])
+	assert.Equal(t, "id2", change["aggregate_id"])
+
+	reply = sendCommand(t, conn, dto.WebSocketCommand{ID: "2", Type: dto.WebSocketCommandUnsubscribe})
+	assert.Equal(t, "2", reply.ID)
+	assert.NoError(t, feed.Handle(ctx, domain.NewMessageDeleted("id3", time.Now())))
+
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+	_, _, err := conn.ReadMessage()
+	assert.Error(t, err)
+}
+
+func TestWebSocket_ShouldSendPings(t *testing.T) {
+	conn, _ := dialWebSocket(t, setupWebSocketHandler(nil, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), 10*time.Millisecond, 100))
+
+	pinged := make(chan struct{}, 1)
+	conn.SetPingHandler(func(string) error {
+		select {
+		case pinged <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-pinged:
+	case <-time.After(time.Second):
+		t.Fatal("no ping received")
+	}
+}
+
+func TestWebSocket_ShouldCloseConnectionsOnShutdown(t *testing.T) {
+	handler := setupWebSocketHandler(nil, changefeed.NewFeed(10, 10, policy.NewRolePolicy()), time.Minute, 100)
+	conn, server := dialWebSocket(t, handler)
+	sendCommand(t, conn, dto.WebSocketCommand{ID: "1", Type: dto.WebSocketCommandUnsubscribe})
+
+	handler.shutdown()
+
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway))
+
+	_, response, err := websocket.DefaultDialer.Dial(webSocketURL(server), nil)
+	assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+}
+
+func setupWebSocketHandler(service ports.MessageUseCase, feed *changefeed.Feed, pingInterval time.Duration, rateBurst int) websocketHandler {
+	return NewWebSocketHandler(service, feed, pingInterval, 0.001, rateBurst)
+}
+
+func dialWebSocket(t *testing.T, handler websocketHandler) (*websocket.Conn, *httptest.Server) {
+	server := httptest.NewServer(Server{wshdl: handler}.setupRoutes())
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial(webSocketURL(server), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, server
+}
+
+func webSocketURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+}
+
+func sendCommand(t *testing.T, conn *websocket.Conn, command dto.WebSocketCommand) dto.WebSocketReply {
+	if err := conn.WriteJSON(command); err != nil {
+		t.Fatal(err)
+	}
+	return readReply(t, conn)
+}
+
+func readReply(t *testing.T, conn *websocket.Conn) dto.WebSocketReply {
+	if err := conn.SetReadDeadline(time.Now().Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	var reply dto.WebSocketReply
+	if err := conn.ReadJSON(&reply); err != nil {
+		t.Fatal(err)
+	}
+	return reply
+}
@@ -0,0 +1,86 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+)
+
+type Relay struct {
+	repository  ports.OutboxRepository
+	publisher   ports.EventPublisher
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+}
+
+func NewRelay(repository ports.OutboxRepository, publisher ports.EventPublisher, interval time.Duration, batchSize int, maxAttempts int) Relay {
+	return Relay{
+		repository:  repository,
+		publisher:   publisher,
+		interval:    interval,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+	}
+}
+
+func (r Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.DispatchPending(ctx); err != nil {
+			log.Printf("failed to dispatch outbox entries: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending publishes the pending entries in order, each with the
+// tenant it was recorded for in the context. An entry is only
+// removed after it was published, so a crash in between delivers it again.
+// Once an entry fails, the later entries of the same aggregate wait for the
+// next pass, unless it was dead-lettered after too many attempts. Entries
+// that cannot be decoded are dead-lettered right away.
+func (r Relay) DispatchPending(ctx context.Context) error {
+	entries, err := r.repository.Pending(ctx, r.batchSize)
+	if err != nil {
+		return err
+	}
+
+	blocked := make(map[string]bool)
+	for _, entry := range entries {
+		if blocked[entry.AggregateID] {
+			continue
+		}
+
+		event, err := entry.Event()
+		if err == nil {
+			err = r.publisher.Publish(domain.ContextWithTenant(ctx, entry.Tenant), event)
+		}
+		if err == nil {
+			if err := r.repository.MarkDispatched(ctx, entry.Sequence); err != nil {
+				return err
+			}
+			continue
+		}
+
+		deadLetter := entry.Attempts+1 >= r.maxAttempts || event == nil
+		if err := r.repository.MarkFailed(ctx, entry.Sequence, err.Error(), deadLetter); err != nil {
+			return err
+		}
+		if !deadLetter {
+			blocked[entry.AggregateID] = true
+		}
+	}
+
+	return nil
+}
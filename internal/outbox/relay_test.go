@@ -0,0 +1,159 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var fixedNow = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestDispatchPending_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
+	ctx := context.Background()
+	unexpectedError := errors.New("unexpected error")
+
+	repositoryMock := new(mocks.OutboxRepositoryMock)
+	repositoryMock.On("Pending", ctx, 10).Return([]domain.OutboxEntry{}, unexpectedError)
+
+	relay := NewRelay(repositoryMock, new(mocks.RecordingEventPublisher), time.Second, 10, 3)
+	err := relay.DispatchPending(ctx)
+
+	assert.ErrorIs(t, err, unexpectedError)
+}
+
+func TestDispatchPending_ShouldPublishAndMarkEntriesAsDispatched(t *testing.T) {
+	ctx := context.Background()
+	created := domain.NewMessageCreated(domain.NewMessage("id", "message content"), fixedNow)
+	deleted := domain.NewMessageDeleted("id", fixedNow)
+
+	repositoryMock := new(mocks.OutboxRepositoryMock)
+	repositoryMock.On("Pending", ctx, 10).Return([]domain.OutboxEntry{
+		newOutboxEntry(t, 1, 0, created),
+		newOutboxEntry(t, 2, 0, deleted),
+	}, nil)
+	repositoryMock.On("MarkDispatched", ctx, uint64(1)).Return(nil)
+	repositoryMock.On("MarkDispatched", ctx, uint64(2)).Return(nil)
+
+	publisher := new(mocks.RecordingEventPublisher)
+	relay := NewRelay(repositoryMock, publisher, time.Second, 10, 3)
+	err := relay.DispatchPending(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Event{created, deleted}, publisher.Events())
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestDispatchPending_ShouldHoldLaterEntriesOfAggregateWhenPublishFails(t *testing.T) {
+	ctx := context.Background()
+	unexpectedError := errors.New("unexpected error")
+
+	repositoryMock := new(mocks.OutboxRepositoryMock)
+	repositoryMock.On("Pending", ctx, 10).Return([]domain.OutboxEntry{
+		newOutboxEntry(t, 1, 0, domain.NewMessageCreated(domain.NewMessage("id1", "message content"), fixedNow)),
+		newOutboxEntry(t, 2, 0, domain.NewMessageDeleted("id1", fixedNow)),
+		newOutboxEntry(t, 3, 0, domain.NewMessageDeleted("id2", fixedNow)),
+	}, nil)
+	repositoryMock.On("MarkFailed", ctx, uint64(1), unexpectedError.Error(), false).Return(nil)
+	repositoryMock.On("MarkFailed", ctx, uint64(3), unexpectedError.Error(), false).Return(nil)
+
+	publisher := &mocks.RecordingEventPublisher{Err: unexpectedError}
+	relay := NewRelay(repositoryMock, publisher, time.Second, 10, 3)
+	err := relay.DispatchPending(ctx)
+
+	assert.NoError(t, err)
+	repositoryMock.AssertExpectations(t)
+	repositoryMock.AssertNotCalled(t, "MarkFailed", ctx, uint64(2), mock.Anything, mock.Anything)
+}
+
+func TestDispatchPending_ShouldDeadLetterEntryAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	unexpectedError := errors.New("unexpected error")
+
+	repositoryMock := new(mocks.OutboxRepositoryMock)
+	repositoryMock.On("Pending", ctx, 10).Return([]domain.OutboxEntry{
+		newOutboxEntry(t, 1, 2, domain.NewMessageDeleted("id", fixedNow)),
+	}, nil)
+	repositoryMock.On("MarkFailed", ctx, uint64(1), unexpectedError.Error(), true).Return(nil)
+
+	publisher := &mocks.RecordingEventPublisher{Err: unexpectedError}
+	relay := NewRelay(repositoryMock, publisher, time.Second, 10, 3)
+	err := relay.DispatchPending(ctx)
+
+	assert.NoError(t, err)
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestDispatchPending_ShouldDeadLetterEntryWhenEventCannotBeDecoded(t *testing.T) {
+	ctx := context.Background()
+
+	repositoryMock := new(mocks.OutboxRepositoryMock)
+	repositoryMock.On("Pending", ctx, 10).Return([]domain.OutboxEntry{
+		{Sequence: 1, AggregateID: "id", EventName: "message.archived", Payload: []byte(`{}`)},
+	}, nil)
+	repositoryMock.On("MarkFailed", ctx, uint64(1), mock.Anything, true).Return(nil)
+
+	publisher := new(mocks.RecordingEventPublisher)
+	relay := NewRelay(repositoryMock, publisher, time.Second, 10, 3)
+	err := relay.DispatchPending(ctx)
+
+	assert.NoError(t, err)
+	assert.Empty(t, publisher.Events())
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestRun_ShouldDispatchUntilContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	deleted := domain.NewMessageDeleted("id", fixedNow)
+
+	repositoryMock := new(mocks.OutboxRepositoryMock)
+	repositoryMock.On("Pending", mock.Anything, 10).Return([]domain.OutboxEntry{newOutboxEntry(t, 1, 0, deleted)}, nil).Once()
+	repositoryMock.On("Pending", mock.Anything, 10).Return([]domain.OutboxEntry{}, nil)
+	repositoryMock.On("MarkDispatched", mock.Anything, uint64(1)).Return(nil)
+
+	publisher := new(mocks.RecordingEventPublisher)
+	relay := NewRelay(repositoryMock, publisher, time.Millisecond, 10, 3)
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return len(publisher.Events()) == 1 }, time.Second, time.Millisecond)
+	cancel()
+	<-done
+}
+
+func TestDispatchPending_ShouldPublishEntriesForTheirTenant(t *testing.T) {
+	ctx := context.Background()
+	created := domain.NewMessageCreated(domain.NewMessage("id", "message content"), fixedNow)
+	acme := newOutboxEntry(t, 1, 0, created)
+	acme.Tenant = "acme"
+	legacy := newOutboxEntry(t, 2, 0, created)
+	legacy.Tenant = ""
+
+	repositoryMock := new(mocks.OutboxRepositoryMock)
+	repositoryMock.On("Pending", ctx, 10).Return([]domain.OutboxEntry{acme, legacy}, nil)
+	repositoryMock.On("MarkDispatched", ctx, mock.Anything).Return(nil)
+
+	publisher := new(mocks.RecordingEventPublisher)
+	err := NewRelay(repositoryMock, publisher, time.Second, 10, 3).DispatchPending(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acme", domain.DefaultTenant}, publisher.Tenants())
+}
+
+func newOutboxEntry(t *testing.T, sequence uint64, attempts int, event domain.Event) domain.OutboxEntry {
+	entry, err := domain.NewOutboxEntry(domain.DefaultTenant, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry.Sequence = sequence
+	entry.Attempts = attempts
+	return entry
+}
@@ -0,0 +1,76 @@
+// Package policy authorizes what principals do with messages.
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+var (
+	errUnknownAction = errors.New("unknown action")
+	errNotOwner      = errors.New("message belongs to another principal")
+)
+
+// requiredRoles is the least role each action needs.
+var requiredRoles = map[domain.Action]domain.Role{
+	domain.ActionRead:    domain.RoleReader,
+	domain.ActionCreate:  domain.RoleWriter,
+	domain.ActionDelete:  domain.RoleWriter,
+	domain.ActionRestore: domain.RoleWriter,
+	domain.ActionImport:  domain.RoleAdmin,
+	domain.ActionAudit:   domain.RoleAdmin,
+}
+
+// ownerActions are limited to the owner of the message, unless the principal
+// is an admin. Every member of the tenant may read its messages.
+var ownerActions = map[domain.Action]bool{
+	domain.ActionDelete:  true,
+	domain.ActionRestore: true,
+}
+
+// rolePolicy lets readers read the messages of their tenant and writers create
+// them and delete and restore their own, while admins may act on every
+// message. Requests without a
+// principal are allowed, as they only happen when authentication is disabled.
+type rolePolicy struct{}
+
+func NewRolePolicy() rolePolicy {
+	return rolePolicy{}
+}
+
+func (rolePolicy) Authorize(ctx context.Context, action domain.Action) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return authorizeRole(principal, action)
+}
+
+func (rolePolicy) AuthorizeMessage(ctx context.Context, action domain.Action, message domain.Message) error {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if err := authorizeRole(principal, action); err != nil {
+		return err
+	}
+	if ownerActions[action] && !principal.Owns(message) && !principal.HasRole(domain.RoleAdmin) {
+		return errors.Join(apperrors.Forbidden, errNotOwner)
+	}
+	return nil
+}
+
+func authorizeRole(principal domain.Principal, action domain.Action) error {
+	role, ok := requiredRoles[action]
+	if !ok {
+		return errors.Join(apperrors.Forbidden, errUnknownAction)
+	}
+	if !principal.HasRole(role) {
+		return errors.Join(apperrors.Forbidden, fmt.Errorf("the %s role is required to %s messages", role, action))
+	}
+	return nil
+}
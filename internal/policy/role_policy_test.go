@@ -0,0 +1,93 @@
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorize_ShouldRequireRoleOfAction(t *testing.T) {
+	tests := map[string]struct {
+		scopes  []string
+		action  domain.Action
+		allowed bool
+	}{
+		"reader reads":    {scopes: []string{"reader"}, action: domain.ActionRead, allowed: true},
+		"reader creates":  {scopes: []string{"reader"}, action: domain.ActionCreate},
+		"writer reads":    {scopes: []string{"writer"}, action: domain.ActionRead, allowed: true},
+		"writer deletes":  {scopes: []string{"writer"}, action: domain.ActionDelete, allowed: true},
+		"reader restores": {scopes: []string{"reader"}, action: domain.ActionRestore},
+		"writer restores": {scopes: []string{"writer"}, action: domain.ActionRestore, allowed: true},
+		"writer imports":  {scopes: []string{"writer"}, action: domain.ActionImport},
+		"admin imports":   {scopes: []string{"admin"}, action: domain.ActionImport, allowed: true},
+		"writer audits":   {scopes: []string{"writer"}, action: domain.ActionAudit},
+		"admin audits":    {scopes: []string{"admin"}, action: domain.ActionAudit, allowed: true},
+		"other scopes":    {scopes: []string{"messages:read"}, action: domain.ActionRead},
+		"unknown action":  {scopes: []string{"admin"}, action: domain.Action("archive")},
+		"several scopes":  {scopes: []string{"messages:read", "writer"}, action: domain.ActionCreate, allowed: true},
+		"no scopes":       {action: domain.ActionRead},
+		"admin deletes":   {scopes: []string{"admin"}, action: domain.ActionDelete, allowed: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := domain.ContextWithPrincipal(context.Background(), domain.NewPrincipal("user-1", test.scopes))
+
+			err := NewRolePolicy().Authorize(ctx, test.action)
+
+			if test.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, apperrors.Forbidden)
+			}
+		})
+	}
+}
+
+func TestAuthorizeMessage_ShouldOnlyLetOwnersAndAdminsDeleteAndRestore(t *testing.T) {
+	owned := domain.NewMessage("id1", "message content").OwnedBy("user-1")
+	other := domain.NewMessage("id2", "message content").OwnedBy("user-2")
+	unowned := domain.NewMessage("id3", "message content")
+
+	tests := map[string]struct {
+		scopes  []string
+		action  domain.Action
+		message domain.Message
+		allowed bool
+	}{
+		"reader reads own message":   {scopes: []string{"reader"}, action: domain.ActionRead, message: owned, allowed: true},
+		"reader reads other message": {scopes: []string{"reader"}, action: domain.ActionRead, message: other, allowed: true},
+		"reader deletes own message": {scopes: []string{"reader"}, action: domain.ActionDelete, message: owned},
+		"writer deletes own message": {scopes: []string{"writer"}, action: domain.ActionDelete, message: owned, allowed: true},
+		"writer deletes other":       {scopes: []string{"writer"}, action: domain.ActionDelete, message: other},
+		"writer reads unowned":       {scopes: []string{"writer"}, action: domain.ActionRead, message: unowned, allowed: true},
+		"writer restores own":        {scopes: []string{"writer"}, action: domain.ActionRestore, message: owned, allowed: true},
+		"writer restores other":      {scopes: []string{"writer"}, action: domain.ActionRestore, message: other},
+		"writer deletes unowned":     {scopes: []string{"writer"}, action: domain.ActionDelete, message: unowned},
+		"no role reads other":        {action: domain.ActionRead, message: other},
+		"admin deletes other":        {scopes: []string{"admin"}, action: domain.ActionDelete, message: other, allowed: true},
+		"admin reads unowned":        {scopes: []string{"admin"}, action: domain.ActionRead, message: unowned, allowed: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := domain.ContextWithPrincipal(context.Background(), domain.NewPrincipal("user-1", test.scopes))
+
+			err := NewRolePolicy().AuthorizeMessage(ctx, test.action, test.message)
+
+			if test.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, apperrors.Forbidden)
+			}
+		})
+	}
+}
+
+func TestAuthorizeMessage_ShouldAllowEverythingWithoutPrincipal(t *testing.T) {
+	message := domain.NewMessage("id1", "message content").OwnedBy("user-1")
+
+	assert.NoError(t, NewRolePolicy().Authorize(context.Background(), domain.ActionImport))
+	assert.NoError(t, NewRolePolicy().AuthorizeMessage(context.Background(), domain.ActionDelete, message))
+}
@@ -0,0 +1,112 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	apiKeysBucket       = []byte("api_keys")
+	errNotFoundAPIKeyID = errors.New("api key id not found")
+)
+
+type apiKeyStorage struct {
+	session
+}
+
+func NewAPIKeyStorage(db *bbolt.DB) (apiKeyStorage, error) {
+	if err := createBucket(db, apiKeysBucket); err != nil {
+		return apiKeyStorage{}, err
+	}
+
+	return apiKeyStorage{
+		session: session{db: db},
+	}, nil
+}
+
+func (a apiKeyStorage) Save(ctx context.Context, key domain.APIKey) error {
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+
+	return a.update(apiKeysBucket, func(bucket *bbolt.Bucket) error {
+		return bucket.Put([]byte(key.ID), keyJSON)
+	})
+}
+
+func (a apiKeyStorage) GetByID(ctx context.Context, id string) (domain.APIKey, error) {
+	var key domain.APIKey
+
+	err := a.view(apiKeysBucket, func(bucket *bbolt.Bucket) error {
+		var err error
+		key, err = getAPIKey(bucket, id)
+		return err
+	})
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+
+	return key, nil
+}
+
+func (a apiKeyStorage) GetAll(ctx context.Context) ([]domain.APIKey, error) {
+	keys := []domain.APIKey{}
+
+	err := a.view(apiKeysBucket, func(bucket *bbolt.Bucket) error {
+		return bucket.ForEach(func(_, keyJSON []byte) error {
+			var key domain.APIKey
+			if err := json.Unmarshal(keyJSON, &key); err != nil {
+				return err
+			}
+			keys = append(keys, key)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+		}
+		return keys[i].ID < keys[j].ID
+	})
+	return keys, nil
+}
+
+// Touch reads and writes the key in the same transaction.
+func (a apiKeyStorage) Touch(ctx context.Context, id string, usedAt time.Time) error {
+	return a.update(apiKeysBucket, func(bucket *bbolt.Bucket) error {
+		key, err := getAPIKey(bucket, id)
+		if err != nil {
+			return err
+		}
+		key.LastUsedAt = usedAt
+
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(id), keyJSON)
+	})
+}
+
+func getAPIKey(bucket *bbolt.Bucket, id string) (domain.APIKey, error) {
+	keyJSON := bucket.Get([]byte(id))
+	if keyJSON == nil {
+		return domain.APIKey{}, errors.Join(apperrors.NotFound, errNotFoundAPIKeyID)
+	}
+
+	var key domain.APIKey
+	err := json.Unmarshal(keyJSON, &key)
+	return key, err
+}
@@ -0,0 +1,84 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"go.etcd.io/bbolt"
+)
+
+// auditBucket holds a bucket per tenant, whose entries are keyed by their
+// big-endian sequence so that they are iterated in order.
+var auditBucket = []byte("audit")
+
+type auditStorage struct {
+	session
+}
+
+func NewAuditStorage(db *bbolt.DB) (auditStorage, error) {
+	if err := createBucket(db, auditBucket); err != nil {
+		return auditStorage{}, err
+	}
+
+	return auditStorage{
+		session: session{db: db},
+	}, nil
+}
+
+func (a auditStorage) Append(ctx context.Context, entry domain.AuditEntry) (domain.AuditEntry, error) {
+	err := a.update(auditBucket, func(audit *bbolt.Bucket) error {
+		bucket, err := audit.CreateBucketIfNotExists([]byte(domain.TenantFromContext(ctx)))
+		if err != nil {
+			return err
+		}
+
+		var previous domain.AuditEntry
+		if _, previousJSON := bucket.Cursor().Last(); previousJSON != nil {
+			if err := json.Unmarshal(previousJSON, &previous); err != nil {
+				return err
+			}
+		}
+		entry = entry.Chain(previous)
+
+		entryJSON, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(sequenceKey(entry.Sequence), entryJSON)
+	})
+	if err != nil {
+		return domain.AuditEntry{}, err
+	}
+	return entry, nil
+}
+
+// ForEach runs fn inside a read transaction, like messageStorage.ForEach.
+func (a auditStorage) ForEach(ctx context.Context, messageID string, fn func(entry domain.AuditEntry) error) error {
+	err := a.view(auditBucket, func(audit *bbolt.Bucket) error {
+		bucket := audit.Bucket([]byte(domain.TenantFromContext(ctx)))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(_, entryJSON []byte) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			var entry domain.AuditEntry
+			if err := json.Unmarshal(entryJSON, &entry); err != nil {
+				return err
+			}
+			if messageID != "" && entry.MessageID != messageID {
+				return nil
+			}
+			return fn(entry)
+		})
+	})
+	if errors.Is(err, ports.ErrStopIteration) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,55 @@
+package boltdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditAppend_ShouldChainEntriesOfEachTenant(t *testing.T) {
+	ctx := context.Background()
+	acmeCtx := domain.ContextWithTenant(ctx, "acme")
+	at := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	repo, err := NewAuditStorage(setupDatabase(t))
+	assert.NoError(t, err)
+	first, err := repo.Append(ctx, domain.NewAuditEntry(at, domain.DefaultTenant, domain.ActionCreate, "1", nil))
+	assert.NoError(t, err)
+	acme, err := repo.Append(acmeCtx, domain.NewAuditEntry(at, "acme", domain.ActionCreate, "1", nil))
+	assert.NoError(t, err)
+	second, err := repo.Append(ctx, domain.NewAuditEntry(at, domain.DefaultTenant, domain.ActionDelete, "1", nil))
+	assert.NoError(t, err)
+
+	assert.Equal(t, uint64(1), acme.Sequence)
+	assert.NoError(t, acme.Verify(domain.AuditEntry{}))
+	assert.NoError(t, first.Verify(domain.AuditEntry{}))
+	assert.NoError(t, second.Verify(first))
+}
+
+func TestAuditForEach_ShouldVisitEntriesOfMessageInOrder(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	repo, err := NewAuditStorage(setupDatabase(t))
+	assert.NoError(t, err)
+	for _, id := range []string{"1", "2", "1", "1"} {
+		_, err = repo.Append(ctx, domain.NewAuditEntry(at, domain.DefaultTenant, domain.ActionCreate, id, nil))
+		assert.NoError(t, err)
+	}
+
+	var sequences []uint64
+	err = repo.ForEach(ctx, "1", func(entry domain.AuditEntry) error {
+		sequences = append(sequences, entry.Sequence)
+		if len(sequences) == 2 {
+			return ports.ErrStopIteration
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 3}, sequences)
+}
@@ -0,0 +1,45 @@
+package boltdb
+
+import (
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+const openTimeout = time.Second
+
+func Open(path string) (*bbolt.DB, error) {
+	return bbolt.Open(path, 0o600, &bbolt.Options{Timeout: openTimeout})
+}
+
+func createBucket(db *bbolt.DB, name []byte) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(name)
+		return err
+	})
+}
+
+// session runs bucket operations in their own transaction, or inside tx when
+// the storage was created by a unit of work.
+type session struct {
+	db *bbolt.DB
+	tx *bbolt.Tx
+}
+
+func (s session) update(bucket []byte, fn func(bucket *bbolt.Bucket) error) error {
+	if s.tx != nil {
+		return fn(s.tx.Bucket(bucket))
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return fn(tx.Bucket(bucket))
+	})
+}
+
+func (s session) view(bucket []byte, fn func(bucket *bbolt.Bucket) error) error {
+	if s.tx != nil {
+		return fn(s.tx.Bucket(bucket))
+	}
+	return s.db.View(func(tx *bbolt.Tx) error {
+		return fn(tx.Bucket(bucket))
+	})
+}
@@ -0,0 +1,32 @@
+package boltdb
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func TestOpen_ShouldReturnErrorWhenPathIsInvalid(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "missing", "hexapi.db"))
+
+	assert.Error(t, err)
+	assert.Nil(t, db)
+}
+
+func TestOpen_ShouldOpenDatabaseWithSuccess(t *testing.T) {
+	db, err := Open(filepath.Join(t.TempDir(), "hexapi.db"))
+
+	assert.NoError(t, err)
+	assert.NoError(t, db.Close())
+}
+
+func setupDatabase(t *testing.T) *bbolt.DB {
+	db, err := Open(filepath.Join(t.TempDir(), "hexapi.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
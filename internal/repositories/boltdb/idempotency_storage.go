@@ -0,0 +1,112 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"go.etcd.io/bbolt"
+)
+
+// idempotencySweepInterval is how many records are saved between two sweeps
+// of the expired ones, which are otherwise only removed when looked up again.
+const idempotencySweepInterval = 1024
+
+var (
+	idempotencyBucket         = []byte("idempotency_keys")
+	errNotFoundIdempotencyKey = errors.New("idempotency key not found")
+)
+
+type idempotencyStorage struct {
+	db    *bbolt.DB
+	clock clock.Clock
+	saves *atomic.Uint64
+}
+
+func NewIdempotencyStorage(db *bbolt.DB, clock clock.Clock) (idempotencyStorage, error) {
+	if err := createBucket(db, idempotencyBucket); err != nil {
+		return idempotencyStorage{}, err
+	}
+
+	return idempotencyStorage{
+		db:    db,
+		clock: clock,
+		saves: &atomic.Uint64{},
+	}, nil
+}
+
+func (i idempotencyStorage) Save(ctx context.Context, record domain.IdempotencyRecord) error {
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return i.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(idempotencyBucket)
+		if i.saves.Add(1)%idempotencySweepInterval == 0 {
+			if err := sweepIdempotencyRecords(bucket, i.clock.Now()); err != nil {
+				return err
+			}
+		}
+
+		return bucket.Put([]byte(record.Key), recordJSON)
+	})
+}
+
+func (i idempotencyStorage) GetByKey(ctx context.Context, key string) (domain.IdempotencyRecord, error) {
+	var record domain.IdempotencyRecord
+	expired := false
+
+	err := i.db.View(func(tx *bbolt.Tx) error {
+		recordJSON := tx.Bucket(idempotencyBucket).Get([]byte(key))
+		if recordJSON == nil {
+			return errors.Join(apperrors.NotFound, errNotFoundIdempotencyKey)
+		}
+		if err := json.Unmarshal(recordJSON, &record); err != nil {
+			return err
+		}
+		expired = record.IsExpired(i.clock.Now())
+		return nil
+	})
+	if err != nil {
+		return domain.IdempotencyRecord{}, err
+	}
+
+	if expired {
+		err = i.db.Update(func(tx *bbolt.Tx) error {
+			return tx.Bucket(idempotencyBucket).Delete([]byte(key))
+		})
+		if err != nil {
+			return domain.IdempotencyRecord{}, err
+		}
+		return domain.IdempotencyRecord{}, errors.Join(apperrors.NotFound, errNotFoundIdempotencyKey)
+	}
+
+	return record, nil
+}
+
+func sweepIdempotencyRecords(bucket *bbolt.Bucket, now time.Time) error {
+	var expired [][]byte
+	err := bucket.ForEach(func(key, value []byte) error {
+		var record domain.IdempotencyRecord
+		if err := json.Unmarshal(value, &record); err == nil && record.IsExpired(now) {
+			expired = append(expired, append([]byte(nil), key...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range expired {
+		if err := bucket.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package boltdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func TestIdempotencyGetByKey_ShouldReturnErrorWhenKeyNotFound(t *testing.T) {
+	repo, err := NewIdempotencyStorage(setupDatabase(t), new(mocks.ClockMock))
+	assert.NoError(t, err)
+
+	record, err := repo.GetByKey(context.Background(), "key")
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.Empty(t, record)
+}
+
+func TestIdempotencyGetByKey_ShouldReturnErrorWhenInvalidRecordContent(t *testing.T) {
+	db := setupDatabase(t)
+	repo, err := NewIdempotencyStorage(db, new(mocks.ClockMock))
+	assert.NoError(t, err)
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(idempotencyBucket).Put([]byte("key"), []byte("{"))
+	})
+	assert.NoError(t, err)
+
+	record, err := repo.GetByKey(context.Background(), "key")
+	assert.Error(t, err)
+	assert.Empty(t, record)
+}
+
+func TestIdempotencyGetByKey_ShouldReturnErrorWhenRecordExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	expiredRecord := domain.NewIdempotencyRecord("key", "fingerprint", 201, "application/json", []byte(`{}`), now.Add(-time.Second))
+
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(now)
+
+	repo, err := NewIdempotencyStorage(setupDatabase(t), clockMock)
+	assert.NoError(t, err)
+	err = repo.Save(ctx, expiredRecord)
+	assert.NoError(t, err)
+
+	record, err := repo.GetByKey(ctx, expiredRecord.Key)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.Empty(t, record)
+}
+
+func TestIdempotencyGetByKey_ShouldGetRecordWithSuccess(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	expectedRecord := domain.NewIdempotencyRecord("key", "fingerprint", 201, "application/json", []byte(`{}`), now.Add(time.Hour))
+
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(now)
+
+	repo, err := NewIdempotencyStorage(setupDatabase(t), clockMock)
+	assert.NoError(t, err)
+	err = repo.Save(ctx, expectedRecord)
+	assert.NoError(t, err)
+
+	actualRecord, err := repo.GetByKey(ctx, expectedRecord.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRecord, actualRecord)
+}
+
+func TestIdempotencySave_ShouldSweepExpiredRecords(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(now)
+
+	db := setupDatabase(t)
+	repo, err := NewIdempotencyStorage(db, clockMock)
+	assert.NoError(t, err)
+	err = repo.Save(ctx, domain.NewIdempotencyRecord("expired", "fingerprint", 201, "application/json", []byte(`{}`), now.Add(-time.Second)))
+	assert.NoError(t, err)
+
+	repo.saves.Store(idempotencySweepInterval - 1)
+	err = repo.Save(ctx, domain.NewIdempotencyRecord("key", "fingerprint", 201, "application/json", []byte(`{}`), now.Add(time.Hour)))
+	assert.NoError(t, err)
+
+	err = db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(idempotencyBucket)
+		assert.Nil(t, bucket.Get([]byte("expired")))
+		assert.NotNil(t, bucket.Get([]byte("key")))
+		return nil
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,238 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"go.etcd.io/bbolt"
+)
+
+// forEachPageSize is the number of messages ForEach reads per transaction.
+const forEachPageSize = 100
+
+var (
+	messagesBucket       = []byte("messages")
+	tenantMessagesBucket = []byte("tenant_messages")
+	errNotFoundMessageID = errors.New("message id not found")
+	errBatchRolledBack   = errors.New("batch rolled back")
+)
+
+// messageStorage keeps the messages of the default tenant in the messages
+// bucket, where they were stored before tenants existed, and those of every
+// other tenant in a bucket of its own nested in tenant_messages.
+type messageStorage struct {
+	session
+}
+
+func NewMessageStorage(db *bbolt.DB) (messageStorage, error) {
+	for _, bucket := range [][]byte{messagesBucket, tenantMessagesBucket} {
+		if err := createBucket(db, bucket); err != nil {
+			return messageStorage{}, err
+		}
+	}
+
+	return messageStorage{
+		session: session{db: db},
+	}, nil
+}
+
+func (m messageStorage) Save(ctx context.Context, message domain.Message) error {
+	messageJSON, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return m.updateTenant(ctx, func(bucket *bbolt.Bucket) error {
+		return bucket.Put([]byte(message.ID), messageJSON)
+	})
+}
+
+func (m messageStorage) SaveBatch(ctx context.Context, messages []domain.Message, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	results := make([]domain.BatchResult, len(messages))
+	encoded := make([][]byte, len(messages))
+
+	for i, message := range messages {
+		messageJSON, err := json.Marshal(message)
+		results[i] = domain.NewBatchResult(message.ID, err)
+		encoded[i] = messageJSON
+	}
+
+	if mode == domain.BatchModeAtomic && domain.HasBatchFailures(results) {
+		return abortBatch(results), nil
+	}
+
+	err := m.updateTenant(ctx, func(bucket *bbolt.Bucket) error {
+		for i, message := range messages {
+			if results[i].Err == nil {
+				results[i].Err = bucket.Put([]byte(message.ID), encoded[i])
+			}
+		}
+		if mode == domain.BatchModeAtomic && domain.HasBatchFailures(results) {
+			return errBatchRolledBack
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchRolledBack) {
+		return abortBatch(results), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (m messageStorage) GetByID(ctx context.Context, id string) (domain.Message, error) {
+	var message domain.Message
+
+	err := m.viewTenant(ctx, func(bucket *bbolt.Bucket) error {
+		var messageJSON []byte
+		if bucket != nil {
+			messageJSON = bucket.Get([]byte(id))
+		}
+		if messageJSON == nil {
+			return errors.Join(apperrors.NotFound, errNotFoundMessageID)
+		}
+		return json.Unmarshal(messageJSON, &message)
+	})
+	if err != nil {
+		return domain.Message{}, err
+	}
+
+	return message, nil
+}
+
+// ForEach reads the messages a page at a time, each page in a read
+// transaction of its own, and calls fn once the transaction is released, so
+// that a slow fn does not keep the file from reusing its pages. Messages
+// changed between two pages may or may not be visited.
+func (m messageStorage) ForEach(ctx context.Context, after string, fn func(message domain.Message) error) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		messages, err := m.page(ctx, after)
+		if err != nil {
+			return err
+		}
+		for _, message := range messages {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(message); err != nil {
+				if errors.Is(err, ports.ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+
+		if len(messages) < forEachPageSize {
+			return nil
+		}
+		after = messages[len(messages)-1].ID
+	}
+}
+
+// page decodes up to forEachPageSize messages of the tenant of ctx, starting
+// after the message with id after unless it is empty.
+func (m messageStorage) page(ctx context.Context, after string) ([]domain.Message, error) {
+	var messages []domain.Message
+	err := m.viewTenant(ctx, func(bucket *bbolt.Bucket) error {
+		if bucket == nil {
+			return nil
+		}
+
+		cursor := bucket.Cursor()
+		key, messageJSON := cursor.Seek([]byte(after))
+		if key != nil && after != "" && string(key) == after {
+			key, messageJSON = cursor.Next()
+		}
+		for ; key != nil && len(messages) < forEachPageSize; key, messageJSON = cursor.Next() {
+			var message domain.Message
+			if err := json.Unmarshal(messageJSON, &message); err != nil {
+				return err
+			}
+			messages = append(messages, message)
+		}
+		return nil
+	})
+	return messages, err
+}
+
+func (m messageStorage) DeleteByID(ctx context.Context, id string) error {
+	return m.updateTenant(ctx, func(bucket *bbolt.Bucket) error {
+		if bucket.Get([]byte(id)) == nil {
+			return errors.Join(apperrors.NotFound, errNotFoundMessageID)
+		}
+		return bucket.Delete([]byte(id))
+	})
+}
+
+func (m messageStorage) DeleteBatch(ctx context.Context, ids []string, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	results := make([]domain.BatchResult, len(ids))
+
+	err := m.updateTenant(ctx, func(bucket *bbolt.Bucket) error {
+		for i, id := range ids {
+			if bucket.Get([]byte(id)) == nil {
+				results[i] = domain.NewBatchResult(id, errors.Join(apperrors.NotFound, errNotFoundMessageID))
+				continue
+			}
+			results[i] = domain.NewBatchResult(id, bucket.Delete([]byte(id)))
+		}
+		if mode == domain.BatchModeAtomic && domain.HasBatchFailures(results) {
+			return errBatchRolledBack
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchRolledBack) {
+		return abortBatch(results), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// updateTenant runs fn on the bucket of the tenant of ctx, creating it on the
+// first write.
+func (m messageStorage) updateTenant(ctx context.Context, fn func(bucket *bbolt.Bucket) error) error {
+	tenant := domain.TenantFromContext(ctx)
+	if tenant == domain.DefaultTenant {
+		return m.update(messagesBucket, fn)
+	}
+	return m.update(tenantMessagesBucket, func(tenants *bbolt.Bucket) error {
+		bucket, err := tenants.CreateBucketIfNotExists([]byte(tenant))
+		if err != nil {
+			return err
+		}
+		return fn(bucket)
+	})
+}
+
+// viewTenant runs fn on the bucket of the tenant of ctx, which is nil until
+// the tenant stores its first message.
+func (m messageStorage) viewTenant(ctx context.Context, fn func(bucket *bbolt.Bucket) error) error {
+	tenant := domain.TenantFromContext(ctx)
+	if tenant == domain.DefaultTenant {
+		return m.view(messagesBucket, fn)
+	}
+	return m.view(tenantMessagesBucket, func(tenants *bbolt.Bucket) error {
+		return fn(tenants.Bucket([]byte(tenant)))
+	})
+}
+
+func abortBatch(results []domain.BatchResult) []domain.BatchResult {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = apperrors.Aborted
+		}
+	}
+	return results
+}
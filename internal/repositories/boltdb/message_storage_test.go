@@ -0,0 +1,325 @@
+package boltdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func TestSave_ShouldSaveMessageWithSuccess(t *testing.T) {
+	message := domain.NewMessage("id", "message content")
+
+	repo := setupMessageStorage(t)
+	err := repo.Save(context.Background(), message)
+
+	assert.NoError(t, err)
+}
+
+func TestSaveBatch_ShouldSaveMessagesWithSuccess(t *testing.T) {
+	ctx := context.Background()
+	messages := []domain.Message{
+		domain.NewMessage("id1", "message content 1"),
+		domain.NewMessage("id2", "message content 2"),
+	}
+
+	repo := setupMessageStorage(t)
+	results, err := repo.SaveBatch(ctx, messages, domain.BatchModeAtomic)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.BatchResult{
+		domain.NewBatchResult("id1", nil),
+		domain.NewBatchResult("id2", nil),
+	}, results)
+
+	actualMessages, err := allMessages(ctx, repo)
+	assert.NoError(t, err)
+	assert.Equal(t, messages, actualMessages)
+}
+
+func TestSaveBatch_ShouldNotSaveAnyMessageWhenAtomicBatchHasFailures(t *testing.T) {
+	ctx := context.Background()
+	messages := []domain.Message{
+		domain.NewMessage("id", "message content 1"),
+		domain.NewMessage("", "message content 2"),
+	}
+
+	repo := setupMessageStorage(t)
+	results, err := repo.SaveBatch(ctx, messages, domain.BatchModeAtomic)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, apperrors.Aborted)
+	assert.Error(t, results[1].Err)
+
+	actualMessages, err := allMessages(ctx, repo)
+	assert.NoError(t, err)
+	assert.Empty(t, actualMessages)
+}
+
+func TestGetByID_ShouldReturnErrorWhenMessageNotFound(t *testing.T) {
+	repo := setupMessageStorage(t)
+	actualMessage, err := repo.GetByID(context.Background(), uuid.NewString())
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.Empty(t, actualMessage)
+}
+
+func TestGetByID_ShouldReturnErrorWhenInvalidMessageContent(t *testing.T) {
+	messageID := uuid.NewString()
+
+	repo := setupMessageStorage(t)
+	putRaw(t, repo.db, messagesBucket, messageID, "{")
+	message, err := repo.GetByID(context.Background(), messageID)
+
+	assert.Error(t, err)
+	assert.Empty(t, message)
+}
+
+func TestGetByID_ShouldGetMessageWithSuccess(t *testing.T) {
+	ctx := context.Background()
+	expectedMessage := domain.NewMessage("id", "message content")
+
+	repo := setupMessageStorage(t)
+	err := repo.Save(ctx, expectedMessage)
+	assert.NoError(t, err)
+
+	actualMessage, err := repo.GetByID(ctx, expectedMessage.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMessage, actualMessage)
+}
+
+func TestForEach_ShouldVisitMessagesInIDOrder(t *testing.T) {
+	ctx := context.Background()
+	firstMessage := domain.NewMessage("id1", "message content 1")
+	secondMessage := domain.NewMessage("id2", "message content 2")
+
+	repo := setupMessageStorage(t)
+	assert.NoError(t, repo.Save(ctx, secondMessage))
+	assert.NoError(t, repo.Save(ctx, firstMessage))
+
+	var visited []domain.Message
+	err := repo.ForEach(ctx, "", func(message domain.Message) error {
+		visited = append(visited, message)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{firstMessage, secondMessage}, visited)
+}
+
+func TestForEach_ShouldReturnErrorWhenInvalidMessageContent(t *testing.T) {
+	repo := setupMessageStorage(t)
+	putRaw(t, repo.db, messagesBucket, uuid.NewString(), "{")
+	err := repo.ForEach(context.Background(), "", func(message domain.Message) error { return nil })
+
+	assert.Error(t, err)
+}
+
+func TestForEach_ShouldStopWithoutErrorWhenAskedTo(t *testing.T) {
+	ctx := context.Background()
+	firstMessage := domain.NewMessage("id1", "message content 1")
+
+	repo := setupMessageStorage(t)
+	assert.NoError(t, repo.Save(ctx, firstMessage))
+	assert.NoError(t, repo.Save(ctx, domain.NewMessage("id2", "message content 2")))
+
+	var visited []domain.Message
+	err := repo.ForEach(ctx, "", func(message domain.Message) error {
+		visited = append(visited, message)
+		return ports.ErrStopIteration
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{firstMessage}, visited)
+}
+
+func TestForEach_ShouldReturnErrorWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	repo := setupMessageStorage(t)
+	assert.NoError(t, repo.Save(ctx, domain.NewMessage("id1", "message content 1")))
+	assert.NoError(t, repo.Save(ctx, domain.NewMessage("id2", "message content 2")))
+
+	calls := 0
+	err := repo.ForEach(ctx, "", func(message domain.Message) error {
+		calls++
+		cancel()
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestForEach_ShouldVisitPagesOutsideOfTransactions(t *testing.T) {
+	ctx := context.Background()
+
+	repo := setupMessageStorage(t)
+	messages := make([]domain.Message, forEachPageSize*2+1)
+	for i := range messages {
+		messages[i] = domain.NewMessage(fmt.Sprintf("id%03d", i), "message content")
+	}
+	_, err := repo.SaveBatch(ctx, messages, domain.BatchModeAtomic)
+	assert.NoError(t, err)
+
+	var visited []domain.Message
+	err = repo.ForEach(ctx, "", func(message domain.Message) error {
+		assert.Zero(t, repo.db.Stats().OpenTxN)
+		visited = append(visited, message)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, messages, visited)
+}
+
+func TestForEach_ShouldStartAfterGivenID(t *testing.T) {
+	ctx := context.Background()
+	secondMessage := domain.NewMessage("id2", "message content 2")
+	thirdMessage := domain.NewMessage("id3", "message content 3")
+
+	repo := setupMessageStorage(t)
+	assert.NoError(t, repo.Save(ctx, domain.NewMessage("id1", "message content 1")))
+	assert.NoError(t, repo.Save(ctx, secondMessage))
+	assert.NoError(t, repo.Save(ctx, thirdMessage))
+
+	var visited []domain.Message
+	err := repo.ForEach(ctx, "id1", func(message domain.Message) error {
+		visited = append(visited, message)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{secondMessage, thirdMessage}, visited)
+
+	visited = nil
+	err = repo.ForEach(ctx, "id10", func(message domain.Message) error {
+		visited = append(visited, message)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{secondMessage, thirdMessage}, visited)
+}
+
+func TestDeleteByID_ShouldReturnErrorWhenMessageNotFound(t *testing.T) {
+	repo := setupMessageStorage(t)
+	err := repo.DeleteByID(context.Background(), uuid.NewString())
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestDeleteByID_ShouldDeleteMessageWithSuccess(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage("id", "message content")
+
+	repo := setupMessageStorage(t)
+	err := repo.Save(ctx, message)
+	assert.NoError(t, err)
+
+	err = repo.DeleteByID(ctx, message.ID)
+	assert.NoError(t, err)
+	_, err = repo.GetByID(ctx, message.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestDeleteBatch_ShouldNotDeleteAnyMessageWhenAtomicBatchHasFailures(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage("id", "message content")
+
+	repo := setupMessageStorage(t)
+	err := repo.Save(ctx, message)
+	assert.NoError(t, err)
+
+	results, err := repo.DeleteBatch(ctx, []string{message.ID, uuid.NewString()}, domain.BatchModeAtomic)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, apperrors.Aborted)
+	assert.ErrorIs(t, results[1].Err, apperrors.NotFound)
+
+	actualMessage, err := repo.GetByID(ctx, message.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, message, actualMessage)
+}
+
+func TestDeleteBatch_ShouldDeleteExistingMessagesWhenBestEffortBatchHasFailures(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage("id", "message content")
+
+	repo := setupMessageStorage(t)
+	err := repo.Save(ctx, message)
+	assert.NoError(t, err)
+
+	results, err := repo.DeleteBatch(ctx, []string{message.ID, message.ID}, domain.BatchModeBestEffort)
+	assert.NoError(t, err)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, apperrors.NotFound)
+
+	_, err = repo.GetByID(ctx, message.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestMessageStorage_ShouldIsolateTenants(t *testing.T) {
+	acme := domain.ContextWithTenant(context.Background(), "acme")
+	globex := domain.ContextWithTenant(context.Background(), "globex")
+	acmeMessage := domain.NewMessage("id", "acme content")
+	globexMessage := domain.NewMessage("id", "globex content")
+
+	repo := setupMessageStorage(t)
+	assert.NoError(t, repo.Save(acme, acmeMessage))
+	assert.NoError(t, repo.Save(globex, globexMessage))
+	_, err := repo.SaveBatch(acme, []domain.Message{domain.NewMessage("acme-only", "acme content")}, domain.BatchModeAtomic)
+	assert.NoError(t, err)
+
+	actualMessage, err := repo.GetByID(globex, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, globexMessage, actualMessage)
+	_, err = repo.GetByID(globex, "acme-only")
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	_, err = repo.GetByID(context.Background(), "id")
+	assert.ErrorIs(t, err, apperrors.NotFound)
+
+	globexMessages, err := allMessages(globex, repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{globexMessage}, globexMessages)
+	defaultMessages, err := allMessages(context.Background(), repo)
+	assert.NoError(t, err)
+	assert.Empty(t, defaultMessages)
+
+	assert.ErrorIs(t, repo.DeleteByID(globex, "acme-only"), apperrors.NotFound)
+	results, err := repo.DeleteBatch(globex, []string{"acme-only"}, domain.BatchModeBestEffort)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, apperrors.NotFound)
+	assert.NoError(t, repo.DeleteByID(globex, "id"))
+
+	acmeMessages, err := allMessages(acme, repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{domain.NewMessage("acme-only", "acme content"), acmeMessage}, acmeMessages)
+}
+
+func setupMessageStorage(t *testing.T) messageStorage {
+	repo, err := NewMessageStorage(setupDatabase(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func putRaw(t *testing.T, db *bbolt.DB, bucket []byte, key string, value string) {
+	err := db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucket).Put([]byte(key), []byte(value))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func allMessages(ctx context.Context, repo messageStorage) ([]domain.Message, error) {
+	var messages []domain.Message
+	err := repo.ForEach(ctx, "", func(message domain.Message) error {
+		messages = append(messages, message)
+		return nil
+	})
+	return messages, err
+}
@@ -0,0 +1,119 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	outboxBucket            = []byte("outbox")
+	outboxDeadLettersBucket = []byte("outbox_dead_letters")
+	errNotFoundOutboxEntry  = errors.New("outbox entry not found")
+)
+
+type outboxStorage struct {
+	session
+}
+
+func NewOutboxStorage(db *bbolt.DB) (outboxStorage, error) {
+	for _, bucket := range [][]byte{outboxBucket, outboxDeadLettersBucket} {
+		if err := createBucket(db, bucket); err != nil {
+			return outboxStorage{}, err
+		}
+	}
+
+	return outboxStorage{
+		session: session{db: db},
+	}, nil
+}
+
+func (o outboxStorage) Append(ctx context.Context, entries ...domain.OutboxEntry) error {
+	return o.update(outboxBucket, func(bucket *bbolt.Bucket) error {
+		for _, entry := range entries {
+			sequence, err := bucket.NextSequence()
+			if err != nil {
+				return err
+			}
+			entry.Sequence = sequence
+
+			if err := putOutboxEntry(bucket, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (o outboxStorage) Pending(ctx context.Context, limit int) ([]domain.OutboxEntry, error) {
+	var entries []domain.OutboxEntry
+
+	err := o.view(outboxBucket, func(bucket *bbolt.Bucket) error {
+		cursor := bucket.Cursor()
+		for key, entryJSON := cursor.First(); key != nil && len(entries) < limit; key, entryJSON = cursor.Next() {
+			var entry domain.OutboxEntry
+			if err := json.Unmarshal(entryJSON, &entry); err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (o outboxStorage) MarkDispatched(ctx context.Context, sequence uint64) error {
+	return o.update(outboxBucket, func(bucket *bbolt.Bucket) error {
+		if bucket.Get(sequenceKey(sequence)) == nil {
+			return errors.Join(apperrors.NotFound, errNotFoundOutboxEntry)
+		}
+		return bucket.Delete(sequenceKey(sequence))
+	})
+}
+
+func (o outboxStorage) MarkFailed(ctx context.Context, sequence uint64, reason string, deadLetter bool) error {
+	return o.update(outboxBucket, func(bucket *bbolt.Bucket) error {
+		entryJSON := bucket.Get(sequenceKey(sequence))
+		if entryJSON == nil {
+			return errors.Join(apperrors.NotFound, errNotFoundOutboxEntry)
+		}
+
+		var entry domain.OutboxEntry
+		if err := json.Unmarshal(entryJSON, &entry); err != nil {
+			return err
+		}
+		entry.Attempts++
+		entry.LastError = reason
+
+		if !deadLetter {
+			return putOutboxEntry(bucket, entry)
+		}
+		if err := bucket.Delete(sequenceKey(sequence)); err != nil {
+			return err
+		}
+		return putOutboxEntry(bucket.Tx().Bucket(outboxDeadLettersBucket), entry)
+	})
+}
+
+func putOutboxEntry(bucket *bbolt.Bucket, entry domain.OutboxEntry) error {
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(sequenceKey(entry.Sequence), entryJSON)
+}
+
+func sequenceKey(sequence uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, sequence)
+	return key
+}
@@ -0,0 +1,121 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+	"go.etcd.io/bbolt"
+)
+
+func TestOutboxPending_ShouldReturnEntriesInAppendOrder(t *testing.T) {
+	ctx := context.Background()
+	first := newOutboxEntry(t, domain.NewMessageCreated(domain.NewMessage("id2", "message content"), time.Now()))
+	second := newOutboxEntry(t, domain.NewMessageDeleted("id1", time.Now()))
+	third := newOutboxEntry(t, domain.NewMessageDeleted("id2", time.Now()))
+
+	repo := setupOutboxStorage(t)
+	assert.NoError(t, repo.Append(ctx, first, second))
+	assert.NoError(t, repo.Append(ctx, third))
+
+	entries, err := repo.Pending(ctx, 2)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, uint64(1), entries[0].Sequence)
+	assert.Equal(t, first.AggregateID, entries[0].AggregateID)
+	assert.Equal(t, uint64(2), entries[1].Sequence)
+	assert.Equal(t, second.AggregateID, entries[1].AggregateID)
+}
+
+func TestOutboxPending_ShouldReturnErrorWhenInvalidEntryContent(t *testing.T) {
+	repo := setupOutboxStorage(t)
+	putRaw(t, repo.db, outboxBucket, string(sequenceKey(1)), "{")
+
+	entries, err := repo.Pending(context.Background(), 10)
+	assert.Error(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestOutboxMarkDispatched_ShouldReturnErrorWhenEntryNotFound(t *testing.T) {
+	repo := setupOutboxStorage(t)
+	err := repo.MarkDispatched(context.Background(), 1)
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestOutboxMarkDispatched_ShouldRemoveEntryFromPending(t *testing.T) {
+	ctx := context.Background()
+
+	repo := setupOutboxStorage(t)
+	assert.NoError(t, repo.Append(ctx, newOutboxEntry(t, domain.NewMessageDeleted("id", time.Now()))))
+
+	err := repo.MarkDispatched(ctx, 1)
+	assert.NoError(t, err)
+
+	entries, err := repo.Pending(ctx, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestOutboxMarkFailed_ShouldReturnErrorWhenEntryNotFound(t *testing.T) {
+	repo := setupOutboxStorage(t)
+	err := repo.MarkFailed(context.Background(), 1, "unexpected error", false)
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestOutboxMarkFailed_ShouldKeepEntryPendingWithAttempts(t *testing.T) {
+	ctx := context.Background()
+
+	repo := setupOutboxStorage(t)
+	assert.NoError(t, repo.Append(ctx, newOutboxEntry(t, domain.NewMessageDeleted("id", time.Now()))))
+
+	err := repo.MarkFailed(ctx, 1, "unexpected error", false)
+	assert.NoError(t, err)
+
+	entries, err := repo.Pending(ctx, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, entries[0].Attempts)
+	assert.Equal(t, "unexpected error", entries[0].LastError)
+}
+
+func TestOutboxMarkFailed_ShouldMoveEntryToDeadLetters(t *testing.T) {
+	ctx := context.Background()
+
+	repo := setupOutboxStorage(t)
+	assert.NoError(t, repo.Append(ctx, newOutboxEntry(t, domain.NewMessageDeleted("id", time.Now()))))
+
+	err := repo.MarkFailed(ctx, 1, "unexpected error", true)
+	assert.NoError(t, err)
+
+	entries, err := repo.Pending(ctx, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	var deadLetter domain.OutboxEntry
+	err = repo.db.View(func(tx *bbolt.Tx) error {
+		return json.Unmarshal(tx.Bucket(outboxDeadLettersBucket).Get(sequenceKey(1)), &deadLetter)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, deadLetter.Attempts)
+}
+
+func setupOutboxStorage(t *testing.T) outboxStorage {
+	repo, err := NewOutboxStorage(setupDatabase(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func newOutboxEntry(t *testing.T, event domain.Event) domain.OutboxEntry {
+	entry, err := domain.NewOutboxEntry(domain.DefaultTenant, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entry
+}
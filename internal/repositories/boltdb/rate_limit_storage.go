@@ -0,0 +1,86 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+	"go.etcd.io/bbolt"
+)
+
+// rateLimitSweepInterval is how many requests are taken between two sweeps
+// of the keys whose limit is whole again.
+const rateLimitSweepInterval = 1024
+
+var rateLimitsBucket = []byte("rate_limits")
+
+// rateLimitStorage stores the TAT of each key as nanoseconds since the epoch.
+type rateLimitStorage struct {
+	db    *bbolt.DB
+	clock clock.Clock
+	takes *atomic.Uint64
+}
+
+func NewRateLimitStorage(db *bbolt.DB, clock clock.Clock) (rateLimitStorage, error) {
+	if err := createBucket(db, rateLimitsBucket); err != nil {
+		return rateLimitStorage{}, err
+	}
+
+	return rateLimitStorage{
+		db:    db,
+		clock: clock,
+		takes: &atomic.Uint64{},
+	}, nil
+}
+
+func (r rateLimitStorage) Take(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	var decision domain.RateLimitDecision
+
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(rateLimitsBucket)
+		now := r.clock.Now()
+
+		if r.takes.Add(1)%rateLimitSweepInterval == 0 {
+			if err := sweepRateLimits(bucket, now); err != nil {
+				return err
+			}
+		}
+
+		var stored time.Time
+		if value := bucket.Get([]byte(key)); len(value) == 8 {
+			stored = time.Unix(0, int64(binary.BigEndian.Uint64(value)))
+		}
+
+		var tat time.Time
+		tat, decision = limit.Take(stored, now)
+		return bucket.Put([]byte(key), binary.BigEndian.AppendUint64(nil, uint64(tat.UnixNano())))
+	})
+	if err != nil {
+		return domain.RateLimitDecision{}, err
+	}
+
+	return decision, nil
+}
+
+func sweepRateLimits(bucket *bbolt.Bucket, now time.Time) error {
+	var expired [][]byte
+	err := bucket.ForEach(func(key, value []byte) error {
+		if len(value) != 8 || !time.Unix(0, int64(binary.BigEndian.Uint64(value))).After(now) {
+			expired = append(expired, append([]byte(nil), key...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range expired {
+		if err := bucket.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package boltdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitTake_ShouldShareLimitsThroughDatabase(t *testing.T) {
+	ctx := context.Background()
+	db := setupDatabase(t)
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC))
+	limit := domain.NewRateLimit(2, time.Minute)
+
+	first, err := NewRateLimitStorage(db, clockMock)
+	assert.NoError(t, err)
+	second, err := NewRateLimitStorage(db, clockMock)
+	assert.NoError(t, err)
+
+	decision, err := first.Take(ctx, "client-1", limit)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, decision.Remaining)
+	decision, err = second.Take(ctx, "client-1", limit)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, decision.Remaining)
+
+	decision, err = first.Take(ctx, "client-1", limit)
+	assert.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 30*time.Second, decision.RetryAfter)
+
+	decision, err = second.Take(ctx, "client-2", limit)
+	assert.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
@@ -0,0 +1,34 @@
+package boltdb
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"go.etcd.io/bbolt"
+)
+
+type unitOfWork struct {
+	db *bbolt.DB
+}
+
+func NewUnitOfWork(db *bbolt.DB) unitOfWork {
+	return unitOfWork{
+		db: db,
+	}
+}
+
+// Do runs fn inside a single read-write transaction. The repositories handed
+// to fn are bound to it, so calling the non transactional ones from fn would
+// deadlock on the database lock. The buckets of the repositories must have
+// been created by their constructors.
+func (u unitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repositories ports.Repositories) error) error {
+	return u.db.Update(func(tx *bbolt.Tx) error {
+		txSession := session{db: u.db, tx: tx}
+		return fn(ctx, ports.Repositories{
+			Messages: messageStorage{session: txSession},
+			Outbox:   outboxStorage{session: txSession},
+			Usage:    usageStorage{session: txSession},
+			Audit:    auditStorage{session: txSession},
+		})
+	})
+}
@@ -0,0 +1,126 @@
+package boltdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitOfWork_ShouldRollbackWhenFunctionFails(t *testing.T) {
+	ctx := context.Background()
+	existingMessage := domain.NewMessage("id1", "message content 1")
+	newMessage := domain.NewMessage("id2", "message content 2")
+	unexpectedError := errors.New("unexpected error")
+
+	repo, outbox := setupTransactionalStorage(t)
+	err := repo.Save(ctx, existingMessage)
+	assert.NoError(t, err)
+
+	err = NewUnitOfWork(repo.db).Do(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		assert.NoError(t, repositories.Messages.Save(ctx, newMessage))
+		assert.NoError(t, repositories.Messages.DeleteByID(ctx, existingMessage.ID))
+		assert.NoError(t, repositories.Outbox.Append(ctx, newOutboxEntry(t, domain.NewMessageCreated(newMessage, time.Now()))))
+		return unexpectedError
+	})
+	assert.ErrorIs(t, err, unexpectedError)
+
+	entries, err := outbox.Pending(ctx, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	actualMessage, err := repo.GetByID(ctx, existingMessage.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, existingMessage, actualMessage)
+	_, err = repo.GetByID(ctx, newMessage.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestUnitOfWork_ShouldCommitWhenFunctionSucceeds(t *testing.T) {
+	ctx := context.Background()
+	existingMessage := domain.NewMessage("id1", "message content 1")
+	newMessage := domain.NewMessage("id2", "message content 2")
+
+	repo, outbox := setupTransactionalStorage(t)
+	err := repo.Save(ctx, existingMessage)
+	assert.NoError(t, err)
+
+	err = NewUnitOfWork(repo.db).Do(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		if err := repositories.Messages.Save(ctx, newMessage); err != nil {
+			return err
+		}
+		if err := repositories.Messages.DeleteByID(ctx, existingMessage.ID); err != nil {
+			return err
+		}
+		return repositories.Outbox.Append(ctx, newOutboxEntry(t, domain.NewMessageCreated(newMessage, time.Now())))
+	})
+	assert.NoError(t, err)
+
+	actualMessages, err := allMessages(ctx, repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{newMessage}, actualMessages)
+
+	entries, err := outbox.Pending(ctx, 10)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func setupTransactionalStorage(t *testing.T) (messageStorage, outboxStorage) {
+	db := setupDatabase(t)
+	messages, err := NewMessageStorage(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outbox, err := NewOutboxStorage(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return messages, outbox
+}
+
+func TestUnitOfWork_ShouldRollbackUsageWhenFunctionFails(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	unexpectedError := errors.New("unexpected error")
+
+	repo, _ := setupTransactionalStorage(t)
+	usage, err := NewUsageStorage(repo.db)
+	assert.NoError(t, err)
+
+	err = NewUnitOfWork(repo.db).Do(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		_, err := repositories.Usage.Add(ctx, day, 2)
+		assert.NoError(t, err)
+		return unexpectedError
+	})
+	assert.ErrorIs(t, err, unexpectedError)
+
+	count, err := usage.Add(ctx, day, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestUnitOfWork_ShouldRollbackAuditWhenFunctionFails(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	unexpectedError := errors.New("unexpected error")
+
+	repo, _ := setupTransactionalStorage(t)
+	audit, err := NewAuditStorage(repo.db)
+	assert.NoError(t, err)
+
+	err = NewUnitOfWork(repo.db).Do(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		_, err := repositories.Audit.Append(ctx, domain.NewAuditEntry(at, domain.DefaultTenant, domain.ActionCreate, "1", nil))
+		assert.NoError(t, err)
+		return unexpectedError
+	})
+	assert.ErrorIs(t, err, unexpectedError)
+
+	entry, err := audit.Append(ctx, domain.NewAuditEntry(at, domain.DefaultTenant, domain.ActionCreate, "2", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), entry.Sequence)
+}
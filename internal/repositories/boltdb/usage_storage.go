@@ -0,0 +1,51 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"go.etcd.io/bbolt"
+)
+
+// usageBucket maps each tenant to its latest daily usage.
+var usageBucket = []byte("usage")
+
+type usageStorage struct {
+	session
+}
+
+func NewUsageStorage(db *bbolt.DB) (usageStorage, error) {
+	if err := createBucket(db, usageBucket); err != nil {
+		return usageStorage{}, err
+	}
+
+	return usageStorage{
+		session: session{db: db},
+	}, nil
+}
+
+func (u usageStorage) Add(ctx context.Context, day time.Time, count int) (int, error) {
+	tenant := []byte(domain.TenantFromContext(ctx))
+	var usage domain.DailyUsage
+
+	err := u.update(usageBucket, func(bucket *bbolt.Bucket) error {
+		if usageJSON := bucket.Get(tenant); usageJSON != nil {
+			if err := json.Unmarshal(usageJSON, &usage); err != nil {
+				return err
+			}
+		}
+		usage = usage.Add(day, count)
+
+		usageJSON, err := json.Marshal(usage)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(tenant, usageJSON)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return usage.Messages, nil
+}
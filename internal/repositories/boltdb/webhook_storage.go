@@ -0,0 +1,224 @@
+package boltdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"go.etcd.io/bbolt"
+)
+
+var (
+	webhooksBucket          = []byte("webhooks")
+	webhookDeliveriesBucket = []byte("webhook_deliveries")
+	errNotFoundWebhookID    = errors.New("webhook id not found")
+	errNotFoundDeliveryID   = errors.New("webhook delivery id not found")
+)
+
+type webhookStorage struct {
+	session
+}
+
+func NewWebhookStorage(db *bbolt.DB) (webhookStorage, error) {
+	for _, bucket := range [][]byte{webhooksBucket, webhookDeliveriesBucket} {
+		if err := createBucket(db, bucket); err != nil {
+			return webhookStorage{}, err
+		}
+	}
+
+	return webhookStorage{
+		session: session{db: db},
+	}, nil
+}
+
+func (w webhookStorage) Save(ctx context.Context, webhook domain.Webhook) error {
+	webhookJSON, err := json.Marshal(webhook)
+	if err != nil {
+		return err
+	}
+
+	return w.update(webhooksBucket, func(bucket *bbolt.Bucket) error {
+		return bucket.Put([]byte(webhook.ID), webhookJSON)
+	})
+}
+
+func (w webhookStorage) GetByID(ctx context.Context, id string) (domain.Webhook, error) {
+	var webhook domain.Webhook
+
+	err := w.view(webhooksBucket, func(bucket *bbolt.Bucket) error {
+		var err error
+		webhook, err = getWebhook(ctx, bucket, id)
+		return err
+	})
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+// getWebhook reads the webhook with the id from the bucket, which is not
+// found when it belongs to a tenant other than the one in ctx.
+func getWebhook(ctx context.Context, bucket *bbolt.Bucket, id string) (domain.Webhook, error) {
+	webhookJSON := bucket.Get([]byte(id))
+	if webhookJSON == nil {
+		return domain.Webhook{}, errors.Join(apperrors.NotFound, errNotFoundWebhookID)
+	}
+
+	var webhook domain.Webhook
+	if err := json.Unmarshal(webhookJSON, &webhook); err != nil {
+		return domain.Webhook{}, err
+	}
+	if !webhook.BelongsTo(domain.TenantFromContext(ctx)) {
+		return domain.Webhook{}, errors.Join(apperrors.NotFound, errNotFoundWebhookID)
+	}
+	return webhook, nil
+}
+
+func (w webhookStorage) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	tenant := domain.TenantFromContext(ctx)
+	webhooks := []domain.Webhook{}
+
+	err := w.view(webhooksBucket, func(bucket *bbolt.Bucket) error {
+		return bucket.ForEach(func(_, webhookJSON []byte) error {
+			var webhook domain.Webhook
+			if err := json.Unmarshal(webhookJSON, &webhook); err != nil {
+				return err
+			}
+			if webhook.BelongsTo(tenant) {
+				webhooks = append(webhooks, webhook)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+			return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+		}
+		return webhooks[i].ID < webhooks[j].ID
+	})
+	return webhooks, nil
+}
+
+func (w webhookStorage) DeleteByID(ctx context.Context, id string) error {
+	return w.update(webhooksBucket, func(bucket *bbolt.Bucket) error {
+		if _, err := getWebhook(ctx, bucket, id); err != nil {
+			return err
+		}
+		if err := bucket.Delete([]byte(id)); err != nil {
+			return err
+		}
+
+		deliveries := bucket.Tx().Bucket(webhookDeliveriesBucket)
+		var deliveryIDs [][]byte
+		err := deliveries.ForEach(func(deliveryID, deliveryJSON []byte) error {
+			var delivery domain.WebhookDelivery
+			if err := json.Unmarshal(deliveryJSON, &delivery); err != nil {
+				return err
+			}
+			if delivery.WebhookID == id {
+				deliveryIDs = append(deliveryIDs, deliveryID)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, deliveryID := range deliveryIDs {
+			if err := deliveries.Delete(deliveryID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (w webhookStorage) SaveDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	deliveryJSON, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+
+	return w.update(webhookDeliveriesBucket, func(bucket *bbolt.Bucket) error {
+		if _, err := getWebhook(ctx, bucket.Tx().Bucket(webhooksBucket), delivery.WebhookID); err != nil {
+			return err
+		}
+		return bucket.Put([]byte(delivery.ID), deliveryJSON)
+	})
+}
+
+func (w webhookStorage) GetDelivery(ctx context.Context, webhookID string, id string) (domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+
+	err := w.view(webhookDeliveriesBucket, func(bucket *bbolt.Bucket) error {
+		deliveryJSON := bucket.Get([]byte(id))
+		if deliveryJSON == nil {
+			return errors.Join(apperrors.NotFound, errNotFoundDeliveryID)
+		}
+		return json.Unmarshal(deliveryJSON, &delivery)
+	})
+	if err != nil {
+		return domain.WebhookDelivery{}, err
+	}
+	if delivery.WebhookID != webhookID || !delivery.BelongsTo(domain.TenantFromContext(ctx)) {
+		return domain.WebhookDelivery{}, errors.Join(apperrors.NotFound, errNotFoundDeliveryID)
+	}
+
+	return delivery, nil
+}
+
+func (w webhookStorage) GetDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	tenant := domain.TenantFromContext(ctx)
+	return w.filterDeliveries(func(delivery domain.WebhookDelivery) bool {
+		return delivery.WebhookID == webhookID && delivery.BelongsTo(tenant)
+	}, 0)
+}
+
+func (w webhookStorage) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	return w.filterDeliveries(func(delivery domain.WebhookDelivery) bool {
+		return delivery.IsDue(now)
+	}, limit)
+}
+
+// filterDeliveries returns the matching deliveries oldest first, up to limit
+// when it is positive.
+func (w webhookStorage) filterDeliveries(match func(delivery domain.WebhookDelivery) bool, limit int) ([]domain.WebhookDelivery, error) {
+	deliveries := []domain.WebhookDelivery{}
+
+	err := w.view(webhookDeliveriesBucket, func(bucket *bbolt.Bucket) error {
+		return bucket.ForEach(func(_, deliveryJSON []byte) error {
+			var delivery domain.WebhookDelivery
+			if err := json.Unmarshal(deliveryJSON, &delivery); err != nil {
+				return err
+			}
+			if match(delivery) {
+				deliveries = append(deliveries, delivery)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+		}
+		return deliveries[i].ID < deliveries[j].ID
+	})
+
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,155 @@
+package boltdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookGetByID_ShouldReturnErrorWhenNotFound(t *testing.T) {
+	repo := setupWebhookStorage(t)
+	webhook, err := repo.GetByID(context.Background(), "webhook-id")
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.Empty(t, webhook)
+}
+
+func TestWebhookGetByID_ShouldReturnErrorWhenInvalidWebhookContent(t *testing.T) {
+	repo := setupWebhookStorage(t)
+	putRaw(t, repo.db, webhooksBucket, "webhook-id", "{")
+
+	webhook, err := repo.GetByID(context.Background(), "webhook-id")
+	assert.Error(t, err)
+	assert.Empty(t, webhook)
+}
+
+func TestWebhookGetAll_ShouldReturnWebhooksInCreationOrder(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	older := domain.NewWebhook("b", domain.DefaultTenant, "http://localhost/older", "secret", []string{domain.EventMessageCreated}, now)
+	newer := domain.NewWebhook("a", domain.DefaultTenant, "http://localhost/newer", "secret", nil, now.Add(time.Second))
+
+	repo := setupWebhookStorage(t)
+	assert.NoError(t, repo.Save(ctx, newer))
+	assert.NoError(t, repo.Save(ctx, older))
+
+	webhooks, err := repo.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Webhook{older, newer}, webhooks)
+}
+
+func TestWebhookDeleteByID_ShouldDeleteWebhookAndDeliveries(t *testing.T) {
+	ctx := context.Background()
+	webhook := domain.NewWebhook("webhook-id", domain.DefaultTenant, "http://localhost/hook", "secret", nil, time.Time{})
+	otherDelivery := domain.WebhookDelivery{ID: "other-delivery-id", WebhookID: "another-webhook-id"}
+
+	repo := setupWebhookStorage(t)
+	assert.NoError(t, repo.Save(ctx, webhook))
+	assert.NoError(t, repo.Save(ctx, domain.Webhook{ID: "another-webhook-id"}))
+	assert.NoError(t, repo.SaveDelivery(ctx, domain.WebhookDelivery{ID: "delivery-id", WebhookID: webhook.ID}))
+	assert.NoError(t, repo.SaveDelivery(ctx, otherDelivery))
+
+	assert.NoError(t, repo.DeleteByID(ctx, webhook.ID))
+
+	_, err := repo.GetByID(ctx, webhook.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	_, err = repo.GetDelivery(ctx, webhook.ID, "delivery-id")
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	delivery, err := repo.GetDelivery(ctx, otherDelivery.WebhookID, otherDelivery.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, otherDelivery, delivery)
+
+	err = repo.DeleteByID(ctx, webhook.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestWebhookSaveDelivery_ShouldReturnErrorWhenWebhookNotFound(t *testing.T) {
+	repo := setupWebhookStorage(t)
+	err := repo.SaveDelivery(context.Background(), domain.WebhookDelivery{ID: "delivery-id", WebhookID: "webhook-id"})
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestWebhookGetDelivery_ShouldReturnErrorWhenDeliveryBelongsToAnotherWebhook(t *testing.T) {
+	ctx := context.Background()
+	delivery := domain.WebhookDelivery{ID: "delivery-id", WebhookID: "webhook-id"}
+
+	repo := setupWebhookStorage(t)
+	assert.NoError(t, repo.Save(ctx, domain.Webhook{ID: "webhook-id"}))
+	assert.NoError(t, repo.SaveDelivery(ctx, delivery))
+
+	_, err := repo.GetDelivery(ctx, "another-webhook-id", delivery.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestWebhookDueDeliveries_ShouldReturnPendingDeliveriesThatAreDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	due := domain.WebhookDelivery{ID: "due", WebhookID: "webhook-id", Status: domain.DeliveryStatusPending, NextAttemptAt: now, CreatedAt: now}
+	secondDue := domain.WebhookDelivery{ID: "a-second-due", WebhookID: "webhook-id", Status: domain.DeliveryStatusPending, NextAttemptAt: now, CreatedAt: now.Add(time.Second)}
+	later := domain.WebhookDelivery{ID: "later", WebhookID: "webhook-id", Status: domain.DeliveryStatusPending, NextAttemptAt: now.Add(time.Minute)}
+	failed := domain.WebhookDelivery{ID: "failed", WebhookID: "webhook-id", Status: domain.DeliveryStatusFailed}
+
+	repo := setupWebhookStorage(t)
+	assert.NoError(t, repo.Save(ctx, domain.Webhook{ID: "webhook-id"}))
+	for _, delivery := range []domain.WebhookDelivery{due, secondDue, later, failed} {
+		assert.NoError(t, repo.SaveDelivery(ctx, delivery))
+	}
+
+	deliveries, err := repo.DueDeliveries(ctx, now, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{due, secondDue}, deliveries)
+
+	deliveries, err = repo.DueDeliveries(ctx, now, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{due}, deliveries)
+
+	deliveries, err = repo.GetDeliveries(ctx, "webhook-id")
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 4)
+}
+
+func setupWebhookStorage(t *testing.T) webhookStorage {
+	repo, err := NewWebhookStorage(setupDatabase(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func TestWebhookStorage_ShouldOnlyReturnWebhooksAndDeliveriesOfTenant(t *testing.T) {
+	ctx := domain.ContextWithTenant(context.Background(), "tenant-a")
+	otherCtx := domain.ContextWithTenant(context.Background(), "tenant-b")
+	webhook := domain.NewWebhook("webhook-id", "tenant-a", "http://localhost/hook", "secret", nil, time.Time{})
+	delivery := domain.WebhookDelivery{ID: "delivery-id", WebhookID: webhook.ID, Tenant: "tenant-a"}
+
+	repo := setupWebhookStorage(t)
+	assert.NoError(t, repo.Save(ctx, webhook))
+	assert.NoError(t, repo.SaveDelivery(ctx, delivery))
+
+	_, err := repo.GetByID(otherCtx, webhook.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	webhooks, err := repo.GetAll(otherCtx)
+	assert.NoError(t, err)
+	assert.Empty(t, webhooks)
+	_, err = repo.GetDelivery(otherCtx, webhook.ID, delivery.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	deliveries, err := repo.GetDeliveries(otherCtx, webhook.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, deliveries)
+	err = repo.SaveDelivery(otherCtx, domain.WebhookDelivery{ID: "other-delivery-id", WebhookID: webhook.ID})
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	err = repo.DeleteByID(otherCtx, webhook.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+
+	webhooks, err = repo.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Webhook{webhook}, webhooks)
+	deliveries, err = repo.GetDeliveries(ctx, webhook.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.WebhookDelivery{delivery}, deliveries)
+}
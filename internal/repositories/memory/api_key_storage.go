@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+var errNotFoundAPIKeyID = errors.New("api key id not found")
+
+type apiKeyStorage struct {
+	mu   *sync.RWMutex
+	keys map[string]domain.APIKey
+}
+
+func NewAPIKeyStorage() apiKeyStorage {
+	return apiKeyStorage{
+		mu:   &sync.RWMutex{},
+		keys: make(map[string]domain.APIKey),
+	}
+}
+
+func (a apiKeyStorage) Save(ctx context.Context, key domain.APIKey) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.keys[key.ID] = key
+	return nil
+}
+
+func (a apiKeyStorage) GetByID(ctx context.Context, id string) (domain.APIKey, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	key, ok := a.keys[id]
+	if !ok {
+		return domain.APIKey{}, errors.Join(apperrors.NotFound, errNotFoundAPIKeyID)
+	}
+	return key, nil
+}
+
+func (a apiKeyStorage) GetAll(ctx context.Context) ([]domain.APIKey, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	keys := make([]domain.APIKey, 0, len(a.keys))
+	for _, key := range a.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].CreatedAt.Equal(keys[j].CreatedAt) {
+			return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+		}
+		return keys[i].ID < keys[j].ID
+	})
+	return keys, nil
+}
+
+func (a apiKeyStorage) Touch(ctx context.Context, id string, usedAt time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key, ok := a.keys[id]
+	if !ok {
+		return errors.Join(apperrors.NotFound, errNotFoundAPIKeyID)
+	}
+	key.LastUsedAt = usedAt
+	a.keys[id] = key
+	return nil
+}
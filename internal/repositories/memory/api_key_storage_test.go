@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyGetByID_ShouldReturnErrorWhenNotFound(t *testing.T) {
+	repo := NewAPIKeyStorage()
+	key, err := repo.GetByID(context.Background(), "key-id")
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.Empty(t, key)
+}
+
+func TestAPIKeyGetAll_ShouldReturnKeysInCreationOrder(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	older := domain.NewAPIKey("b", "older", []string{"admin"}, []byte("salt"), []byte("hash"), now, time.Time{})
+	newer := domain.NewAPIKey("a", "newer", nil, []byte("salt"), []byte("hash"), now.Add(time.Second), time.Time{})
+
+	repo := NewAPIKeyStorage()
+	assert.NoError(t, repo.Save(ctx, newer))
+	assert.NoError(t, repo.Save(ctx, older))
+
+	keys, err := repo.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.APIKey{older, newer}, keys)
+}
+
+func TestAPIKeyTouch_ShouldOnlyUpdateLastUse(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	key := domain.NewAPIKey("key-id", "name", nil, []byte("salt"), []byte("hash"), now, time.Time{}).Revoke(now)
+
+	repo := NewAPIKeyStorage()
+	assert.NoError(t, repo.Save(ctx, key))
+	assert.NoError(t, repo.Touch(ctx, key.ID, now.Add(time.Hour)))
+
+	stored, err := repo.GetByID(ctx, key.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), stored.LastUsedAt)
+	assert.True(t, stored.IsRevoked())
+	assert.ErrorIs(t, repo.Touch(ctx, "unknown", now), apperrors.NotFound)
+}
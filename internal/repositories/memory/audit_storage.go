@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+)
+
+type auditStorage struct {
+	mu     *sync.RWMutex
+	data   map[string][]domain.AuditEntry
+	parent *auditStorage
+}
+
+func NewAuditStorage() auditStorage {
+	return auditStorage{
+		mu:   &sync.RWMutex{},
+		data: make(map[string][]domain.AuditEntry),
+	}
+}
+
+// layer returns an empty storage whose entries are chained after those of a
+// and only added to it on commit. a must not change while the layer is in use.
+func (a auditStorage) layer() auditStorage {
+	layer := NewAuditStorage()
+	layer.parent = &a
+	return layer
+}
+
+// commit adds the entries of a layer to its parent.
+func (a auditStorage) commit() {
+	for tenant, entries := range a.data {
+		a.parent.data[tenant] = append(a.parent.data[tenant], entries...)
+	}
+}
+
+func (a auditStorage) Append(ctx context.Context, entry domain.AuditEntry) (domain.AuditEntry, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	tenant := domain.TenantFromContext(ctx)
+	var previous domain.AuditEntry
+	if entries := a.entries(tenant); len(entries) > 0 {
+		previous = entries[len(entries)-1]
+	}
+	entry = entry.Chain(previous)
+	a.data[tenant] = append(a.data[tenant], entry)
+	return entry, nil
+}
+
+// ForEach iterates over the entries appended before it was called.
+func (a auditStorage) ForEach(ctx context.Context, messageID string, fn func(entry domain.AuditEntry) error) error {
+	a.mu.RLock()
+	entries := a.entries(domain.TenantFromContext(ctx))
+	a.mu.RUnlock()
+
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if messageID != "" && entry.MessageID != messageID {
+			continue
+		}
+		if err := fn(entry); err != nil {
+			if errors.Is(err, ports.ErrStopIteration) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// entries are those of the tenant in the parent followed by those of a.
+func (a auditStorage) entries(tenant string) []domain.AuditEntry {
+	if a.parent == nil {
+		return a.data[tenant]
+	}
+	parent := a.parent.entries(tenant)
+	return append(parent[:len(parent):len(parent)], a.data[tenant]...)
+}
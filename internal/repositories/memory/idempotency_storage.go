@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+)
+
+// idempotencySweepInterval is how many records are saved between two sweeps
+// of the expired ones, which are otherwise only removed when looked up again.
+const idempotencySweepInterval = 1024
+
+var errNotFoundIdempotencyKey = errors.New("idempotency key not found")
+
+type idempotencyStorage struct {
+	clock clock.Clock
+	mu    *sync.Mutex
+	data  map[string]domain.IdempotencyRecord
+	saves *int
+}
+
+func NewIdempotencyStorage(clock clock.Clock) idempotencyStorage {
+	return idempotencyStorage{
+		clock: clock,
+		mu:    &sync.Mutex{},
+		data:  make(map[string]domain.IdempotencyRecord),
+		saves: new(int),
+	}
+}
+
+func (i idempotencyStorage) Save(ctx context.Context, record domain.IdempotencyRecord) error {
+	now := i.clock.Now()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	*i.saves++
+	if *i.saves%idempotencySweepInterval == 0 {
+		for key, saved := range i.data {
+			if saved.IsExpired(now) {
+				delete(i.data, key)
+			}
+		}
+	}
+
+	i.data[record.Key] = record
+	return nil
+}
+
+func (i idempotencyStorage) GetByKey(ctx context.Context, key string) (domain.IdempotencyRecord, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	record, ok := i.data[key]
+	if !ok {
+		return domain.IdempotencyRecord{}, errors.Join(apperrors.NotFound, errNotFoundIdempotencyKey)
+	}
+	if record.IsExpired(i.clock.Now()) {
+		delete(i.data, key)
+		return domain.IdempotencyRecord{}, errors.Join(apperrors.NotFound, errNotFoundIdempotencyKey)
+	}
+
+	return record, nil
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyGetByKey_ShouldReturnErrorWhenKeyNotFound(t *testing.T) {
+	repo := NewIdempotencyStorage(new(mocks.ClockMock))
+	record, err := repo.GetByKey(context.Background(), "key")
+
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.Empty(t, record)
+}
+
+func TestIdempotencyGetByKey_ShouldReturnErrorWhenRecordExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	expiredRecord := domain.NewIdempotencyRecord("key", "fingerprint", 201, "application/json", []byte(`{}`), now.Add(-time.Second))
+
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(now)
+
+	repo := NewIdempotencyStorage(clockMock)
+	err := repo.Save(ctx, expiredRecord)
+	assert.NoError(t, err)
+
+	record, err := repo.GetByKey(ctx, expiredRecord.Key)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	assert.Empty(t, record)
+	assert.NotContains(t, repo.data, expiredRecord.Key)
+}
+
+func TestIdempotencyGetByKey_ShouldGetRecordWithSuccess(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	expectedRecord := domain.NewIdempotencyRecord("key", "fingerprint", 201, "application/json", []byte(`{}`), now.Add(time.Hour))
+
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(now)
+
+	repo := NewIdempotencyStorage(clockMock)
+	err := repo.Save(ctx, expectedRecord)
+	assert.NoError(t, err)
+
+	actualRecord, err := repo.GetByKey(ctx, expectedRecord.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRecord, actualRecord)
+}
+
+func TestIdempotencySave_ShouldSweepExpiredRecords(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(now)
+
+	repo := NewIdempotencyStorage(clockMock)
+	for i := 0; i < idempotencySweepInterval-1; i++ {
+		key := fmt.Sprintf("expired-%d", i)
+		err := repo.Save(ctx, domain.NewIdempotencyRecord(key, "fingerprint", 201, "application/json", []byte(`{}`), now.Add(-time.Second)))
+		assert.NoError(t, err)
+	}
+	assert.Len(t, repo.data, idempotencySweepInterval-1)
+
+	err := repo.Save(ctx, domain.NewIdempotencyRecord("key", "fingerprint", 201, "application/json", []byte(`{}`), now.Add(time.Hour)))
+	assert.NoError(t, err)
+	assert.Len(t, repo.data, 1)
+	assert.Contains(t, repo.data, "key")
+}
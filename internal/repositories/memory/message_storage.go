@@ -4,20 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
 	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
 )
 
+const tenantSeparator = "/"
+
 var errNotFoundMessageID = errors.New("message id not found")
 
+// messageStorage keys the messages by tenant and id, so that every method
+// only reaches the messages of the tenant in its context.
 type messageStorage struct {
-	data map[string][]byte
+	mu   *sync.RWMutex
+	data *table[string, []byte]
 }
 
 func NewMessageStorage() messageStorage {
 	return messageStorage{
-		data: make(map[string][]byte),
+		mu:   &sync.RWMutex{},
+		data: newTable[string, []byte](),
 	}
 }
 
@@ -27,12 +37,42 @@ func (m messageStorage) Save(ctx context.Context, message domain.Message) error
 		return err
 	}
 
-	m.data[message.ID] = messageJSON
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.data.set(messageKey(ctx, message.ID), messageJSON)
 	return nil
 }
 
+func (m messageStorage) SaveBatch(ctx context.Context, messages []domain.Message, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	results := make([]domain.BatchResult, len(messages))
+	encoded := make([][]byte, len(messages))
+
+	for i, message := range messages {
+		messageJSON, err := json.Marshal(message)
+		results[i] = domain.NewBatchResult(message.ID, err)
+		encoded[i] = messageJSON
+	}
+
+	if mode == domain.BatchModeAtomic && domain.HasBatchFailures(results) {
+		return abortBatch(results), nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, message := range messages {
+		if results[i].Err == nil {
+			m.data.set(messageKey(ctx, message.ID), encoded[i])
+		}
+	}
+	return results, nil
+}
+
 func (m messageStorage) GetByID(ctx context.Context, id string) (domain.Message, error) {
-	messageJSON, ok := m.data[id]
+	m.mu.RLock()
+	messageJSON, ok := m.data.get(messageKey(ctx, id))
+	m.mu.RUnlock()
 	if !ok {
 		return domain.Message{}, errors.Join(apperrors.NotFound, errNotFoundMessageID)
 	}
@@ -46,25 +86,107 @@ func (m messageStorage) GetByID(ctx context.Context, id string) (domain.Message,
 	return message, nil
 }
 
-func (m messageStorage) GetAll(ctx context.Context) ([]domain.Message, error) {
-	var messages []domain.Message
+// ForEach decodes one message at a time and does not hold the lock while fn
+// runs. Messages saved after it started are not visited.
+func (m messageStorage) ForEach(ctx context.Context, after string, fn func(message domain.Message) error) error {
+	m.mu.RLock()
+	keys := m.sortedKeys(domain.TenantFromContext(ctx))
+	m.mu.RUnlock()
+
+	afterKey := messageKey(ctx, after)
+	start := sort.SearchStrings(keys, afterKey)
+	if start < len(keys) && keys[start] == afterKey {
+		start++
+	}
+
+	for _, key := range keys[start:] {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		m.mu.RLock()
+		messageJSON, ok := m.data.get(key)
+		m.mu.RUnlock()
+		if !ok {
+			continue
+		}
 
-	for _, messageJSON := range m.data {
 		var message domain.Message
-		err := json.Unmarshal(messageJSON, &message)
-		if err != nil {
-			return nil, err
+		if err := json.Unmarshal(messageJSON, &message); err != nil {
+			return err
+		}
+		if err := fn(message); err != nil {
+			if errors.Is(err, ports.ErrStopIteration) {
+				return nil
+			}
+			return err
 		}
-		messages = append(messages, message)
 	}
-
-	return messages, nil
+	return nil
 }
 
 func (m messageStorage) DeleteByID(ctx context.Context, id string) error {
-	if _, ok := m.data[id]; !ok {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := messageKey(ctx, id)
+	if _, ok := m.data.get(key); !ok {
 		return errors.Join(apperrors.NotFound, errNotFoundMessageID)
 	}
-	delete(m.data, id)
+	m.data.remove(key)
 	return nil
 }
+
+func (m messageStorage) DeleteBatch(ctx context.Context, ids []string, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	results := make([]domain.BatchResult, len(ids))
+	deleted := make(map[string]bool, len(ids))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, id := range ids {
+		if _, ok := m.data.get(messageKey(ctx, id)); !ok || deleted[id] {
+			results[i] = domain.NewBatchResult(id, errors.Join(apperrors.NotFound, errNotFoundMessageID))
+			continue
+		}
+		deleted[id] = true
+		results[i] = domain.NewBatchResult(id, nil)
+	}
+
+	if mode == domain.BatchModeAtomic && domain.HasBatchFailures(results) {
+		return abortBatch(results), nil
+	}
+
+	for id := range deleted {
+		m.data.remove(messageKey(ctx, id))
+	}
+	return results, nil
+}
+
+// sortedKeys returns the keys of the messages of tenant in id order.
+func (m messageStorage) sortedKeys(tenant string) []string {
+	prefix := tenant + tenantSeparator
+	var keys []string
+	m.data.each(func(key string, _ []byte) {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	})
+	sort.Strings(keys)
+	return keys
+}
+
+// messageKey prefixes id with the tenant of ctx. Tenants cannot contain the
+// separator, so the prefix of a tenant never matches another one.
+func messageKey(ctx context.Context, id string) string {
+	return domain.TenantFromContext(ctx) + tenantSeparator + id
+}
+
+func abortBatch(results []domain.BatchResult) []domain.BatchResult {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].Err = apperrors.Aborted
+		}
+	}
+	return results
+}
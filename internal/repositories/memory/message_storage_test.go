@@ -2,10 +2,12 @@ package memory
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
 	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
 	"github.com/stretchr/testify/assert"
 )
@@ -33,10 +35,12 @@ func TestGetByID_ShouldReturnErrorWhenInvalidMessageContent(t *testing.T) {
 	messageID := uuid.NewString()
 	invalidMessageContent := []byte("{")
 
-	repo := messageStorage{data: map[string][]byte{messageID: invalidMessageContent}}
+	repo := NewMessageStorage()
+	repo.data.set(messageKey(context.Background(), messageID), invalidMessageContent)
 	message, err := repo.GetByID(context.Background(), messageID)
 
 	assert.Error(t, err)
+	assert.NotErrorIs(t, err, apperrors.NotFound)
 	assert.Empty(t, message)
 }
 
@@ -53,36 +57,118 @@ func TestGetByID_ShouldGetMessageWithSuccess(t *testing.T) {
 	assert.Equal(t, expectedMessage, actualMessage)
 }
 
-func TestGetAll_ShouldReturnErrorWhenInvalidMessageContent(t *testing.T) {
+func TestForEach_ShouldReturnErrorWhenInvalidMessageContent(t *testing.T) {
 	ctx := context.Background()
 	firstMessage := domain.Message{ID: uuid.NewString(), Content: "message content 1"}
 	secondMessage := domain.Message{ID: uuid.NewString(), Content: "{"}
 
-	repo := messageStorage{data: map[string][]byte{
-		firstMessage.ID:  []byte(firstMessage.Content),
-		secondMessage.ID: []byte(secondMessage.Content),
-	}}
-	messages, err := repo.GetAll(ctx)
+	repo := NewMessageStorage()
+	repo.data.set(messageKey(ctx, firstMessage.ID), []byte(firstMessage.Content))
+	repo.data.set(messageKey(ctx, secondMessage.ID), []byte(secondMessage.Content))
+	messages, err := allMessages(ctx, repo)
 
 	assert.Error(t, err)
 	assert.Empty(t, messages)
 }
 
-func TestGetAll_ShouldReturnAllMessagesWithSuccess(t *testing.T) {
+func TestForEach_ShouldVisitMessagesInIDOrder(t *testing.T) {
 	ctx := context.Background()
 	firstMessage := domain.NewMessage("id1", "message content 1")
 	secondMessage := domain.NewMessage("id2", "message content 2")
-	expectedMessages := []domain.Message{firstMessage, secondMessage}
 
 	repo := NewMessageStorage()
-	err := repo.Save(ctx, firstMessage)
+	assert.NoError(t, repo.Save(ctx, secondMessage))
+	assert.NoError(t, repo.Save(ctx, firstMessage))
+
+	var visited []domain.Message
+	err := repo.ForEach(ctx, "", func(message domain.Message) error {
+		visited = append(visited, message)
+		return nil
+	})
+
 	assert.NoError(t, err)
-	err = repo.Save(ctx, secondMessage)
+	assert.Equal(t, []domain.Message{firstMessage, secondMessage}, visited)
+}
+
+func TestForEach_ShouldStopAtFirstError(t *testing.T) {
+	ctx := context.Background()
+	stop := errors.New("stop")
+
+	repo := NewMessageStorage()
+	assert.NoError(t, repo.Save(ctx, domain.NewMessage("id1", "message content 1")))
+	assert.NoError(t, repo.Save(ctx, domain.NewMessage("id2", "message content 2")))
+
+	calls := 0
+	err := repo.ForEach(ctx, "", func(message domain.Message) error {
+		calls++
+		return stop
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestForEach_ShouldStopWithoutErrorWhenAskedTo(t *testing.T) {
+	ctx := context.Background()
+	firstMessage := domain.NewMessage("id1", "message content 1")
+
+	repo := NewMessageStorage()
+	assert.NoError(t, repo.Save(ctx, firstMessage))
+	assert.NoError(t, repo.Save(ctx, domain.NewMessage("id2", "message content 2")))
+
+	var visited []domain.Message
+	err := repo.ForEach(ctx, "", func(message domain.Message) error {
+		visited = append(visited, message)
+		return ports.ErrStopIteration
+	})
+
 	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{firstMessage}, visited)
+}
+
+func TestForEach_ShouldReturnErrorWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
 
-	actualMessages, err := repo.GetAll(ctx)
+	repo := NewMessageStorage()
+	assert.NoError(t, repo.Save(ctx, domain.NewMessage("id1", "message content 1")))
+	assert.NoError(t, repo.Save(ctx, domain.NewMessage("id2", "message content 2")))
+
+	calls := 0
+	err := repo.ForEach(ctx, "", func(message domain.Message) error {
+		calls++
+		cancel()
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestForEach_ShouldStartAfterGivenID(t *testing.T) {
+	ctx := context.Background()
+	secondMessage := domain.NewMessage("id2", "message content 2")
+	thirdMessage := domain.NewMessage("id3", "message content 3")
+
+	repo := NewMessageStorage()
+	assert.NoError(t, repo.Save(ctx, domain.NewMessage("id1", "message content 1")))
+	assert.NoError(t, repo.Save(ctx, secondMessage))
+	assert.NoError(t, repo.Save(ctx, thirdMessage))
+
+	var visited []domain.Message
+	err := repo.ForEach(ctx, "id1", func(message domain.Message) error {
+		visited = append(visited, message)
+		return nil
+	})
 	assert.NoError(t, err)
-	assert.Equal(t, expectedMessages, actualMessages)
+	assert.Equal(t, []domain.Message{secondMessage, thirdMessage}, visited)
+
+	visited = nil
+	err = repo.ForEach(ctx, "id10", func(message domain.Message) error {
+		visited = append(visited, message)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{secondMessage, thirdMessage}, visited)
 }
 
 func TestDeleteByID_ShouldReturnErrorWhenMessageNotFound(t *testing.T) {
@@ -106,3 +192,110 @@ func TestDeleteByID_ShouldDeleteMessageWithSuccess(t *testing.T) {
 	err = repo.DeleteByID(ctx, message.ID)
 	assert.NoError(t, err)
 }
+
+func TestSaveBatch_ShouldSaveMessagesWithSuccess(t *testing.T) {
+	ctx := context.Background()
+	messages := []domain.Message{
+		domain.NewMessage("id1", "message content 1"),
+		domain.NewMessage("id2", "message content 2"),
+	}
+
+	repo := NewMessageStorage()
+	results, err := repo.SaveBatch(ctx, messages, domain.BatchModeAtomic)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.BatchResult{
+		domain.NewBatchResult("id1", nil),
+		domain.NewBatchResult("id2", nil),
+	}, results)
+	for _, message := range messages {
+		actualMessage, err := repo.GetByID(ctx, message.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, message, actualMessage)
+	}
+}
+
+func TestDeleteBatch_ShouldNotDeleteAnyMessageWhenAtomicBatchHasFailures(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage("id", "message content")
+	missingID := uuid.NewString()
+
+	repo := NewMessageStorage()
+	err := repo.Save(ctx, message)
+	assert.NoError(t, err)
+
+	results, err := repo.DeleteBatch(ctx, []string{message.ID, missingID}, domain.BatchModeAtomic)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, apperrors.Aborted)
+	assert.ErrorIs(t, results[1].Err, apperrors.NotFound)
+
+	actualMessage, err := repo.GetByID(ctx, message.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, message, actualMessage)
+}
+
+func TestDeleteBatch_ShouldDeleteExistingMessagesWhenBestEffortBatchHasFailures(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage("id", "message content")
+	missingID := uuid.NewString()
+
+	repo := NewMessageStorage()
+	err := repo.Save(ctx, message)
+	assert.NoError(t, err)
+
+	results, err := repo.DeleteBatch(ctx, []string{message.ID, message.ID, missingID}, domain.BatchModeBestEffort)
+	assert.NoError(t, err)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, apperrors.NotFound)
+	assert.ErrorIs(t, results[2].Err, apperrors.NotFound)
+
+	_, err = repo.GetByID(ctx, message.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestMessageStorage_ShouldIsolateTenants(t *testing.T) {
+	acme := domain.ContextWithTenant(context.Background(), "acme")
+	globex := domain.ContextWithTenant(context.Background(), "globex")
+	acmeMessage := domain.NewMessage("id", "acme content")
+	globexMessage := domain.NewMessage("id", "globex content")
+
+	repo := NewMessageStorage()
+	assert.NoError(t, repo.Save(acme, acmeMessage))
+	assert.NoError(t, repo.Save(globex, globexMessage))
+	_, err := repo.SaveBatch(acme, []domain.Message{domain.NewMessage("acme-only", "acme content")}, domain.BatchModeAtomic)
+	assert.NoError(t, err)
+
+	actualMessage, err := repo.GetByID(globex, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, globexMessage, actualMessage)
+	_, err = repo.GetByID(globex, "acme-only")
+	assert.ErrorIs(t, err, apperrors.NotFound)
+	_, err = repo.GetByID(context.Background(), "id")
+	assert.ErrorIs(t, err, apperrors.NotFound)
+
+	globexMessages, err := allMessages(globex, repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{globexMessage}, globexMessages)
+	defaultMessages, err := allMessages(context.Background(), repo)
+	assert.NoError(t, err)
+	assert.Empty(t, defaultMessages)
+
+	assert.ErrorIs(t, repo.DeleteByID(globex, "acme-only"), apperrors.NotFound)
+	results, err := repo.DeleteBatch(globex, []string{"acme-only"}, domain.BatchModeBestEffort)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, apperrors.NotFound)
+	assert.NoError(t, repo.DeleteByID(globex, "id"))
+
+	acmeMessages, err := allMessages(acme, repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{domain.NewMessage("acme-only", "acme content"), acmeMessage}, acmeMessages)
+}
+
+func allMessages(ctx context.Context, repo messageStorage) ([]domain.Message, error) {
+	var messages []domain.Message
+	err := repo.ForEach(ctx, "", func(message domain.Message) error {
+		messages = append(messages, message)
+		return nil
+	})
+	return messages, err
+}
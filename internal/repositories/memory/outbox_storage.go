@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+var errNotFoundOutboxEntry = errors.New("outbox entry not found")
+
+type outboxStorage struct {
+	mu   *sync.Mutex
+	data *outboxData
+}
+
+type outboxData struct {
+	sequence    uint64
+	pending     *table[uint64, domain.OutboxEntry]
+	deadLetters *table[uint64, domain.OutboxEntry]
+}
+
+func NewOutboxStorage() outboxStorage {
+	return outboxStorage{
+		mu: &sync.Mutex{},
+		data: &outboxData{
+			pending:     newTable[uint64, domain.OutboxEntry](),
+			deadLetters: newTable[uint64, domain.OutboxEntry](),
+		},
+	}
+}
+
+func (o outboxStorage) Append(ctx context.Context, entries ...domain.OutboxEntry) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, entry := range entries {
+		o.data.sequence++
+		entry.Sequence = o.data.sequence
+		o.data.pending.set(entry.Sequence, entry)
+	}
+	return nil
+}
+
+func (o outboxStorage) Pending(ctx context.Context, limit int) ([]domain.OutboxEntry, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var entries []domain.OutboxEntry
+	o.data.pending.each(func(_ uint64, entry domain.OutboxEntry) {
+		entries = append(entries, entry)
+	})
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Sequence < entries[j].Sequence })
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+func (o outboxStorage) MarkDispatched(ctx context.Context, sequence uint64) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.data.pending.get(sequence); !ok {
+		return errors.Join(apperrors.NotFound, errNotFoundOutboxEntry)
+	}
+	o.data.pending.remove(sequence)
+	return nil
+}
+
+func (o outboxStorage) MarkFailed(ctx context.Context, sequence uint64, reason string, deadLetter bool) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	entry, ok := o.data.pending.get(sequence)
+	if !ok {
+		return errors.Join(apperrors.NotFound, errNotFoundOutboxEntry)
+	}
+
+	entry.Attempts++
+	entry.LastError = reason
+	if deadLetter {
+		o.data.pending.remove(sequence)
+		o.data.deadLetters.set(sequence, entry)
+		return nil
+	}
+	o.data.pending.set(sequence, entry)
+	return nil
+}
+
+func (d *outboxData) layer() *outboxData {
+	return &outboxData{
+		sequence:    d.sequence,
+		pending:     d.pending.layer(),
+		deadLetters: d.deadLetters.layer(),
+	}
+}
+
+func (d *outboxData) commit(parent *outboxData) {
+	parent.sequence = d.sequence
+	d.pending.commit()
+	d.deadLetters.commit()
+}
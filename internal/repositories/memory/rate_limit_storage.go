@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+)
+
+// rateLimitSweepInterval is how many requests are taken between two sweeps
+// of the keys whose limit is whole again.
+const rateLimitSweepInterval = 1024
+
+type rateLimitStorage struct {
+	clock clock.Clock
+	mu    *sync.Mutex
+	tats  map[string]time.Time
+	takes *int
+}
+
+func NewRateLimitStorage(clock clock.Clock) rateLimitStorage {
+	return rateLimitStorage{
+		clock: clock,
+		mu:    &sync.Mutex{},
+		tats:  make(map[string]time.Time),
+		takes: new(int),
+	}
+}
+
+func (r rateLimitStorage) Take(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	now := r.clock.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	*r.takes++
+	if *r.takes%rateLimitSweepInterval == 0 {
+		for tatKey, tat := range r.tats {
+			if !tat.After(now) {
+				delete(r.tats, tatKey)
+			}
+		}
+	}
+
+	tat, decision := limit.Take(r.tats[key], now)
+	r.tats[key] = tat
+	return decision, nil
+}
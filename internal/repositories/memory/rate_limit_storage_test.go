@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitTake_ShouldDenyRequestsOverLimitOfKey(t *testing.T) {
+	ctx := context.Background()
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC))
+	limit := domain.NewRateLimit(2, time.Minute)
+
+	repo := NewRateLimitStorage(clockMock)
+	for i := 0; i < 2; i++ {
+		decision, err := repo.Take(ctx, "client-1", limit)
+		assert.NoError(t, err)
+		assert.True(t, decision.Allowed)
+	}
+
+	decision, err := repo.Take(ctx, "client-1", limit)
+	assert.NoError(t, err)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, 30*time.Second, decision.RetryAfter)
+
+	decision, err = repo.Take(ctx, "client-2", limit)
+	assert.NoError(t, err)
+	assert.True(t, decision.Allowed)
+}
+
+func TestRateLimitTake_ShouldSweepKeysWhoseLimitIsWhole(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(now).Once()
+	clockMock.On("Now").Return(now.Add(time.Hour))
+
+	repo := NewRateLimitStorage(clockMock)
+	_, err := repo.Take(ctx, "idle", domain.NewRateLimit(2, time.Minute))
+	assert.NoError(t, err)
+	for i := 1; i < rateLimitSweepInterval; i++ {
+		_, err := repo.Take(ctx, "active", domain.NewRateLimit(rateLimitSweepInterval, time.Minute))
+		assert.NoError(t, err)
+	}
+
+	assert.NotContains(t, repo.tats, "idle")
+	assert.Contains(t, repo.tats, "active")
+}
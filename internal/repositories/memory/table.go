@@ -0,0 +1,72 @@
+package memory
+
+// table is a map that can be layered over another table. The writes to a
+// layer are kept apart from its parent until they are committed, so a unit of
+// work only holds what it changed instead of a copy of the whole storage.
+type table[K comparable, V any] struct {
+	parent  *table[K, V]
+	values  map[K]V
+	removed map[K]bool
+}
+
+func newTable[K comparable, V any]() *table[K, V] {
+	return &table[K, V]{
+		values:  make(map[K]V),
+		removed: make(map[K]bool),
+	}
+}
+
+// layer returns an empty table over t. The parent must not change while the
+// layer is in use.
+func (t *table[K, V]) layer() *table[K, V] {
+	layer := newTable[K, V]()
+	layer.parent = t
+	return layer
+}
+
+func (t *table[K, V]) get(key K) (V, bool) {
+	if value, ok := t.values[key]; ok {
+		return value, true
+	}
+	if t.parent == nil || t.removed[key] {
+		var zero V
+		return zero, false
+	}
+	return t.parent.get(key)
+}
+
+func (t *table[K, V]) set(key K, value V) {
+	t.values[key] = value
+	delete(t.removed, key)
+}
+
+func (t *table[K, V]) remove(key K) {
+	delete(t.values, key)
+	if t.parent != nil {
+		t.removed[key] = true
+	}
+}
+
+// each calls fn with every key and value of the table, in no particular order.
+func (t *table[K, V]) each(fn func(key K, value V)) {
+	if t.parent != nil {
+		t.parent.each(func(key K, value V) {
+			if _, changed := t.values[key]; !changed && !t.removed[key] {
+				fn(key, value)
+			}
+		})
+	}
+	for key, value := range t.values {
+		fn(key, value)
+	}
+}
+
+// commit applies the changes of a layer to its parent.
+func (t *table[K, V]) commit() {
+	for key := range t.removed {
+		t.parent.remove(key)
+	}
+	for key, value := range t.values {
+		t.parent.set(key, value)
+	}
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableLayer_ShouldKeepChangesApartUntilCommitted(t *testing.T) {
+	parent := newTable[string, int]()
+	parent.set("kept", 1)
+	parent.set("changed", 2)
+	parent.set("removed", 3)
+
+	layer := parent.layer()
+	layer.set("changed", 20)
+	layer.set("added", 40)
+	layer.remove("removed")
+
+	assert.Equal(t, map[string]int{"kept": 1, "changed": 20, "added": 40}, tableValues(layer))
+	assert.Equal(t, map[string]int{"kept": 1, "changed": 2, "removed": 3}, tableValues(parent))
+	assert.Len(t, layer.values, 2)
+
+	layer.commit()
+
+	assert.Equal(t, map[string]int{"kept": 1, "changed": 20, "added": 40}, tableValues(parent))
+	assert.Empty(t, parent.removed)
+}
+
+func TestTableLayer_ShouldReadValueSetAgainAfterRemoval(t *testing.T) {
+	parent := newTable[string, int]()
+	parent.set("key", 1)
+
+	layer := parent.layer()
+	layer.remove("key")
+	_, ok := layer.get("key")
+	assert.False(t, ok)
+
+	layer.set("key", 2)
+	value, ok := layer.get("key")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+
+	layer.commit()
+	value, ok = parent.get("key")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value)
+}
+
+func tableValues(t *table[string, int]) map[string]int {
+	values := make(map[string]int)
+	t.each(func(key string, value int) {
+		values[key] = value
+	})
+	return values
+}
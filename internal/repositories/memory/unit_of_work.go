@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+)
+
+// unitOfWork owns the usage storage, as usage is only ever counted along with
+// the messages it counts.
+type unitOfWork struct {
+	messages messageStorage
+	outbox   outboxStorage
+	usage    usageStorage
+	audit    auditStorage
+}
+
+func NewUnitOfWork(messages messageStorage, outbox outboxStorage, audit auditStorage) unitOfWork {
+	return unitOfWork{
+		messages: messages,
+		outbox:   outbox,
+		usage:    NewUsageStorage(),
+		audit:    audit,
+	}
+}
+
+// Do runs fn against layers over the stored data and only commits them when
+// fn succeeds, so a failed unit of work leaves the storage untouched.
+func (u unitOfWork) Do(ctx context.Context, fn func(ctx context.Context, repositories ports.Repositories) error) error {
+	u.messages.mu.Lock()
+	defer u.messages.mu.Unlock()
+	u.outbox.mu.Lock()
+	defer u.outbox.mu.Unlock()
+	u.usage.mu.Lock()
+	defer u.usage.mu.Unlock()
+	u.audit.mu.Lock()
+	defer u.audit.mu.Unlock()
+
+	messages := messageStorage{
+		mu:   &sync.RWMutex{},
+		data: u.messages.data.layer(),
+	}
+	outbox := outboxStorage{
+		mu:   &sync.Mutex{},
+		data: u.outbox.data.layer(),
+	}
+	usage := usageStorage{
+		mu:   &sync.Mutex{},
+		data: u.usage.data.layer(),
+	}
+	audit := u.audit.layer()
+
+	if err := fn(ctx, ports.Repositories{Messages: messages, Outbox: outbox, Usage: usage, Audit: audit}); err != nil {
+		return err
+	}
+
+	messages.data.commit()
+	outbox.data.commit(u.outbox.data)
+	usage.data.commit()
+	audit.commit()
+	return nil
+}
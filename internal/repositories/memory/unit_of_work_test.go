@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitOfWork_ShouldRollbackWhenFunctionFails(t *testing.T) {
+	ctx := context.Background()
+	existingMessage := domain.NewMessage("id1", "message content 1")
+	newMessage := domain.NewMessage("id2", "message content 2")
+	unexpectedError := errors.New("unexpected error")
+
+	repo := NewMessageStorage()
+	outbox := NewOutboxStorage()
+	err := repo.Save(ctx, existingMessage)
+	assert.NoError(t, err)
+
+	err = NewUnitOfWork(repo, outbox, NewAuditStorage()).Do(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		assert.NoError(t, repositories.Messages.Save(ctx, newMessage))
+		assert.NoError(t, repositories.Messages.DeleteByID(ctx, existingMessage.ID))
+		assert.NoError(t, repositories.Outbox.Append(ctx, newOutboxEntry(t, domain.NewMessageCreated(newMessage, time.Now()))))
+		return unexpectedError
+	})
+	assert.ErrorIs(t, err, unexpectedError)
+
+	entries, err := outbox.Pending(ctx, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	actualMessage, err := repo.GetByID(ctx, existingMessage.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, existingMessage, actualMessage)
+	_, err = repo.GetByID(ctx, newMessage.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestUnitOfWork_ShouldRollbackWhenFunctionPanics(t *testing.T) {
+	ctx := context.Background()
+	message := domain.NewMessage("id", "message content")
+
+	repo := NewMessageStorage()
+	assert.Panics(t, func() {
+		_ = NewUnitOfWork(repo, NewOutboxStorage(), NewAuditStorage()).Do(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+			assert.NoError(t, repositories.Messages.Save(ctx, message))
+			panic("unexpected panic")
+		})
+	})
+
+	_, err := repo.GetByID(ctx, message.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestUnitOfWork_ShouldCommitWhenFunctionSucceeds(t *testing.T) {
+	ctx := context.Background()
+	existingMessage := domain.NewMessage("id1", "message content 1")
+	newMessage := domain.NewMessage("id2", "message content 2")
+
+	repo := NewMessageStorage()
+	outbox := NewOutboxStorage()
+	err := repo.Save(ctx, existingMessage)
+	assert.NoError(t, err)
+
+	err = NewUnitOfWork(repo, outbox, NewAuditStorage()).Do(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		if err := repositories.Messages.Save(ctx, newMessage); err != nil {
+			return err
+		}
+		if err := repositories.Messages.DeleteByID(ctx, existingMessage.ID); err != nil {
+			return err
+		}
+		return repositories.Outbox.Append(ctx, newOutboxEntry(t, domain.NewMessageCreated(newMessage, time.Now())))
+	})
+	assert.NoError(t, err)
+
+	actualMessages, err := allMessages(ctx, repo)
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Message{newMessage}, actualMessages)
+
+	entries, err := outbox.Pending(ctx, 10)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestUnitOfWork_ShouldRollbackUsageWhenFunctionFails(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	unexpectedError := errors.New("unexpected error")
+
+	unitOfWork := NewUnitOfWork(NewMessageStorage(), NewOutboxStorage(), NewAuditStorage())
+	err := unitOfWork.Do(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		_, err := repositories.Usage.Add(ctx, day, 2)
+		assert.NoError(t, err)
+		return unexpectedError
+	})
+	assert.ErrorIs(t, err, unexpectedError)
+
+	err = unitOfWork.Do(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		count, err := repositories.Usage.Add(ctx, day, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestUnitOfWork_ShouldChainAuditEntriesOnlyWhenCommitted(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	unexpectedError := errors.New("unexpected error")
+
+	audit := NewAuditStorage()
+	first, err := audit.Append(ctx, domain.NewAuditEntry(at, domain.DefaultTenant, domain.ActionCreate, "1", nil))
+	assert.NoError(t, err)
+
+	unitOfWork := NewUnitOfWork(NewMessageStorage(), NewOutboxStorage(), audit)
+	err = unitOfWork.Do(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		_, err := repositories.Audit.Append(ctx, domain.NewAuditEntry(at, domain.DefaultTenant, domain.ActionCreate, "2", nil))
+		assert.NoError(t, err)
+		return unexpectedError
+	})
+	assert.ErrorIs(t, err, unexpectedError)
+
+	var second domain.AuditEntry
+	err = unitOfWork.Do(ctx, func(ctx context.Context, repositories ports.Repositories) error {
+		second, err = repositories.Audit.Append(ctx, domain.NewAuditEntry(at, domain.DefaultTenant, domain.ActionDelete, "1", nil))
+		return err
+	})
+	assert.NoError(t, err)
+
+	var entries []domain.AuditEntry
+	err = audit.ForEach(ctx, "", func(entry domain.AuditEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.AuditEntry{first, second}, entries)
+	assert.NoError(t, second.Verify(first))
+}
@@ -0,0 +1,32 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type usageStorage struct {
+	mu   *sync.Mutex
+	data *table[string, domain.DailyUsage]
+}
+
+func NewUsageStorage() usageStorage {
+	return usageStorage{
+		mu:   &sync.Mutex{},
+		data: newTable[string, domain.DailyUsage](),
+	}
+}
+
+func (u usageStorage) Add(ctx context.Context, day time.Time, count int) (int, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	tenant := domain.TenantFromContext(ctx)
+	current, _ := u.data.get(tenant)
+	usage := current.Add(day, count)
+	u.data.set(tenant, usage)
+	return usage.Messages, nil
+}
@@ -0,0 +1,31 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageAdd_ShouldCountMessagesPerTenantAndDay(t *testing.T) {
+	ctx := context.Background()
+	acmeCtx := domain.ContextWithTenant(ctx, "acme")
+	day := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	repo := NewUsageStorage()
+	count, err := repo.Add(ctx, day, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	count, err = repo.Add(acmeCtx, day, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	count, err = repo.Add(ctx, day, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, count)
+
+	count, err = repo.Add(ctx, day.AddDate(0, 0, 1), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+var (
+	errNotFoundWebhookID  = errors.New("webhook id not found")
+	errNotFoundDeliveryID = errors.New("webhook delivery id not found")
+)
+
+type webhookStorage struct {
+	mu         *sync.RWMutex
+	webhooks   map[string]domain.Webhook
+	deliveries map[string]domain.WebhookDelivery
+}
+
+func NewWebhookStorage() webhookStorage {
+	return webhookStorage{
+		mu:         &sync.RWMutex{},
+		webhooks:   make(map[string]domain.Webhook),
+		deliveries: make(map[string]domain.WebhookDelivery),
+	}
+}
+
+func (w webhookStorage) Save(ctx context.Context, webhook domain.Webhook) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (w webhookStorage) GetByID(ctx context.Context, id string) (domain.Webhook, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	webhook, ok := w.webhooks[id]
+	if !ok || !webhook.BelongsTo(domain.TenantFromContext(ctx)) {
+		return domain.Webhook{}, errors.Join(apperrors.NotFound, errNotFoundWebhookID)
+	}
+	return webhook, nil
+}
+
+func (w webhookStorage) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	tenant := domain.TenantFromContext(ctx)
+	webhooks := make([]domain.Webhook, 0, len(w.webhooks))
+	for _, webhook := range w.webhooks {
+		if webhook.BelongsTo(tenant) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+			return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+		}
+		return webhooks[i].ID < webhooks[j].ID
+	})
+	return webhooks, nil
+}
+
+func (w webhookStorage) DeleteByID(ctx context.Context, id string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if webhook, ok := w.webhooks[id]; !ok || !webhook.BelongsTo(domain.TenantFromContext(ctx)) {
+		return errors.Join(apperrors.NotFound, errNotFoundWebhookID)
+	}
+
+	delete(w.webhooks, id)
+	for deliveryID, delivery := range w.deliveries {
+		if delivery.WebhookID == id {
+			delete(w.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+func (w webhookStorage) SaveDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if webhook, ok := w.webhooks[delivery.WebhookID]; !ok || !webhook.BelongsTo(domain.TenantFromContext(ctx)) {
+		return errors.Join(apperrors.NotFound, errNotFoundWebhookID)
+	}
+	w.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (w webhookStorage) GetDelivery(ctx context.Context, webhookID string, id string) (domain.WebhookDelivery, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	delivery, ok := w.deliveries[id]
+	if !ok || delivery.WebhookID != webhookID || !delivery.BelongsTo(domain.TenantFromContext(ctx)) {
+		return domain.WebhookDelivery{}, errors.Join(apperrors.NotFound, errNotFoundDeliveryID)
+	}
+	return delivery, nil
+}
+
+func (w webhookStorage) GetDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	tenant := domain.TenantFromContext(ctx)
+	return w.filterDeliveries(func(delivery domain.WebhookDelivery) bool {
+		return delivery.WebhookID == webhookID && delivery.BelongsTo(tenant)
+	}, 0), nil
+}
+
+func (w webhookStorage) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	return w.filterDeliveries(func(delivery domain.WebhookDelivery) bool {
+		return delivery.IsDue(now)
+	}, limit), nil
+}
+
+// filterDeliveries returns the matching deliveries oldest first, up to limit
+// when it is positive.
+func (w webhookStorage) filterDeliveries(match func(delivery domain.WebhookDelivery) bool, limit int) []domain.WebhookDelivery {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	deliveries := []domain.WebhookDelivery{}
+	for _, delivery := range w.deliveries {
+		if match(delivery) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		if !deliveries[i].CreatedAt.Equal(deliveries[j].CreatedAt) {
+			return deliveries[i].CreatedAt.Before(deliveries[j].CreatedAt)
+		}
+		return deliveries[i].ID < deliveries[j].ID
+	})
+
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries
+}
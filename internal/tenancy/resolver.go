@@ -0,0 +1,174 @@
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+// Header names the tenant of requests whose host or principal do not.
+const Header = "X-Tenant-ID"
+
+var (
+	errMissingTenant  = errors.New("the request names no tenant")
+	errUnknownTenant  = errors.New("unknown tenant")
+	errDisabledTenant = errors.New("tenant is disabled")
+	errTenantMismatch = errors.New("the host and the X-Tenant-ID header name different tenants")
+	errOtherTenant    = errors.New("the principal belongs to another tenant")
+)
+
+// Resolver finds the tenant of a request among the configured ones. The zero
+// value has no tenants and resolves every request to the default tenant.
+type Resolver struct {
+	tenants map[string]Tenant
+	hosts   map[string]string
+	domain  string
+}
+
+// NewResolver also resolves the subdomains of domain, unless it is empty, to
+// the tenant of the same id.
+func NewResolver(tenants []Tenant, domain string) (Resolver, error) {
+	resolver := Resolver{
+		tenants: make(map[string]Tenant, len(tenants)),
+		hosts:   make(map[string]string),
+		domain:  normalizeHost(domain),
+	}
+	for _, tenant := range tenants {
+		if !validID(tenant.ID) {
+			return Resolver{}, fmt.Errorf("%w: %q", errInvalidTenantID, tenant.ID)
+		}
+		if _, ok := resolver.tenants[tenant.ID]; ok {
+			return Resolver{}, fmt.Errorf("%w: %q", errDuplicateTenant, tenant.ID)
+		}
+		if tenant.DailyMessageQuota < 0 {
+			return Resolver{}, fmt.Errorf("%w: %q", errNegativeQuota, tenant.ID)
+		}
+		resolver.tenants[tenant.ID] = tenant
+
+		for _, host := range tenant.Hosts {
+			host = normalizeHost(host)
+			if _, ok := resolver.hosts[host]; ok {
+				return Resolver{}, fmt.Errorf("%w: %q", errDuplicateHost, host)
+			}
+			resolver.hosts[host] = tenant.ID
+		}
+	}
+	return resolver, nil
+}
+
+func (r Resolver) Enabled() bool {
+	return len(r.tenants) > 0
+}
+
+// Resolve returns the tenant a request acts in. A principal acts in its
+// tenant, or in the default tenant when it has none, and the host and header
+// may only name that same tenant. Principals without a tenant that have the
+// cross-tenant role, and unauthenticated requests, act in the tenant named by
+// the host, then by the header, and finally in the default tenant when it is
+// configured.
+func (r Resolver) Resolve(ctx context.Context, host string, header string) (string, error) {
+	if !r.Enabled() {
+		return domain.DefaultTenant, nil
+	}
+
+	tenant, err := r.named(host, header)
+	if err != nil {
+		return "", err
+	}
+	if bound, ok := boundTenant(ctx); ok {
+		if tenant != "" && tenant != bound {
+			return "", errors.Join(apperrors.Forbidden, errOtherTenant)
+		}
+		tenant = bound
+	}
+	if tenant == "" {
+		if _, ok := r.tenants[domain.DefaultTenant]; !ok {
+			return "", errors.Join(apperrors.InvalidInput, errMissingTenant)
+		}
+		tenant = domain.DefaultTenant
+	}
+
+	config, ok := r.tenants[tenant]
+	if !ok {
+		return "", errors.Join(apperrors.Forbidden, errUnknownTenant)
+	}
+	if config.Disabled {
+		return "", errors.Join(apperrors.Forbidden, errDisabledTenant)
+	}
+	return tenant, nil
+}
+
+// Tenants returns the ids of the configured tenants, disabled ones included,
+// and of the default tenant, which may hold messages written before tenants
+// were configured, in id order.
+func (r Resolver) Tenants() []string {
+	tenants := []string{domain.DefaultTenant}
+	for id := range r.tenants {
+		if id != domain.DefaultTenant {
+			tenants = append(tenants, id)
+		}
+	}
+	sort.Strings(tenants)
+	return tenants
+}
+
+// DailyMessages is the daily message quota of the tenant in ctx. The default
+// tenant has none unless it is configured.
+func (r Resolver) DailyMessages(ctx context.Context) int {
+	return r.tenants[domain.TenantFromContext(ctx)].DailyMessageQuota
+}
+
+// boundTenant returns the tenant the principal of ctx is bound to, if any.
+func boundTenant(ctx context.Context) (string, bool) {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	switch {
+	case !ok:
+		return "", false
+	case principal.Tenant != "":
+		return principal.Tenant, true
+	case principal.HasRole(domain.RoleCrossTenant):
+		return "", false
+	default:
+		return domain.DefaultTenant, true
+	}
+}
+
+// named returns the tenant named by the host or the header, if any.
+func (r Resolver) named(host string, header string) (string, error) {
+	if header != "" && !validID(header) {
+		return "", errors.Join(apperrors.InvalidInput, errInvalidTenantID)
+	}
+
+	tenant := r.hostTenant(host)
+	if tenant != "" && header != "" && tenant != header {
+		return "", errors.Join(apperrors.InvalidInput, errTenantMismatch)
+	}
+	if tenant != "" {
+		return tenant, nil
+	}
+	return header, nil
+}
+
+func (r Resolver) hostTenant(host string) string {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	host = normalizeHost(host)
+
+	if tenant, ok := r.hosts[host]; ok {
+		return tenant
+	}
+	if r.domain == "" {
+		return ""
+	}
+	if subdomain, ok := strings.CutSuffix(host, "."+r.domain); ok && validID(subdomain) {
+		return subdomain
+	}
+	return ""
+}
@@ -0,0 +1,159 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewResolver_ShouldReturnErrorWhenTenantsAreInvalid(t *testing.T) {
+	tests := map[string][]Tenant{
+		"empty id":       {{ID: ""}},
+		"uppercase id":   {{ID: "Acme"}},
+		"id with dot":    {{ID: "acme.com"}},
+		"duplicate id":   {{ID: "acme"}, {ID: "acme"}},
+		"duplicate host": {{ID: "acme", Hosts: []string{"example.com"}}, {ID: "globex", Hosts: []string{"Example.com."}}},
+		"negative quota": {{ID: "acme", DailyMessageQuota: -1}},
+	}
+	for name, tenants := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewResolver(tenants, "")
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestResolve_ShouldResolveTenantOfRequest(t *testing.T) {
+	resolver, err := NewResolver([]Tenant{
+		{ID: "acme", Hosts: []string{"messages.acme.com"}},
+		{ID: "globex"},
+		{ID: "initech", Disabled: true},
+	}, "hexapi.example.com")
+	assert.NoError(t, err)
+	unbound := domain.ContextWithPrincipal(context.Background(), domain.NewPrincipal("user-1", []string{string(domain.RoleCrossTenant)}))
+	boundToAcme := domain.ContextWithPrincipal(context.Background(), domain.NewPrincipal("user-1", nil).InTenant("acme"))
+
+	tests := map[string]struct {
+		ctx      context.Context
+		host     string
+		header   string
+		expected string
+		err      error
+	}{
+		"header":                   {ctx: unbound, header: "globex", expected: "globex"},
+		"subdomain":                {ctx: unbound, host: "globex.hexapi.example.com:8080", expected: "globex"},
+		"configured host":          {ctx: unbound, host: "Messages.Acme.com", expected: "acme"},
+		"host and same header":     {ctx: unbound, host: "messages.acme.com", header: "acme", expected: "acme"},
+		"host and other header":    {ctx: unbound, host: "messages.acme.com", header: "globex", err: apperrors.InvalidInput},
+		"unrelated host":           {ctx: unbound, host: "hexapi.example.com", header: "globex", expected: "globex"},
+		"bound principal":          {ctx: boundToAcme, host: "localhost:8080", expected: "acme"},
+		"bound principal, same":    {ctx: boundToAcme, header: "acme", expected: "acme"},
+		"bound principal, other":   {ctx: boundToAcme, header: "globex", err: apperrors.Forbidden},
+		"bound principal, subhost": {ctx: boundToAcme, host: "globex.hexapi.example.com", err: apperrors.Forbidden},
+		"unknown tenant":           {ctx: unbound, header: "umbrella", err: apperrors.Forbidden},
+		"unknown subdomain":        {ctx: unbound, host: "www.hexapi.example.com", err: apperrors.Forbidden},
+		"disabled tenant":          {ctx: unbound, header: "initech", err: apperrors.Forbidden},
+		"malformed header":         {ctx: unbound, header: "../acme", err: apperrors.InvalidInput},
+		"no tenant":                {ctx: context.Background(), err: apperrors.InvalidInput},
+		"unauthenticated":          {ctx: context.Background(), header: "globex", expected: "globex"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tenant, err := resolver.Resolve(test.ctx, test.host, test.header)
+
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				assert.Empty(t, tenant)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, tenant)
+			}
+		})
+	}
+}
+
+func TestResolve_ShouldFallBackToConfiguredDefaultTenant(t *testing.T) {
+	resolver, err := NewResolver([]Tenant{{ID: domain.DefaultTenant}, {ID: "acme"}}, "")
+	assert.NoError(t, err)
+
+	tenant, err := resolver.Resolve(context.Background(), "localhost", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DefaultTenant, tenant)
+}
+
+func TestResolve_ShouldBindPrincipalsWithoutTenantToDefaultTenant(t *testing.T) {
+	resolver, err := NewResolver([]Tenant{{ID: domain.DefaultTenant}, {ID: "acme"}}, "hexapi.example.com")
+	assert.NoError(t, err)
+	admin := domain.ContextWithPrincipal(context.Background(), domain.NewPrincipal("apikey:admin", []string{domain.ScopeAdmin}))
+
+	tenant, err := resolver.Resolve(admin, "localhost", "")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DefaultTenant, tenant)
+
+	tenant, err = resolver.Resolve(admin, "localhost", "acme")
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	assert.Empty(t, tenant)
+
+	tenant, err = resolver.Resolve(admin, "acme.hexapi.example.com", "")
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	assert.Empty(t, tenant)
+}
+
+func TestResolve_ShouldRejectPrincipalsWithoutTenantWhenDefaultTenantIsNotConfigured(t *testing.T) {
+	resolver, err := NewResolver([]Tenant{{ID: "acme"}}, "")
+	assert.NoError(t, err)
+	ctx := domain.ContextWithPrincipal(context.Background(), domain.NewPrincipal("user-1", []string{string(domain.RoleWriter)}))
+
+	tenant, err := resolver.Resolve(ctx, "localhost", "")
+
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	assert.Empty(t, tenant)
+}
+
+func TestResolve_ShouldLetCrossTenantPrincipalsActInAnyTenant(t *testing.T) {
+	resolver, err := NewResolver([]Tenant{{ID: "acme"}, {ID: "globex"}}, "")
+	assert.NoError(t, err)
+	crossTenant := domain.NewPrincipal("operator", []string{domain.ScopeAdmin, string(domain.RoleCrossTenant)})
+
+	for _, expected := range []string{"acme", "globex"} {
+		tenant, err := resolver.Resolve(domain.ContextWithPrincipal(context.Background(), crossTenant), "localhost", expected)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, tenant)
+	}
+
+	tenant, err := resolver.Resolve(domain.ContextWithPrincipal(context.Background(), crossTenant.InTenant("acme")), "localhost", "globex")
+	assert.ErrorIs(t, err, apperrors.Forbidden)
+	assert.Empty(t, tenant)
+}
+
+func TestResolve_ShouldResolveDefaultTenantWhenDisabled(t *testing.T) {
+	ctx := domain.ContextWithPrincipal(context.Background(), domain.NewPrincipal("user-1", nil).InTenant("acme"))
+
+	tenant, err := Resolver{}.Resolve(ctx, "acme.hexapi.example.com", "globex")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DefaultTenant, tenant)
+}
+
+func TestDailyMessages_ShouldReturnQuotaOfTenantInContext(t *testing.T) {
+	resolver, err := NewResolver([]Tenant{{ID: "acme", DailyMessageQuota: 100}, {ID: "globex"}}, "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 100, resolver.DailyMessages(domain.ContextWithTenant(context.Background(), "acme")))
+	assert.Equal(t, 0, resolver.DailyMessages(domain.ContextWithTenant(context.Background(), "globex")))
+	assert.Equal(t, 0, resolver.DailyMessages(context.Background()))
+}
+
+func TestTenants_ShouldListEveryTenantAndDefaultOne(t *testing.T) {
+	resolver, err := NewResolver([]Tenant{{ID: "globex", Disabled: true}, {ID: domain.DefaultTenant}, {ID: "acme"}}, "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"acme", domain.DefaultTenant, "globex"}, resolver.Tenants())
+	assert.Equal(t, []string{domain.DefaultTenant}, Resolver{}.Tenants())
+}
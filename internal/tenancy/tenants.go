@@ -0,0 +1,61 @@
+// Package tenancy resolves the tenant every request acts in.
+package tenancy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	tenantIDPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+	errInvalidTenantID = errors.New("tenant ids must be lowercase DNS labels")
+	errDuplicateTenant = errors.New("duplicate tenant")
+	errDuplicateHost   = errors.New("host is listed by more than one tenant")
+	errNegativeQuota   = errors.New("quotas cannot be negative")
+)
+
+// Tenant is the configuration of a tenant. Requests to one of Hosts act in
+// the tenant, and those to a disabled tenant are rejected. The tenant may
+// create up to DailyMessageQuota messages per UTC day, or any number when 0.
+type Tenant struct {
+	ID                string   `json:"id"`
+	Hosts             []string `json:"hosts,omitempty"`
+	Disabled          bool     `json:"disabled,omitempty"`
+	DailyMessageQuota int      `json:"daily_message_quota,omitempty"`
+}
+
+type tenantsFile struct {
+	Tenants []Tenant `json:"tenants"`
+}
+
+// LoadTenants reads the tenants of a JSON file such as
+// {"tenants": [{"id": "acme", "hosts": ["messages.acme.com"]}]}. An empty
+// path has no tenants.
+func LoadTenants(path string) ([]Tenant, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file tenantsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return file.Tenants, nil
+}
+
+func validID(id string) bool {
+	return tenantIDPattern.MatchString(id)
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
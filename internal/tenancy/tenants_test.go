@@ -0,0 +1,38 @@
+package tenancy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTenants_ShouldReadTenantsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"tenants": [{"id": "acme", "hosts": ["messages.acme.com"], "daily_message_quota": 1000}, {"id": "globex", "disabled": true}]}`), 0o600))
+
+	tenants, err := LoadTenants(path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Tenant{
+		{ID: "acme", Hosts: []string{"messages.acme.com"}, DailyMessageQuota: 1000},
+		{ID: "globex", Disabled: true},
+	}, tenants)
+}
+
+func TestLoadTenants_ShouldReturnNoTenantsWhenNothingIsConfigured(t *testing.T) {
+	tenants, err := LoadTenants("")
+
+	assert.NoError(t, err)
+	assert.Empty(t, tenants)
+}
+
+func TestLoadTenants_ShouldReturnErrorWhenFileIsInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"tenants": {`), 0o600))
+
+	_, err := LoadTenants(path)
+
+	assert.ErrorContains(t, err, path)
+}
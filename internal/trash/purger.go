@@ -0,0 +1,68 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+)
+
+// Purger empties the trash of every tenant of the messages deleted longer
+// than the retention ago.
+type Purger struct {
+	service   ports.MessageTrashUseCase
+	tenants   []string
+	clock     clock.Clock
+	interval  time.Duration
+	retention time.Duration
+}
+
+func NewPurger(service ports.MessageTrashUseCase, tenants []string, clock clock.Clock, interval time.Duration, retention time.Duration) Purger {
+	return Purger{
+		service:   service,
+		tenants:   tenants,
+		clock:     clock,
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+func (p Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.PurgeExpired(ctx); err != nil {
+			log.Printf("failed to purge the trash: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeExpired purges the tenants one after the other, going on with the
+// others when one of them fails.
+func (p Purger) PurgeExpired(ctx context.Context) error {
+	deletedBefore := p.clock.Now().Add(-p.retention)
+
+	var errs []error
+	for _, tenant := range p.tenants {
+		purged, err := p.service.Purge(domain.ContextWithTenant(ctx, tenant), deletedBefore)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenant, err))
+		}
+		if purged > 0 {
+			log.Printf("purged %d messages from the trash of tenant %s", purged, tenant)
+		}
+	}
+	return errors.Join(errs...)
+}
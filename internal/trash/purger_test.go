@@ -0,0 +1,54 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var fixedNow = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestPurgeExpired_ShouldPurgeEveryTenantPastRetention(t *testing.T) {
+	serviceMock := new(mocks.MessageTrashUseCaseMock)
+	serviceMock.On("Purge", mock.MatchedBy(inTenant("acme")), fixedNow.Add(-time.Hour)).Return(2, nil)
+	serviceMock.On("Purge", mock.MatchedBy(inTenant(domain.DefaultTenant)), fixedNow.Add(-time.Hour)).Return(0, nil)
+
+	purger := NewPurger(serviceMock, []string{"acme", domain.DefaultTenant}, newClockMock(), time.Minute, time.Hour)
+	err := purger.PurgeExpired(context.Background())
+
+	assert.NoError(t, err)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestPurgeExpired_ShouldGoOnWithOtherTenantsWhenOneFails(t *testing.T) {
+	unexpectedError := errors.New("unexpected error")
+
+	serviceMock := new(mocks.MessageTrashUseCaseMock)
+	serviceMock.On("Purge", mock.MatchedBy(inTenant("acme")), mock.Anything).Return(0, unexpectedError)
+	serviceMock.On("Purge", mock.MatchedBy(inTenant("globex")), mock.Anything).Return(1, nil)
+
+	purger := NewPurger(serviceMock, []string{"acme", "globex"}, newClockMock(), time.Minute, time.Hour)
+	err := purger.PurgeExpired(context.Background())
+
+	assert.ErrorIs(t, err, unexpectedError)
+	assert.ErrorContains(t, err, "tenant acme")
+	serviceMock.AssertExpectations(t)
+}
+
+func inTenant(tenant string) func(ctx context.Context) bool {
+	return func(ctx context.Context) bool {
+		return domain.TenantFromContext(ctx) == tenant
+	}
+}
+
+func newClockMock() *mocks.ClockMock {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(fixedNow)
+	return clockMock
+}
@@ -0,0 +1,91 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+)
+
+type Dispatcher struct {
+	repository  ports.WebhookRepository
+	sender      ports.WebhookSender
+	clock       clock.Clock
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	backoff     time.Duration
+}
+
+func NewDispatcher(
+	repository ports.WebhookRepository,
+	sender ports.WebhookSender,
+	clock clock.Clock,
+	interval time.Duration,
+	batchSize int,
+	maxAttempts int,
+	backoff time.Duration,
+) Dispatcher {
+	return Dispatcher{
+		repository:  repository,
+		sender:      sender,
+		clock:       clock,
+		interval:    interval,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+	}
+}
+
+func (d Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.DispatchDue(ctx); err != nil {
+			log.Printf("failed to dispatch webhook deliveries: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchDue sends the deliveries whose next attempt is due, whatever their
+// tenant, and records the outcome in the delivery log. Deliveries of webhooks
+// deleted in the meantime are skipped, since deleting a webhook removes its
+// deliveries too.
+func (d Dispatcher) DispatchDue(ctx context.Context) error {
+	deliveries, err := d.repository.DueDeliveries(ctx, d.clock.Now(), d.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		ctx := domain.ContextWithTenant(ctx, delivery.Tenant)
+		webhook, err := d.repository.GetByID(ctx, delivery.WebhookID)
+		if errors.Is(err, apperrors.NotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		attempt := d.sender.Send(ctx, webhook, delivery)
+		delivery = delivery.RecordAttempt(attempt, d.maxAttempts, d.backoff)
+		err = d.repository.SaveDelivery(ctx, delivery)
+		if err != nil && !errors.Is(err, apperrors.NotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
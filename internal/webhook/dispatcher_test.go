@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/repositories/memory"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDispatchDue_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
+	ctx := context.Background()
+	unexpectedError := errors.New("unexpected error")
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("DueDeliveries", ctx, fixedNow, 10).Return([]domain.WebhookDelivery{}, unexpectedError)
+
+	dispatcher := NewDispatcher(repositoryMock, new(mocks.WebhookSenderMock), newClockMock(), time.Second, 10, 3, time.Second)
+	err := dispatcher.DispatchDue(ctx)
+
+	assert.ErrorIs(t, err, unexpectedError)
+}
+
+func TestDispatchDue_ShouldRecordAttemptInDeliveryLog(t *testing.T) {
+	ctx := context.Background()
+	webhook := domain.NewWebhook("webhook-id", domain.DefaultTenant, "http://localhost/hook", "secret", nil, fixedNow)
+	delivery := newDelivery(t, "delivery-id", webhook.ID)
+	attempt := domain.WebhookAttempt{At: fixedNow, StatusCode: 500, Error: "unexpected status code 500"}
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("DueDeliveries", ctx, fixedNow, 10).Return([]domain.WebhookDelivery{delivery}, nil)
+	tenantCtx := domain.ContextWithTenant(ctx, domain.DefaultTenant)
+	repositoryMock.On("GetByID", tenantCtx, webhook.ID).Return(webhook, nil)
+	repositoryMock.On("SaveDelivery", tenantCtx, delivery.RecordAttempt(attempt, 3, time.Second)).Return(nil)
+	senderMock := new(mocks.WebhookSenderMock)
+	senderMock.On("Send", tenantCtx, webhook, delivery).Return(attempt)
+
+	dispatcher := NewDispatcher(repositoryMock, senderMock, newClockMock(), time.Second, 10, 3, time.Second)
+	err := dispatcher.DispatchDue(ctx)
+
+	assert.NoError(t, err)
+	repositoryMock.AssertExpectations(t)
+}
+
+func TestDispatchDue_ShouldSkipDeliveriesOfDeletedWebhooks(t *testing.T) {
+	ctx := context.Background()
+	delivery := newDelivery(t, "delivery-id", "webhook-id")
+
+	repositoryMock := new(mocks.WebhookRepositoryMock)
+	repositoryMock.On("DueDeliveries", ctx, fixedNow, 10).Return([]domain.WebhookDelivery{delivery}, nil)
+	repositoryMock.On("GetByID", domain.ContextWithTenant(ctx, domain.DefaultTenant), "webhook-id").Return(domain.Webhook{}, apperrors.NotFound)
+	senderMock := new(mocks.WebhookSenderMock)
+
+	dispatcher := NewDispatcher(repositoryMock, senderMock, newClockMock(), time.Second, 10, 3, time.Second)
+	err := dispatcher.DispatchDue(ctx)
+
+	assert.NoError(t, err)
+	senderMock.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	repositoryMock.AssertNotCalled(t, "SaveDelivery", mock.Anything, mock.Anything)
+}
+
+func TestDispatchDue_ShouldRetryUntilReceiverAccepts(t *testing.T) {
+	ctx := context.Background()
+	var requests atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	repository := memory.NewWebhookStorage()
+	webhook := domain.NewWebhook("webhook-id", domain.DefaultTenant, receiver.URL, "secret", nil, fixedNow)
+	assert.NoError(t, repository.Save(ctx, webhook))
+	assert.NoError(t, repository.SaveDelivery(ctx, newDelivery(t, "delivery-id", webhook.ID)))
+
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(fixedNow).Times(3)
+	clockMock.On("Now").Return(fixedNow.Add(time.Second))
+
+	dispatcher := NewDispatcher(repository, NewHTTPSender(clockMock, time.Second, true), clockMock, time.Second, 10, 3, time.Second)
+	assert.NoError(t, dispatcher.DispatchDue(ctx))
+
+	delivery, err := repository.GetDelivery(ctx, webhook.ID, "delivery-id")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeliveryStatusPending, delivery.Status)
+	assert.Equal(t, fixedNow.Add(time.Second), delivery.NextAttemptAt)
+
+	assert.NoError(t, dispatcher.DispatchDue(ctx))
+
+	delivery, err = repository.GetDelivery(ctx, webhook.ID, "delivery-id")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.DeliveryStatusSucceeded, delivery.Status)
+	assert.Len(t, delivery.Attempts, 2)
+	assert.Equal(t, http.StatusServiceUnavailable, delivery.Attempts[0].StatusCode)
+	assert.Equal(t, http.StatusOK, delivery.Attempts[1].StatusCode)
+	assert.Equal(t, int32(2), requests.Load())
+}
@@ -0,0 +1,118 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/clock"
+)
+
+const (
+	HeaderEvent     = "X-Hexapi-Event"
+	HeaderDelivery  = "X-Hexapi-Delivery"
+	HeaderTimestamp = "X-Hexapi-Timestamp"
+	HeaderSignature = "X-Hexapi-Signature"
+
+	signaturePrefix = "sha256="
+	maxResponseBody = 64 << 10
+)
+
+var errPrivateAddress = errors.New("webhook address is loopback, link-local or private")
+
+type httpSender struct {
+	client *http.Client
+	clock  clock.Clock
+}
+
+// NewHTTPSender refuses to connect to loopback, link-local and private
+// addresses unless allowPrivate is set. The address is checked once resolved,
+// for every connection including redirects, and proxies from the environment
+// are not used, as they would connect on the sender's behalf.
+func NewHTTPSender(clock clock.Clock, timeout time.Duration, allowPrivate bool) httpSender {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivate {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: refusePrivate}
+		transport.DialContext = dialer.DialContext
+		transport.Proxy = nil
+	}
+
+	return httpSender{
+		client: &http.Client{Timeout: timeout, Transport: transport},
+		clock:  clock,
+	}
+}
+
+// Send posts the delivery payload to the webhook URL. Any response outside
+// the 2xx range counts as a failed attempt.
+func (s httpSender) Send(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery) domain.WebhookAttempt {
+	startedAt := s.clock.Now()
+	attempt := domain.WebhookAttempt{At: startedAt}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	timestamp := strconv.FormatInt(startedAt.Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "hexapi-webhooks")
+	request.Header.Set(HeaderEvent, delivery.EventName)
+	request.Header.Set(HeaderDelivery, delivery.ID)
+	request.Header.Set(HeaderTimestamp, timestamp)
+	request.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	response, err := s.client.Do(request)
+	attempt.Duration = s.clock.Now().Sub(startedAt)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxResponseBody))
+
+	attempt.StatusCode = response.StatusCode
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		attempt.Error = fmt.Sprintf("unexpected status code %d", response.StatusCode)
+	}
+	return attempt
+}
+
+func refusePrivate(_ string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !domain.IsPublicAddress(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", errPrivateAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+// Sign returns the signature header value for a payload. Receivers compute it
+// with their copy of the secret and compare it with the X-Hexapi-Signature
+// header; the timestamp is signed too so old deliveries cannot be replayed.
+func Sign(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func Verify(secret string, timestamp string, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, payload)), []byte(signature))
+}
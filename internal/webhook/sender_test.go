@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/test/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var fixedNow = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestSend_ShouldPostSignedPayload(t *testing.T) {
+	var received *http.Request
+	var receivedBody []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	webhook := domain.NewWebhook("webhook-id", domain.DefaultTenant, receiver.URL, "secret", nil, fixedNow)
+	delivery := newDelivery(t, "delivery-id", webhook.ID)
+
+	sender := NewHTTPSender(newClockMock(), time.Second, true)
+	attempt := sender.Send(context.Background(), webhook, delivery)
+
+	assert.Equal(t, domain.WebhookAttempt{At: fixedNow, StatusCode: http.StatusNoContent}, attempt)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, domain.EventMessageCreated, received.Header.Get(HeaderEvent))
+	assert.Equal(t, "delivery-id", received.Header.Get(HeaderDelivery))
+	assert.Equal(t, strconv.FormatInt(fixedNow.Unix(), 10), received.Header.Get(HeaderTimestamp))
+	assert.Equal(t, delivery.Payload, receivedBody)
+	assert.True(t, Verify("secret", received.Header.Get(HeaderTimestamp), receivedBody, received.Header.Get(HeaderSignature)))
+	assert.False(t, Verify("another-secret", received.Header.Get(HeaderTimestamp), receivedBody, received.Header.Get(HeaderSignature)))
+}
+
+func TestSend_ShouldFailWhenReceiverReturnsErrorStatus(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	webhook := domain.NewWebhook("webhook-id", domain.DefaultTenant, receiver.URL, "secret", nil, fixedNow)
+
+	sender := NewHTTPSender(newClockMock(), time.Second, true)
+	attempt := sender.Send(context.Background(), webhook, newDelivery(t, "delivery-id", webhook.ID))
+
+	assert.Equal(t, http.StatusInternalServerError, attempt.StatusCode)
+	assert.Equal(t, "unexpected status code 500", attempt.Error)
+}
+
+func TestSend_ShouldFailWhenReceiverTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer receiver.Close()
+	defer close(release)
+
+	webhook := domain.NewWebhook("webhook-id", domain.DefaultTenant, receiver.URL, "secret", nil, fixedNow)
+
+	sender := NewHTTPSender(newClockMock(), 10*time.Millisecond, true)
+	attempt := sender.Send(context.Background(), webhook, newDelivery(t, "delivery-id", webhook.ID))
+
+	assert.Zero(t, attempt.StatusCode)
+	assert.Contains(t, attempt.Error, "Client.Timeout")
+}
+
+func TestSend_ShouldRefusePrivateAddresses(t *testing.T) {
+	received := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+	defer receiver.Close()
+
+	sender := NewHTTPSender(newClockMock(), time.Second, false)
+	for _, url := range []string{receiver.URL, strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1)} {
+		webhook := domain.NewWebhook("webhook-id", domain.DefaultTenant, url, "secret", nil, fixedNow)
+		attempt := sender.Send(context.Background(), webhook, newDelivery(t, "delivery-id", webhook.ID))
+
+		assert.Contains(t, attempt.Error, errPrivateAddress.Error(), url)
+		assert.Zero(t, attempt.StatusCode)
+	}
+	assert.False(t, received)
+}
+
+func TestSend_ShouldFailWhenURLIsInvalid(t *testing.T) {
+	webhook := domain.NewWebhook("webhook-id", domain.DefaultTenant, "http://[::1", "secret", nil, fixedNow)
+
+	sender := NewHTTPSender(newClockMock(), time.Second, true)
+	attempt := sender.Send(context.Background(), webhook, newDelivery(t, "delivery-id", webhook.ID))
+
+	assert.NotEmpty(t, attempt.Error)
+}
+
+func newClockMock() *mocks.ClockMock {
+	clockMock := new(mocks.ClockMock)
+	clockMock.On("Now").Return(fixedNow)
+	return clockMock
+}
+
+func newDelivery(t *testing.T, id string, webhookID string) domain.WebhookDelivery {
+	event := domain.NewMessageCreated(domain.NewMessage("message-id", "message content"), fixedNow)
+	delivery, err := domain.NewWebhookDelivery(id, domain.NewWebhook(webhookID, domain.DefaultTenant, "http://localhost/hook", "secret", nil, fixedNow), event, fixedNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return delivery
+}
@@ -3,7 +3,15 @@ package apperrors
 import "errors"
 
 var (
-	InternalServerError = errors.New("internal_server_error")
-	InvalidInput        = errors.New("invalid_input")
-	NotFound            = errors.New("not_found")
+	Aborted              = errors.New("aborted")
+	Conflict             = errors.New("conflict")
+	Forbidden            = errors.New("forbidden")
+	InternalServerError  = errors.New("internal_server_error")
+	InvalidInput         = errors.New("invalid_input")
+	NotAcceptable        = errors.New("not_acceptable")
+	NotFound             = errors.New("not_found")
+	TooManyRequests      = errors.New("too_many_requests")
+	Unauthorized         = errors.New("unauthorized")
+	UnprocessableEntity  = errors.New("unprocessable_entity")
+	UnsupportedMediaType = errors.New("unsupported_media_type")
 )
@@ -0,0 +1,303 @@
+// Package client is a typed client of the v1 hexapi HTTP API.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+)
+
+const (
+	defaultMaxRetries = 2
+	defaultBackoff    = 100 * time.Millisecond
+	defaultMaxBackoff = 2 * time.Second
+
+	idempotencyKeyHeader = "Idempotency-Key"
+	apiKeyHeader         = "X-API-Key"
+	tenantHeader         = "X-Tenant-ID"
+)
+
+type Client struct {
+	baseURL    string
+	token      string
+	apiKey     string
+	tenant     string
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+type Option func(*Client)
+
+// WithHTTPClient replaces http.DefaultClient, e.g. to set timeouts or a
+// custom transport.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries sets how many times a request is retried after a network error
+// or a 429, 502, 503 or 504 response, waiting backoff before the first retry
+// and doubling it up to maxBackoff. A Retry-After header takes precedence.
+func WithRetries(maxRetries int, backoff time.Duration, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+		c.maxBackoff = maxBackoff
+	}
+}
+
+// WithBearerToken authenticates every request with token, a JWT accepted by
+// the server.
+func WithBearerToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithAPIKey authenticates every request with key, an API key issued by the
+// server, instead of a JWT.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithTenant sends every request to tenant, for servers hosting several
+// tenants.
+func WithTenant(tenant string) Option {
+	return func(c *Client) {
+		c.tenant = tenant
+	}
+}
+
+func New(baseURL string, options ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+		maxBackoff: defaultMaxBackoff,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+func (c *Client) CreateMessage(ctx context.Context, request CreateMessageRequest) (CreateMessageResponse, error) {
+	var response CreateMessageResponse
+	err := c.do(ctx, http.MethodPost, "/v1/message", request, &response)
+	return response, err
+}
+
+func (c *Client) GetMessage(ctx context.Context, id string) (Message, error) {
+	var response Message
+	err := c.do(ctx, http.MethodGet, "/v1/message/"+url.PathEscape(id), nil, &response)
+	return response, err
+}
+
+func (c *Client) ListMessages(ctx context.Context) ([]Message, error) {
+	var response []Message
+	err := c.do(ctx, http.MethodGet, "/v1/messages", nil, &response)
+	return response, err
+}
+
+// DeleteMessage succeeds for messages that do not exist, as the API does.
+func (c *Client) DeleteMessage(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/v1/message/"+url.PathEscape(id), nil, nil)
+}
+
+// CreateMessages returns the results along with an UnprocessableEntity error
+// when an atomic batch was aborted.
+func (c *Client) CreateMessages(ctx context.Context, request BatchCreateMessagesRequest) (BatchMessagesResponse, error) {
+	var response BatchMessagesResponse
+	err := c.do(ctx, http.MethodPost, "/v1/messages:batch", request, &response)
+	return response, err
+}
+
+// DeleteMessages returns the results along with an UnprocessableEntity error
+// when an atomic batch was aborted.
+func (c *Client) DeleteMessages(ctx context.Context, request BatchDeleteMessagesRequest) (BatchMessagesResponse, error) {
+	var response BatchMessagesResponse
+	err := c.do(ctx, http.MethodDelete, "/v1/messages:batch", request, &response)
+	return response, err
+}
+
+// do retries with the same Idempotency-Key on POST, so that a retried create
+// is replayed by the API instead of creating the message twice.
+func (c *Client) do(ctx context.Context, method string, path string, body any, response any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	header := http.Header{}
+	if body != nil {
+		header.Set("Content-Type", "application/json")
+	}
+	if method == http.MethodPost {
+		header.Set(idempotencyKeyHeader, uuid.NewString())
+	}
+	if c.token != "" {
+		header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.apiKey != "" {
+		header.Set(apiKeyHeader, c.apiKey)
+	}
+	if c.tenant != "" {
+		header.Set(tenantHeader, c.tenant)
+	}
+
+	for attempt := 0; ; attempt++ {
+		request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		request.Header = header.Clone()
+
+		httpResponse, err := c.httpClient.Do(request)
+		if err == nil && !retryable(httpResponse.StatusCode) {
+			return decode(httpResponse, response)
+		}
+		if attempt == c.maxRetries || ctx.Err() != nil {
+			if err != nil {
+				return err
+			}
+			return decode(httpResponse, response)
+		}
+
+		delay := c.retryDelay(attempt, httpResponse)
+		if httpResponse != nil {
+			_, _ = io.Copy(io.Discard, httpResponse.Body)
+			httpResponse.Body.Close()
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) retryDelay(attempt int, response *http.Response) time.Duration {
+	if response != nil {
+		if seconds, err := strconv.Atoi(response.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	delay := c.backoff
+	for i := 0; i < attempt && delay < c.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, c.maxBackoff)
+}
+
+func retryable(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func decode(httpResponse *http.Response, response any) error {
+	defer httpResponse.Body.Close()
+
+	payload, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+
+	if httpResponse.StatusCode >= 400 {
+		// An aborted batch still describes every item.
+		if httpResponse.StatusCode == http.StatusUnprocessableEntity && response != nil {
+			_ = json.Unmarshal(payload, response)
+		}
+		return newError(httpResponse.StatusCode, payload)
+	}
+	if response == nil || len(payload) == 0 {
+		return nil
+	}
+	return json.Unmarshal(payload, response)
+}
+
+// Error is returned for every response with an error status. It unwraps to
+// the apperrors value matching the status, so callers can use errors.Is.
+// Violations lists the fields of a request rejected by the API contract.
+type Error struct {
+	StatusCode int
+	Message    string
+	Violations []Violation
+}
+
+func newError(statusCode int, payload []byte) *Error {
+	var body errorResponse
+	_ = json.Unmarshal(payload, &body)
+	if body.Error == "" {
+		body.Error = strconv.Itoa(statusCode) + " " + http.StatusText(statusCode)
+	}
+
+	return &Error{
+		StatusCode: statusCode,
+		Message:    body.Error,
+		Violations: body.Violations,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusBadRequest:
+		return apperrors.InvalidInput
+	case http.StatusUnauthorized:
+		return apperrors.Unauthorized
+	case http.StatusForbidden:
+		return apperrors.Forbidden
+	case http.StatusNotFound:
+		return apperrors.NotFound
+	case http.StatusNotAcceptable:
+		return apperrors.NotAcceptable
+	case http.StatusConflict:
+		return apperrors.Conflict
+	case http.StatusUnsupportedMediaType:
+		return apperrors.UnsupportedMediaType
+	case http.StatusUnprocessableEntity:
+		return apperrors.UnprocessableEntity
+	case http.StatusTooManyRequests:
+		return apperrors.TooManyRequests
+	case http.StatusInternalServerError:
+		return apperrors.InternalServerError
+	default:
+		return nil
+	}
+}
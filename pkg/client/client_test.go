@@ -0,0 +1,285 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hiago-balbino/hex-architecture-template/internal/config"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/dto"
+	"github.com/hiago-balbino/hex-architecture-template/internal/handlers"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMessage_ShouldCreateMessageThatCanBeFetched(t *testing.T) {
+	ctx := context.Background()
+	client := New(setupServer(t, nil).URL)
+
+	created, err := client.CreateMessage(ctx, CreateMessageRequest{Content: "message content"})
+	assert.NoError(t, err)
+
+	message, err := client.GetMessage(ctx, created.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, Message{ID: created.ID, Content: "message content"}, message)
+
+	messages, err := client.ListMessages(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []Message{message}, messages)
+}
+
+func TestGetMessage_ShouldReturnNotFoundError(t *testing.T) {
+	client := New(setupServer(t, nil).URL)
+
+	_, err := client.GetMessage(context.Background(), uuid.NewString())
+
+	var clientErr *Error
+	assert.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, http.StatusNotFound, clientErr.StatusCode)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestDeleteMessage_ShouldRemoveMessage(t *testing.T) {
+	ctx := context.Background()
+	client := New(setupServer(t, nil).URL)
+
+	created, err := client.CreateMessage(ctx, CreateMessageRequest{Content: "message content"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.DeleteMessage(ctx, created.ID))
+	_, err = client.GetMessage(ctx, created.ID)
+	assert.ErrorIs(t, err, apperrors.NotFound)
+}
+
+func TestCreateMessages_ShouldReturnInvalidInputWhenModeIsUnknown(t *testing.T) {
+	client := New(setupServer(t, nil).URL)
+
+	_, err := client.CreateMessages(context.Background(), BatchCreateMessagesRequest{
+		Mode:     "eventually",
+		Messages: []CreateMessageRequest{{Content: "message content"}},
+	})
+
+	assert.ErrorIs(t, err, apperrors.InvalidInput)
+	var clientErr *Error
+	if assert.ErrorAs(t, err, &clientErr) {
+		assert.Equal(t, []Violation{{In: "body", Field: "/mode", Message: "must be one of atomic, best_effort"}}, clientErr.Violations)
+	}
+}
+
+func TestDeleteMessages_ShouldReturnResultsWhenAtomicBatchIsAborted(t *testing.T) {
+	ctx := context.Background()
+	client := New(setupServer(t, nil).URL)
+
+	created, err := client.CreateMessage(ctx, CreateMessageRequest{Content: "message content"})
+	assert.NoError(t, err)
+
+	response, err := client.DeleteMessages(ctx, BatchDeleteMessagesRequest{
+		Mode: string(domain.BatchModeAtomic),
+		IDs:  []string{created.ID, "missing-id"},
+	})
+
+	assert.ErrorIs(t, err, apperrors.UnprocessableEntity)
+	assert.Equal(t, BatchItemStatusAborted, response.Results[0].Status)
+	assert.Equal(t, BatchItemStatusFailed, response.Results[1].Status)
+}
+
+func TestCreateMessage_ShouldRetryWithSameIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+
+	// The first response is lost after the message was created.
+	var requests atomic.Int32
+	server := setupServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost && requests.Add(1) == 1 {
+				next.ServeHTTP(httptest.NewRecorder(), r)
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	client := New(server.URL, WithRetries(2, time.Millisecond, time.Millisecond))
+
+	_, err := client.CreateMessage(ctx, CreateMessageRequest{Content: "message content"})
+	assert.NoError(t, err)
+
+	messages, err := client.ListMessages(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestListMessages_ShouldReturnLastErrorWhenRetriesAreExhausted(t *testing.T) {
+	var requests atomic.Int32
+	server := setupServer(t, func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		})
+	})
+	client := New(server.URL, WithRetries(2, time.Millisecond, time.Millisecond))
+
+	_, err := client.ListMessages(context.Background())
+
+	var clientErr *Error
+	assert.ErrorAs(t, err, &clientErr)
+	assert.Equal(t, http.StatusBadGateway, clientErr.StatusCode)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestListMessages_ShouldStopRetryingWhenContextIsDone(t *testing.T) {
+	server := setupServer(t, func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+	})
+	client := New(server.URL, WithRetries(5, time.Millisecond, time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.ListMessages(ctx)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestNew_ShouldUseGivenHTTPClient(t *testing.T) {
+	var header string
+	server := setupServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Get("X-Test")
+			next.ServeHTTP(w, r)
+		})
+	})
+	httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		r.Header.Set("X-Test", "custom")
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+
+	_, err := New(server.URL, WithHTTPClient(httpClient)).ListMessages(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "custom", header)
+}
+
+func TestListMessages_ShouldAuthenticateWithBearerToken(t *testing.T) {
+	secret := "0123456789abcdef0123456789abcdef"
+	t.Setenv("HEXAPI_JWT_SECRET", secret)
+	server := setupServer(t, nil)
+
+	_, err := New(server.URL).ListMessages(context.Background())
+	assert.ErrorIs(t, err, apperrors.Unauthorized)
+
+	messages, err := New(server.URL, WithBearerToken(signToken(t, secret))).ListMessages(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestListMessages_ShouldSendAPIKeyAndTenant(t *testing.T) {
+	var apiKey, tenant string
+	server := setupServer(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey = r.Header.Get("X-API-Key")
+			tenant = r.Header.Get("X-Tenant-ID")
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	_, err := New(server.URL, WithAPIKey("key"), WithTenant("acme")).ListMessages(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "key", apiKey)
+	assert.Equal(t, "acme", tenant)
+}
+
+func TestListMessages_ShouldReturnTooManyRequestsWhenRateLimited(t *testing.T) {
+	server := setupServer(t, func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+	})
+
+	_, err := New(server.URL, WithRetries(0, time.Millisecond, time.Millisecond)).ListMessages(context.Background())
+
+	assert.ErrorIs(t, err, apperrors.TooManyRequests)
+}
+
+func TestTypes_ShouldMatchBodiesOfServer(t *testing.T) {
+	bodies := []struct {
+		server any
+		client any
+	}{
+		{dto.CreateMessageRequest{Content: "content"}, &CreateMessageRequest{}},
+		{dto.CreateMessageResponse{ID: "id"}, &CreateMessageResponse{}},
+		{dto.GetMessageResponse{ID: "id", Content: "content"}, &Message{}},
+		{dto.BatchCreateMessagesRequest{Mode: "atomic", Messages: []dto.CreateMessageRequest{{Content: "content"}}}, &BatchCreateMessagesRequest{}},
+		{dto.BatchDeleteMessagesRequest{Mode: "atomic", IDs: []string{"id"}}, &BatchDeleteMessagesRequest{}},
+		{dto.BatchMessagesResponse{Mode: "atomic", Results: []dto.BatchItemResponse{{Index: 1, ID: "id", Status: dto.BatchItemStatusFailed, Error: "error"}}}, &BatchMessagesResponse{}},
+		{dto.ErrorResponse{Error: "error", Violations: []dto.ViolationResponse{{In: "body", Field: "/mode", Message: "message"}}}, &errorResponse{}},
+	}
+
+	for _, body := range bodies {
+		serverJSON, err := json.Marshal(body.server)
+		assert.NoError(t, err)
+		decoder := json.NewDecoder(bytes.NewReader(serverJSON))
+		decoder.DisallowUnknownFields()
+		assert.NoError(t, decoder.Decode(body.client))
+		clientJSON, err := json.Marshal(body.client)
+		assert.NoError(t, err)
+		assert.JSONEq(t, string(serverJSON), string(clientJSON))
+	}
+	assert.Equal(t, []string{dto.BatchItemStatusCreated, dto.BatchItemStatusDeleted, dto.BatchItemStatusFailed, dto.BatchItemStatusAborted},
+		[]string{BatchItemStatusCreated, BatchItemStatusDeleted, BatchItemStatusFailed, BatchItemStatusAborted})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func setupServer(t *testing.T, wrap func(http.Handler) http.Handler) *httptest.Server {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Storage = config.StorageMemory
+	cfg.ValidateResponses = true
+
+	api, err := handlers.NewServer(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := api.Handler()
+	if wrap != nil {
+		handler = wrap(handler)
+	}
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func signToken(t *testing.T, secret string) string {
+	header, err := json.Marshal(map[string]any{"alg": "HS256"})
+	assert.NoError(t, err)
+	claims, err := json.Marshal(map[string]any{"sub": "user-1", "scope": "reader", "exp": time.Now().Add(time.Hour).Unix()})
+	assert.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
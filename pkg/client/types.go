@@ -0,0 +1,59 @@
+package client
+
+// The request and response bodies of the v1 API. They are declared here
+// rather than taken from the server, whose types cannot be imported from
+// other modules.
+
+const (
+	BatchItemStatusCreated = "created"
+	BatchItemStatusDeleted = "deleted"
+	BatchItemStatusFailed  = "failed"
+	BatchItemStatusAborted = "aborted"
+)
+
+type CreateMessageRequest struct {
+	Content string `json:"content"`
+}
+
+type CreateMessageResponse struct {
+	ID string `json:"id"`
+}
+
+type Message struct {
+	ID      string `json:"id"`
+	Content string `json:"content"`
+}
+
+type BatchCreateMessagesRequest struct {
+	Mode     string                 `json:"mode,omitempty"`
+	Messages []CreateMessageRequest `json:"messages"`
+}
+
+type BatchDeleteMessagesRequest struct {
+	Mode string   `json:"mode,omitempty"`
+	IDs  []string `json:"ids"`
+}
+
+type BatchMessagesResponse struct {
+	Mode    string              `json:"mode"`
+	Results []BatchItemResponse `json:"results"`
+}
+
+type BatchItemResponse struct {
+	Index  int    `json:"index"`
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Violation is a part of a request rejected by the API contract.
+type Violation struct {
+	In      string `json:"in"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type errorResponse struct {
+	Error      string      `json:"error"`
+	Violations []Violation `json:"violations,omitempty"`
+}
@@ -0,0 +1,17 @@
+package clock
+
+import "time"
+
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func NewSystemClock() Clock {
+	return systemClock{}
+}
+
+func (s systemClock) Now() time.Time {
+	return time.Now().UTC()
+}
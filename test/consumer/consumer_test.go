@@ -0,0 +1,47 @@
+// Package consumer is a module of its own that uses the client as any other
+// module would, so that the client cannot come to depend on internal packages.
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hiago-balbino/hex-architecture-template/pkg/apperrors"
+	"github.com/hiago-balbino/hex-architecture-template/pkg/client"
+)
+
+func TestClient_ShouldBeUsableFromAnotherModule(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v1/message":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "message-id"})
+		case "GET /v1/message/message-id":
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "message-id", "content": "message content"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "not found"})
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := client.New(server.URL)
+
+	created, err := c.CreateMessage(ctx, client.CreateMessageRequest{Content: "message content"})
+	if err != nil || created.ID != "message-id" {
+		t.Fatalf("CreateMessage() = %v, %v", created, err)
+	}
+	message, err := c.GetMessage(ctx, created.ID)
+	if err != nil || message != (client.Message{ID: "message-id", Content: "message content"}) {
+		t.Fatalf("GetMessage() = %v, %v", message, err)
+	}
+	if _, err := c.GetMessage(ctx, "unknown-id"); !errors.Is(err, apperrors.NotFound) {
+		t.Fatalf("GetMessage() error = %v, want %v", err, apperrors.NotFound)
+	}
+}
@@ -0,0 +1,9 @@
+module example.com/hexapi-consumer
+
+go 1.21.0
+
+require github.com/hiago-balbino/hex-architecture-template v0.0.0
+
+require github.com/google/uuid v1.3.1 // indirect
+
+replace github.com/hiago-balbino/hex-architecture-template => ../..
@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type APIKeyRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *APIKeyRepositoryMock) Save(ctx context.Context, key domain.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *APIKeyRepositoryMock) GetByID(ctx context.Context, id string) (domain.APIKey, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.APIKey), args.Error(1)
+}
+
+func (m *APIKeyRepositoryMock) GetAll(ctx context.Context) ([]domain.APIKey, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *APIKeyRepositoryMock) Touch(ctx context.Context, id string, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+	return args.Error(0)
+}
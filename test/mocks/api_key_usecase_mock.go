@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type APIKeyUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *APIKeyUseCaseMock) Create(ctx context.Context, name string, scopes []string, expiresAt time.Time) (domain.APIKey, string, error) {
+	args := m.Called(ctx, name, scopes, expiresAt)
+	return args.Get(0).(domain.APIKey), args.String(1), args.Error(2)
+}
+
+func (m *APIKeyUseCaseMock) GetAll(ctx context.Context) ([]domain.APIKey, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *APIKeyUseCaseMock) Revoke(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *APIKeyUseCaseMock) Authenticate(ctx context.Context, secret string) (domain.Principal, error) {
+	args := m.Called(ctx, secret)
+	return args.Get(0).(domain.Principal), args.Error(1)
+}
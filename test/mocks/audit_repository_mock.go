@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type AuditRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AuditRepositoryMock) Append(ctx context.Context, entry domain.AuditEntry) (domain.AuditEntry, error) {
+	args := m.Called(ctx, entry)
+	return args.Get(0).(domain.AuditEntry), args.Error(1)
+}
+
+// ForEach calls fn with the entries given to Return before returning its
+// error.
+func (m *AuditRepositoryMock) ForEach(ctx context.Context, messageID string, fn func(entry domain.AuditEntry) error) error {
+	args := m.Called(ctx, messageID)
+	for _, entry := range args.Get(0).([]domain.AuditEntry) {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ClockMock struct {
+	mock.Mock
+}
+
+func (c *ClockMock) Now() time.Time {
+	args := c.Called()
+	return args.Get(0).(time.Time)
+}
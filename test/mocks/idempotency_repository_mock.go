@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type IdempotencyRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *IdempotencyRepositoryMock) Save(ctx context.Context, record domain.IdempotencyRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *IdempotencyRepositoryMock) GetByKey(ctx context.Context, key string) (domain.IdempotencyRecord, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(domain.IdempotencyRecord), args.Error(1)
+}
@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MessagePolicyMock struct {
+	mock.Mock
+}
+
+func (m *MessagePolicyMock) Authorize(ctx context.Context, action domain.Action) error {
+	args := m.Called(ctx, action)
+	return args.Error(0)
+}
+
+func (m *MessagePolicyMock) AuthorizeMessage(ctx context.Context, action domain.Action, message domain.Message) error {
+	args := m.Called(ctx, action, message)
+	return args.Error(0)
+}
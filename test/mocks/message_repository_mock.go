@@ -2,8 +2,10 @@ package mocks
 
 import (
 	"context"
+	"errors"
 
 	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -16,17 +18,45 @@ func (m *MessageRepositoryMock) Save(ctx context.Context, message domain.Message
 	return args.Error(0)
 }
 
+func (m *MessageRepositoryMock) SaveBatch(ctx context.Context, messages []domain.Message, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	args := m.Called(ctx, messages, mode)
+	return args.Get(0).([]domain.BatchResult), args.Error(1)
+}
+
 func (m *MessageRepositoryMock) GetByID(ctx context.Context, id string) (domain.Message, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(domain.Message), args.Error(1)
 }
 
-func (m *MessageRepositoryMock) GetAll(ctx context.Context) ([]domain.Message, error) {
+// ForEach calls fn with the messages given to Return that sort after after
+// before returning its error.
+func (m *MessageRepositoryMock) ForEach(ctx context.Context, after string, fn func(message domain.Message) error) error {
 	args := m.Called(ctx)
-	return args.Get(0).([]domain.Message), args.Error(1)
+	return forEach(args.Get(0).([]domain.Message), after, fn, args.Error(1))
 }
 
 func (m *MessageRepositoryMock) DeleteByID(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MessageRepositoryMock) DeleteBatch(ctx context.Context, ids []string, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	args := m.Called(ctx, ids, mode)
+	return args.Get(0).([]domain.BatchResult), args.Error(1)
+}
+
+// forEach follows the ports.MessageRepository contract of ForEach.
+func forEach(messages []domain.Message, after string, fn func(message domain.Message) error, err error) error {
+	for _, message := range messages {
+		if after != "" && message.ID <= after {
+			continue
+		}
+		if fnErr := fn(message); fnErr != nil {
+			if errors.Is(fnErr, ports.ErrStopIteration) {
+				return nil
+			}
+			return fnErr
+		}
+	}
+	return err
+}
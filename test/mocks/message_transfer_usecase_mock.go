@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MessageTransferUseCaseMock struct {
+	mock.Mock
+}
+
+// Export calls fn with the messages given to Return before returning its
+// error.
+func (m *MessageTransferUseCaseMock) Export(ctx context.Context, fn func(message domain.Message) error) error {
+	args := m.Called(ctx)
+	return forEach(args.Get(0).([]domain.Message), "", fn, args.Error(1))
+}
+
+// Import reads every record from next, so expectations are set on the records
+// instead of the function.
+func (m *MessageTransferUseCaseMock) Import(ctx context.Context, next func() (domain.ImportRecord, error), options domain.ImportOptions) (domain.ImportReport, error) {
+	var records []domain.ImportRecord
+	for {
+		record, err := next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return domain.ImportReport{}, err
+		}
+		records = append(records, record)
+	}
+
+	args := m.Called(ctx, records, options)
+	return args.Get(0).(domain.ImportReport), args.Error(1)
+}
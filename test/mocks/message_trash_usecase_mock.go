@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type MessageTrashUseCaseMock struct {
+	mock.Mock
+}
+
+// ForEachTrashed calls fn with the messages given to Return before returning
+// its error.
+func (m *MessageTrashUseCaseMock) ForEachTrashed(ctx context.Context, fn func(message domain.Message) error) error {
+	args := m.Called(ctx)
+	return forEach(args.Get(0).([]domain.Message), "", fn, args.Error(1))
+}
+
+func (m *MessageTrashUseCaseMock) Restore(ctx context.Context, id string) (domain.Message, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Message), args.Error(1)
+}
+
+func (m *MessageTrashUseCaseMock) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	args := m.Called(ctx, deletedBefore)
+	return args.Int(0), args.Error(1)
+}
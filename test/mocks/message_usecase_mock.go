@@ -16,6 +16,11 @@ func (m *MessageUseCaseMock) Save(ctx context.Context, content string) (domain.M
 	return args.Get(0).(domain.Message), args.Error(1)
 }
 
+func (m *MessageUseCaseMock) SaveBatch(ctx context.Context, contents []string, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	args := m.Called(ctx, contents, mode)
+	return args.Get(0).([]domain.BatchResult), args.Error(1)
+}
+
 func (m *MessageUseCaseMock) GetByID(ctx context.Context, id string) (domain.Message, error) {
 	args := m.Called(ctx, id)
 	return args.Get(0).(domain.Message), args.Error(1)
@@ -26,7 +31,19 @@ func (m *MessageUseCaseMock) GetAll(ctx context.Context) ([]domain.Message, erro
 	return args.Get(0).([]domain.Message), args.Error(1)
 }
 
+// ForEach calls fn with the messages given to Return that sort after after
+// before returning its error.
+func (m *MessageUseCaseMock) ForEach(ctx context.Context, after string, fn func(message domain.Message) error) error {
+	args := m.Called(ctx)
+	return forEach(args.Get(0).([]domain.Message), after, fn, args.Error(1))
+}
+
 func (m *MessageUseCaseMock) DeleteByID(ctx context.Context, id string) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MessageUseCaseMock) DeleteBatch(ctx context.Context, ids []string, mode domain.BatchMode) ([]domain.BatchResult, error) {
+	args := m.Called(ctx, ids, mode)
+	return args.Get(0).([]domain.BatchResult), args.Error(1)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type OutboxRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *OutboxRepositoryMock) Append(ctx context.Context, entries ...domain.OutboxEntry) error {
+	args := m.Called(ctx, entries)
+	return args.Error(0)
+}
+
+func (m *OutboxRepositoryMock) Pending(ctx context.Context, limit int) ([]domain.OutboxEntry, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]domain.OutboxEntry), args.Error(1)
+}
+
+func (m *OutboxRepositoryMock) MarkDispatched(ctx context.Context, sequence uint64) error {
+	args := m.Called(ctx, sequence)
+	return args.Error(0)
+}
+
+func (m *OutboxRepositoryMock) MarkFailed(ctx context.Context, sequence uint64, reason string, deadLetter bool) error {
+	args := m.Called(ctx, sequence, reason, deadLetter)
+	return args.Error(0)
+}
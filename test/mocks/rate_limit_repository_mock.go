@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type RateLimitRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RateLimitRepositoryMock) Take(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitDecision, error) {
+	args := m.Called(ctx, key, limit)
+	return args.Get(0).(domain.RateLimitDecision), args.Error(1)
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+)
+
+type RecordingEventPublisher struct {
+	Err     error
+	mu      sync.Mutex
+	events  []domain.Event
+	tenants []string
+}
+
+func (r *RecordingEventPublisher) Publish(ctx context.Context, events ...domain.Event) error {
+	if r.Err != nil {
+		return r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, events...)
+	for range events {
+		r.tenants = append(r.tenants, domain.TenantFromContext(ctx))
+	}
+	return nil
+}
+
+func (r *RecordingEventPublisher) Events() []domain.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]domain.Event(nil), r.events...)
+}
+
+// Tenants are those of the published events, in the same order.
+func (r *RecordingEventPublisher) Tenants() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.tenants...)
+}
@@ -0,0 +1,16 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type TenantQuotasMock struct {
+	mock.Mock
+}
+
+func (m *TenantQuotasMock) DailyMessages(ctx context.Context) int {
+	args := m.Called(ctx)
+	return args.Int(0)
+}
@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/ports"
+	"github.com/stretchr/testify/mock"
+)
+
+type UnitOfWorkMock struct {
+	mock.Mock
+	Repositories ports.Repositories
+}
+
+func (m *UnitOfWorkMock) Do(ctx context.Context, fn func(ctx context.Context, repositories ports.Repositories) error) error {
+	args := m.Called(ctx)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(ctx, m.Repositories)
+}
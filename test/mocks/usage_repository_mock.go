@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type UsageRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *UsageRepositoryMock) Add(ctx context.Context, day time.Time, count int) (int, error) {
+	args := m.Called(ctx, day, count)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type WebhookRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *WebhookRepositoryMock) Save(ctx context.Context, webhook domain.Webhook) error {
+	args := m.Called(ctx, webhook)
+	return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) GetByID(ctx context.Context, id string) (domain.Webhook, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) DeleteByID(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) SaveDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) GetDelivery(ctx context.Context, webhookID string, id string) (domain.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, id)
+	return args.Get(0).(domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) GetDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
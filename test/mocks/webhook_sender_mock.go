@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type WebhookSenderMock struct {
+	mock.Mock
+}
+
+func (m *WebhookSenderMock) Send(ctx context.Context, webhook domain.Webhook, delivery domain.WebhookDelivery) domain.WebhookAttempt {
+	args := m.Called(ctx, webhook, delivery)
+	return args.Get(0).(domain.WebhookAttempt)
+}
@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/hiago-balbino/hex-architecture-template/internal/core/domain"
+	"github.com/stretchr/testify/mock"
+)
+
+type WebhookUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *WebhookUseCaseMock) Create(ctx context.Context, url string, events []string, secret string) (domain.Webhook, error) {
+	args := m.Called(ctx, url, events, secret)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+func (m *WebhookUseCaseMock) GetByID(ctx context.Context, id string) (domain.Webhook, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+func (m *WebhookUseCaseMock) GetAll(ctx context.Context) ([]domain.Webhook, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *WebhookUseCaseMock) DeleteByID(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *WebhookUseCaseMock) GetDeliveries(ctx context.Context, webhookID string) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *WebhookUseCaseMock) Redeliver(ctx context.Context, webhookID string, deliveryID string) (domain.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, deliveryID)
+	return args.Get(0).(domain.WebhookDelivery), args.Error(1)
+}